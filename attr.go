@@ -0,0 +1,259 @@
+package rope
+
+import (
+	"maps"
+
+	"github.com/reusee/rope/internal/avl"
+)
+
+// Attrs is a set of attributes of a text range, like style, link or language.
+// Attrs are compared by content and must not be modified after use
+type Attrs map[string]string
+
+// AttrRope is a rope with attributes on ranges.
+// Text is the rope of bytes, attributes are runs in a companion AVL tree weighted by byte lengths.
+// Adjacent runs with equal attributes are merged. The zero value is empty
+type AttrRope struct {
+	Text  *Rope
+	spans *spanNode
+}
+
+// NewAttrRope returns text with attrs on all bytes
+func NewAttrRope(text *Rope, attrs Attrs) AttrRope {
+	return AttrRope{
+		Text:  text,
+		spans: newSpanLeaf(text.Len(), attrs),
+	}
+}
+
+func (a AttrRope) Len() int {
+	return a.Text.Len()
+}
+
+func (a AttrRope) Concat(b AttrRope) AttrRope {
+	return AttrRope{
+		Text:  a.Text.Concat(b.Text),
+		spans: a.spans.join(b.spans),
+	}
+}
+
+func (a AttrRope) Split(n int) (AttrRope, AttrRope) {
+	t1, t2 := a.Text.Split(n)
+	s1, s2 := a.spans.split(n)
+	return AttrRope{t1, s1}, AttrRope{t2, s2}
+}
+
+// Insert inserts bs with attrs at n
+func (a AttrRope) Insert(n int, bs []byte, attrs Attrs) AttrRope {
+	return a.InsertRope(n, NewAttrRope(NewFromBytes(bs), attrs))
+}
+
+func (a AttrRope) InsertRope(n int, b AttrRope) AttrRope {
+	a1, a2 := a.Split(n)
+	return a1.Concat(b).Concat(a2)
+}
+
+func (a AttrRope) Delete(n, l int) AttrRope {
+	a1, a2 := a.Split(n)
+	_, a2 = a2.Split(l)
+	return a1.Concat(a2)
+}
+
+// SetAttrs replaces attributes of bytes in [from, to) with attrs
+func (a AttrRope) SetAttrs(from, to int, attrs Attrs) AttrRope {
+	return a.UpdateAttrs(from, to, func(Attrs) Attrs {
+		return attrs
+	})
+}
+
+// MergeAttrs sets attributes in attrs on bytes in [from, to), keeping other attributes.
+// Empty values remove the attributes
+func (a AttrRope) MergeAttrs(from, to int, attrs Attrs) AttrRope {
+	return a.UpdateAttrs(from, to, func(old Attrs) Attrs {
+		ret := maps.Clone(old)
+		if ret == nil {
+			ret = make(Attrs)
+		}
+		for k, v := range attrs {
+			if v == "" {
+				delete(ret, k)
+			} else {
+				ret[k] = v
+			}
+		}
+		return ret
+	})
+}
+
+// UpdateAttrs replaces attributes of each run in [from, to) with the result of fn
+func (a AttrRope) UpdateAttrs(from, to int, fn func(Attrs) Attrs) AttrRope {
+	if from >= to {
+		return a
+	}
+	s1, s2 := a.spans.split(from)
+	s2, s3 := s2.split(to - from)
+	var updated *spanNode
+	s2.iter(0, func(_, length int, attrs Attrs) bool {
+		updated = updated.join(newSpanLeaf(length, fn(attrs)))
+		return true
+	})
+	return AttrRope{
+		Text:  a.Text,
+		spans: s1.join(updated).join(s3),
+	}
+}
+
+// AttrsAt returns attributes of the byte at i
+func (a AttrRope) AttrsAt(i int) (ret Attrs) {
+	a.spans.iter(i, func(_, _ int, attrs Attrs) bool {
+		ret = attrs
+		return false
+	})
+	return
+}
+
+// IterSpans calls fn with runs of text in [from, to) and their attributes, in order.
+// text is a copy
+func (a AttrRope) IterSpans(from, to int, fn func(offset int, text []byte, attrs Attrs) bool) bool {
+	if from >= to {
+		return true
+	}
+	return a.spans.iter(from, func(start, length int, attrs Attrs) bool {
+		if start >= to {
+			return false
+		}
+		end := min(start+length, to)
+		start = max(start, from)
+		return fn(start, a.Text.Sub(start, end-start), attrs)
+	})
+}
+
+// spanNode is a node of the AVL tree of attribute runs, balanced by package avl like Rope
+type spanNode struct {
+	left   *spanNode
+	right  *spanNode
+	attrs  Attrs // of leaf
+	height int
+	weight int // length of the run for leaves
+}
+
+func newSpanLeaf(length int, attrs Attrs) *spanNode {
+	if length == 0 {
+		return nil
+	}
+	return &spanNode{
+		attrs:  attrs,
+		height: 1,
+		weight: length,
+	}
+}
+
+func newSpanNode(left, right *spanNode) *spanNode {
+	return &spanNode{
+		left:   left,
+		right:  right,
+		height: max(left.height, right.height) + 1,
+		weight: left.len(),
+	}
+}
+
+func (s *spanNode) len() int {
+	if s == nil {
+		return 0
+	}
+	return s.weight + s.right.len()
+}
+
+// join concats s and s2, merging runs at the joint if attributes are equal
+func (s *spanNode) join(s2 *spanNode) *spanNode {
+	if s == nil || s2 == nil {
+		return s.concat(s2)
+	}
+	last, first := s.lastLeaf(), s2.firstLeaf()
+	if !maps.Equal(last.attrs, first.attrs) {
+		return s.concat(s2)
+	}
+	s, _ = s.split(s.len() - last.weight)
+	_, s2 = s2.split(first.weight)
+	return s.concat(newSpanLeaf(last.weight+first.weight, last.attrs)).concat(s2)
+}
+
+func (s *spanNode) firstLeaf() *spanNode {
+	for s.left != nil {
+		s = s.left
+	}
+	return s
+}
+
+func (s *spanNode) lastLeaf() *spanNode {
+	for s.right != nil {
+		s = s.right
+	}
+	return s
+}
+
+func (s *spanNode) concat(s2 *spanNode) *spanNode {
+	return avl.Concat(spanTree{}, s, s2)
+}
+
+func (s *spanNode) split(n int) (out1, out2 *spanNode) {
+	return avl.Split(spanTree{}, s, n)
+}
+
+type spanTree struct{}
+
+func (spanTree) Height(s *spanNode) int {
+	return s.height
+}
+
+func (spanTree) Children(s *spanNode) (*spanNode, *spanNode) {
+	return s.left, s.right
+}
+
+func (spanTree) Node(left, right *spanNode) *spanNode {
+	return newSpanNode(left, right)
+}
+
+func (spanTree) Cut(s *spanNode, n int) (*spanNode, *spanNode, bool) {
+	if n <= 0 {
+		return nil, s, true
+	}
+	if n >= s.len() {
+		return s, nil, true
+	}
+	if s.left == nil { // leaf, split the run
+		return newSpanLeaf(n, s.attrs), newSpanLeaf(s.weight-n, s.attrs), true
+	}
+	return nil, nil, false
+}
+
+func (spanTree) Descend(s, _, _ *spanNode, n int) (bool, int) {
+	if n >= s.weight { // at right subtree
+		return true, n - s.weight
+	}
+	return false, n
+}
+
+// iter calls fn with runs containing or after offset, with their start offsets
+func (s *spanNode) iter(offset int, fn func(start, length int, attrs Attrs) bool) bool {
+	return s.iterFrom(0, offset, fn)
+}
+
+func (s *spanNode) iterFrom(base, offset int, fn func(start, length int, attrs Attrs) bool) bool {
+	if s == nil {
+		return true
+	}
+	if s.left == nil { // leaf
+		if offset < s.weight {
+			return fn(base, s.weight, s.attrs)
+		}
+		return true
+	}
+	if offset < s.weight {
+		if !s.left.iterFrom(base, offset, fn) {
+			return false
+		}
+		return s.right.iterFrom(base+s.weight, 0, fn)
+	}
+	return s.right.iterFrom(base+s.weight, offset-s.weight, fn)
+}
@@ -0,0 +1,144 @@
+package rope
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	mrand "math/rand"
+	"testing"
+)
+
+func checkSpans(s *spanNode) error {
+	if s == nil {
+		return nil
+	}
+	if s.left == nil {
+		if s.right != nil || s.height != 1 || s.weight <= 0 {
+			return fmt.Errorf("bad leaf")
+		}
+		return nil
+	}
+	if s.right == nil || s.weight != s.left.len() || s.height != max(s.left.height, s.right.height)+1 {
+		return fmt.Errorf("bad node")
+	}
+	if diff := s.left.height - s.right.height; diff > 1 || diff < -1 {
+		return fmt.Errorf("not balanced")
+	}
+	if err := checkSpans(s.left); err != nil {
+		return err
+	}
+	return checkSpans(s.right)
+}
+
+func TestAttrRope(t *testing.T) {
+	styles := []Attrs{
+		nil,
+		{"style": "bold"},
+		{"style": "italic"},
+		{"link": "http://example.com"},
+	}
+	// attributes of each byte
+	var bs []byte
+	var attrs []Attrs
+	var a AttrRope
+
+	check := func() {
+		if a.Len() != len(bs) || a.spans.len() != len(bs) {
+			t.Fatal()
+		}
+		if err := checkSpans(a.spans); err != nil {
+			t.Fatal(err)
+		}
+		from := mrand.Intn(len(bs) + 1)
+		to := from + mrand.Intn(len(bs)-from+1)
+		var text []byte
+		var last Attrs
+		first := true
+		offset := from
+		a.IterSpans(from, to, func(o int, run []byte, runAttrs Attrs) bool {
+			if o != offset || len(run) == 0 {
+				t.Fatal()
+			}
+			offset += len(run)
+			if !first && maps.Equal(last, runAttrs) {
+				t.Fatal("not merged")
+			}
+			first = false
+			last = runAttrs
+			for i := range run {
+				if !maps.Equal(attrs[o+i], runAttrs) {
+					t.Fatal()
+				}
+			}
+			text = append(text, run...)
+			return true
+		})
+		if !bytes.Equal(text, bs[from:to]) {
+			t.Fatal()
+		}
+		if len(bs) > 0 {
+			i := mrand.Intn(len(bs))
+			if !maps.Equal(a.AttrsAt(i), attrs[i]) {
+				t.Fatal()
+			}
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		n := mrand.Intn(len(bs) + 1)
+		switch mrand.Intn(5) {
+		case 0, 1: // insert
+			ins := getRandomBytes(mrand.Intn(10) + 1)
+			style := styles[mrand.Intn(len(styles))]
+			a = a.Insert(n, ins, style)
+			bs = bytes.Join([][]byte{bs[:n], ins, bs[n:]}, nil)
+			insAttrs := make([]Attrs, len(ins))
+			for i := range insAttrs {
+				insAttrs[i] = style
+			}
+			attrs = append(attrs[:n:n], append(insAttrs, attrs[n:]...)...)
+		case 2: // delete
+			l := min(mrand.Intn(10), len(bs)-n)
+			a = a.Delete(n, l)
+			bs = bytes.Join([][]byte{bs[:n], bs[n+l:]}, nil)
+			attrs = append(attrs[:n:n], attrs[n+l:]...)
+		case 3: // rotate
+			a1, a2 := a.Split(n)
+			a = a2.Concat(a1)
+			bs = bytes.Join([][]byte{bs[n:], bs[:n]}, nil)
+			attrs = append(attrs[n:n:n], append(attrs[n:], attrs[:n]...)...)
+		case 4: // set or merge
+			to := n + mrand.Intn(len(bs)-n+1)
+			style := styles[mrand.Intn(len(styles))]
+			if mrand.Intn(2) == 0 {
+				a = a.SetAttrs(n, to, style)
+				for i := n; i < to; i++ {
+					attrs[i] = style
+				}
+			} else {
+				a = a.MergeAttrs(n, to, style)
+				for i := n; i < to; i++ {
+					merged := maps.Clone(attrs[i])
+					if merged == nil {
+						merged = Attrs{}
+					}
+					maps.Copy(merged, style)
+					attrs[i] = merged
+				}
+			}
+		}
+		check()
+	}
+}
+
+func TestMergeAttrsRemove(t *testing.T) {
+	a := NewAttrRope(NewFromString("foobar"), Attrs{"style": "bold", "lang": "go"})
+	a = a.MergeAttrs(0, 3, Attrs{"style": ""})
+	if !maps.Equal(a.AttrsAt(0), Attrs{"lang": "go"}) || !maps.Equal(a.AttrsAt(3), Attrs{"style": "bold", "lang": "go"}) {
+		t.Fatal()
+	}
+	a = a.MergeAttrs(0, 3, Attrs{"style": "bold"})
+	if a.spans.left != nil { // merged back into one run
+		t.Fatal()
+	}
+}
@@ -2,6 +2,8 @@ package rope
 
 import (
 	"bytes"
+	mrand "math/rand"
+	"runtime"
 	"testing"
 )
 
@@ -127,3 +129,311 @@ func BenchmarkIterRune(b *testing.B) {
 		})
 	}
 }
+
+// benchLayouts compares the binary tree and the B+ tree, with default node sizes
+func benchLayouts(
+	b *testing.B,
+	binary func(b *testing.B, r *Rope),
+	btree func(b *testing.B, t *BTree),
+) {
+	defer func(n, leaf, children int) {
+		MaxLengthPerNode = n
+		BTreeMaxLeaf = leaf
+		BTreeMaxChildren = children
+	}(MaxLengthPerNode, BTreeMaxLeaf, BTreeMaxChildren)
+	MaxLengthPerNode = 128
+	BTreeMaxLeaf = 2048
+	BTreeMaxChildren = 16
+	bs := getBenchBytes()
+	b.Run("binary", func(b *testing.B) {
+		r := NewFromBytes(bs)
+		b.ResetTimer()
+		binary(b, r)
+	})
+	b.Run("btree", func(b *testing.B) {
+		t := NewBTreeFromBytes(bs)
+		b.ResetTimer()
+		btree(b, t)
+	})
+}
+
+func heapInUse() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapInuse
+}
+
+func BenchmarkLayoutMemory(b *testing.B) {
+	benchLayouts(b, func(b *testing.B, _ *Rope) {
+		var r *Rope
+		for i := 0; i < b.N; i++ {
+			bs := getBenchBytes()
+			before := heapInUse()
+			r = NewFromBytes(bs)
+			b.ReportMetric(float64(heapInUse()-before)/benchBytesLen, "heap-bytes/byte")
+			runtime.KeepAlive(bs)
+		}
+		runtime.KeepAlive(r)
+	}, func(b *testing.B, _ *BTree) {
+		var t *BTree
+		for i := 0; i < b.N; i++ {
+			bs := getBenchBytes()
+			before := heapInUse()
+			t = NewBTreeFromBytes(bs)
+			b.ReportMetric(float64(heapInUse()-before)/benchBytesLen, "heap-bytes/byte")
+			runtime.KeepAlive(bs)
+		}
+		runtime.KeepAlive(t)
+	})
+}
+
+func BenchmarkLayoutIndex(b *testing.B) {
+	benchLayouts(b, func(b *testing.B, r *Rope) {
+		for i := 0; i < b.N; i++ {
+			r.Index(i * 7919 % benchBytesLen)
+		}
+	}, func(b *testing.B, t *BTree) {
+		for i := 0; i < b.N; i++ {
+			t.Index(i * 7919 % benchBytesLen)
+		}
+	})
+}
+
+func BenchmarkLayoutSplit(b *testing.B) {
+	benchLayouts(b, func(b *testing.B, r *Rope) {
+		for i := 0; i < b.N; i++ {
+			r.Split(i * 7919 % benchBytesLen)
+		}
+	}, func(b *testing.B, t *BTree) {
+		for i := 0; i < b.N; i++ {
+			t.Split(i * 7919 % benchBytesLen)
+		}
+	})
+}
+
+func BenchmarkLayoutInsert(b *testing.B) {
+	benchLayouts(b, func(b *testing.B, r *Rope) {
+		for i := 0; i < b.N; i++ {
+			r = r.Insert(i*7919%benchBytesLen, []byte("x"))
+		}
+	}, func(b *testing.B, t *BTree) {
+		for i := 0; i < b.N; i++ {
+			t = t.Insert(i*7919%benchBytesLen, []byte("x"))
+		}
+	})
+}
+
+func BenchmarkLayoutIter(b *testing.B) {
+	benchLayouts(b, func(b *testing.B, r *Rope) {
+		b.SetBytes(benchBytesLen)
+		for i := 0; i < b.N; i++ {
+			r.Iter(0, func([]byte) bool {
+				return true
+			})
+		}
+	}, func(b *testing.B, t *BTree) {
+		b.SetBytes(benchBytesLen)
+		for i := 0; i < b.N; i++ {
+			t.Iter(0, func([]byte) bool {
+				return true
+			})
+		}
+	})
+}
+
+// adversarial edit patterns, height should stay logarithmic
+
+func BenchmarkAdversarialPrepend(b *testing.B) {
+	r := getBenchRope()
+	x := NewFromBytes([]byte("x"))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r = x.Concat(r)
+	}
+	b.ReportMetric(float64(r.height), "height")
+}
+
+func BenchmarkAdversarialAlternate(b *testing.B) {
+	r := getBenchRope()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			r = r.Insert(0, []byte{byte(i)})
+		} else {
+			r = r.Insert(r.Len(), []byte{byte(i)})
+		}
+	}
+	b.ReportMetric(float64(r.height), "height")
+}
+
+func BenchmarkAdversarialRotate(b *testing.B) {
+	r := getBenchRope()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r1, r2 := r.Split(i * 7919 % benchBytesLen)
+		r = r2.Concat(r1)
+	}
+	b.ReportMetric(float64(r.height), "height")
+}
+
+func BenchmarkAdversarialScatteredInsert(b *testing.B) {
+	r := getBenchRope()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r = r.Insert(i*7919%r.Len(), []byte{byte(i)})
+	}
+	b.ReportMetric(float64(r.height), "height")
+}
+
+type oneByteInsertsResult struct {
+	rope         *Rope
+	bytesPerByte float64
+	// heap bytes of cached nodes per insert, which the cache would keep without eviction
+	cacheBytesPerInsert float64
+}
+
+// oneByteInsertsResults are reused by repeated runs of the benchmark
+var oneByteInsertsResults = map[bool]oneByteInsertsResult{}
+
+// oneByteInserts returns a rope of 1M random one byte inserts into an empty one, and its heap bytes per byte.
+// The cache has no eviction and keeps every intermediate version alive, which would take gigabytes here.
+// So it is cleared periodically, and the heap it held is reported separately
+func oneByteInserts(merge bool) oneByteInsertsResult {
+	if res, ok := oneByteInsertsResults[merge]; ok {
+		return res
+	}
+	defer func(m bool) {
+		MergeLeaves = m
+	}(MergeLeaves)
+	MergeLeaves = merge
+	// clearCache returns heap bytes released by clearing the cache
+	clearCache := func() int64 {
+		before := heapInUse()
+		cache.Range(func(k, _ any) bool {
+			cache.Delete(k)
+			return true
+		})
+		return max(int64(before)-int64(heapInUse()), 0)
+	}
+	clearCache()
+	before := heapInUse()
+	var r *Rope
+	rand := mrand.New(mrand.NewSource(42))
+	const n = 1 << 20
+	var cacheBytes int64
+	for i := 0; i < n; i++ {
+		r = r.Insert(rand.Intn(r.Len()+1), []byte{byte(i)})
+		if i%(1<<14) == 0 {
+			cacheBytes += clearCache()
+		}
+	}
+	cacheBytes += clearCache()
+	res := oneByteInsertsResult{
+		rope:                r,
+		bytesPerByte:        float64(heapInUse()-before) / n,
+		cacheBytesPerInsert: float64(cacheBytes) / n,
+	}
+	oneByteInsertsResults[merge] = res
+	return res
+}
+
+func BenchmarkOneByteInserts(b *testing.B) {
+	defer func(n int) {
+		MaxLengthPerNode = n
+	}(MaxLengthPerNode)
+	MaxLengthPerNode = 128
+	for _, merge := range []bool{true, false} {
+		name := "merge"
+		if !merge {
+			name = "nomerge"
+		}
+		b.Run(name, func(b *testing.B) {
+			res := oneByteInserts(merge)
+			r := res.rope
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r.Index(i * 7919 % r.Len())
+			}
+			b.ReportMetric(res.bytesPerByte, "heap-bytes/byte")
+			b.ReportMetric(res.cacheBytesPerInsert, "cache-bytes/insert")
+			b.ReportMetric(float64(r.Stats().Leaves), "leaves")
+			b.ReportMetric(float64(r.height), "height")
+		})
+	}
+}
+
+func BenchmarkNewMemory(b *testing.B) {
+	defer func(n int) {
+		MaxLengthPerNode = n
+	}(MaxLengthPerNode)
+	MaxLengthPerNode = 128
+	for _, c := range []struct {
+		name string
+		fn   func([]byte) *Rope
+	}{
+		{"copy", NewFromBytes},
+		{"nocopy", NewFromBytesNoCopy},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			var r *Rope
+			var perByte float64
+			for i := 0; i < b.N; i++ {
+				bs := getBenchBytes()
+				before := heapInUse()
+				r = c.fn(bs)
+				perByte = float64(heapInUse()-before) / benchBytesLen
+				runtime.KeepAlive(bs)
+			}
+			b.ReportMetric(perByte, "heap-bytes/byte")
+			runtime.KeepAlive(r)
+		})
+	}
+}
+
+func BenchmarkNewParallel(b *testing.B) {
+	defer func(n int) {
+		MaxLengthPerNode = n
+	}(MaxLengthPerNode)
+	MaxLengthPerNode = 128
+	bs := getBenchBytes()
+	b.Run("sequential", func(b *testing.B) {
+		b.SetBytes(benchBytesLen)
+		for i := 0; i < b.N; i++ {
+			NewFromBytes(bs)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		b.SetBytes(benchBytesLen)
+		for i := 0; i < b.N; i++ {
+			NewFromBytesParallel(bs, 0)
+		}
+	})
+	b.Run("readerat", func(b *testing.B) {
+		b.SetBytes(benchBytesLen)
+		for i := 0; i < b.N; i++ {
+			NewFromReaderAtParallel(bytes.NewReader(bs), benchBytesLen, 0)
+		}
+	})
+}
+
+func BenchmarkVisualColumn(b *testing.B) {
+	defer func(n int) {
+		MaxLengthPerNode = n
+	}(MaxLengthPerNode)
+	MaxLengthPerNode = 128
+	// one long line
+	r := NewFromBytes(bytes.Repeat([]byte("foo\t中文 bar "), 1<<16))
+	for _, cache := range []bool{false, true} {
+		b.Run(map[bool]string{false: "nocache", true: "cache"}[cache], func(b *testing.B) {
+			defer func() {
+				CacheWidths = false
+			}()
+			CacheWidths = cache
+			for i := 0; i < b.N; i++ {
+				offset := mrand.Intn(r.Len())
+				r.VisualColumn(offset, 4)
+			}
+		})
+	}
+}
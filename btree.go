@@ -0,0 +1,343 @@
+package rope
+
+import (
+	"bytes"
+	"io"
+	"sort"
+)
+
+// Text is the API shared by Rope and BTree. T is the implementing type
+type Text[T any] interface {
+	Len() int
+	Index(i int) byte
+	Bytes() []byte
+	Sub(n, l int) []byte
+	Iter(offset int, fn func([]byte) bool) bool
+	IterBackward(offset int, fn func([]byte) bool) bool
+	Concat(T) T
+	Split(n int) (T, T)
+	Insert(n int, bs []byte) T
+	Delete(n, l int) T
+}
+
+var (
+	_ Text[*Rope]  = (*Rope)(nil)
+	_ Text[*BTree] = (*BTree)(nil)
+)
+
+// BTree is a persistent rope in B+ tree layout, with the same API as Rope by Text.
+// Nodes are wide and leaves are large, so there are less nodes and pointers to chase.
+// All leaves are at the same depth. Except the root, leaves are at least half of BTreeMaxLeaf,
+// and internal nodes have at least half of BTreeMaxChildren children
+type BTree struct {
+	height   int // 0 for leaves
+	content  []byte
+	children []*BTree
+	offsets  []int // offsets[i] is the length of children[:i+1]
+}
+
+var (
+	BTreeMaxLeaf     = 2048
+	BTreeMaxChildren = 16
+)
+
+func NewBTreeFromReader(r io.Reader) (*BTree, error) {
+	bs, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return newBTreeFromBytes(bs), nil
+}
+
+func NewBTreeFromString(s string) *BTree {
+	return newBTreeFromBytes([]byte(s))
+}
+
+func NewBTreeFromBytes(bs []byte) *BTree {
+	return newBTreeFromBytes(bytes.Clone(bs))
+}
+
+// newBTreeFromBytes builds bottom up, leaves share bs
+func newBTreeFromBytes(bs []byte) *BTree {
+	if len(bs) == 0 {
+		return nil
+	}
+	var nodes []*BTree
+	for len(bs) > 0 {
+		l := nextChunk(len(bs), BTreeMaxLeaf)
+		nodes = append(nodes, &BTree{
+			content: bs[:l:l],
+		})
+		bs = bs[l:]
+	}
+	for len(nodes) > 1 {
+		var parents []*BTree
+		for len(nodes) > 0 {
+			l := nextChunk(len(nodes), BTreeMaxChildren)
+			parents = append(parents, newBTreeNode(nodes[:l:l]))
+			nodes = nodes[l:]
+		}
+		nodes = parents
+	}
+	return nodes[0]
+}
+
+// nextChunk returns the length of the next chunk of at most max, keeping the last two chunks at least half full
+func nextChunk(rest, max int) int {
+	if rest <= max {
+		return rest
+	}
+	if rest < max+max/2 {
+		return rest / 2
+	}
+	return max
+}
+
+func newBTreeNode(children []*BTree) *BTree {
+	offsets := make([]int, len(children))
+	sum := 0
+	for i, c := range children {
+		sum += c.Len()
+		offsets[i] = sum
+	}
+	return &BTree{
+		height:   children[0].height + 1,
+		children: children,
+		offsets:  offsets,
+	}
+}
+
+// fromBTreeChildren returns a tree of children, which may be underfull
+func fromBTreeChildren(children []*BTree) *BTree {
+	switch len(children) {
+	case 0:
+		return nil
+	case 1:
+		return children[0]
+	}
+	return newBTreeNode(children[:len(children):len(children)])
+}
+
+// okChild reports whether t can be a child without violating the minimum occupancy
+func (t *BTree) okChild() bool {
+	if t.height == 0 {
+		return len(t.content) >= BTreeMaxLeaf/2
+	}
+	return len(t.children) >= BTreeMaxChildren/2
+}
+
+func (t *BTree) Len() int {
+	if t == nil {
+		return 0
+	}
+	if t.height == 0 {
+		return len(t.content)
+	}
+	return t.offsets[len(t.offsets)-1]
+}
+
+// child returns the index of the child containing offset i, and the offset of the child
+func (t *BTree) child(i int) (int, int) {
+	c := sort.SearchInts(t.offsets, i+1)
+	if c == 0 {
+		return 0, 0
+	}
+	return c, t.offsets[c-1]
+}
+
+func (t *BTree) Index(i int) byte {
+	for t.height > 0 {
+		c, start := t.child(i)
+		t = t.children[c]
+		i -= start
+	}
+	return t.content[i]
+}
+
+func (t *BTree) Bytes() []byte {
+	ret := make([]byte, 0, t.Len())
+	t.Iter(0, func(bs []byte) bool {
+		ret = append(ret, bs...)
+		return true
+	})
+	return ret
+}
+
+func (t *BTree) Concat(t2 *BTree) *BTree {
+	if t == nil {
+		return t2
+	}
+	if t2 == nil {
+		return t
+	}
+	h1, h2 := t.height, t2.height
+	switch {
+	case h1 < h2: // join at the left spine of t2
+		children := t2.children
+		if h1 == h2-1 && t.okChild() {
+			return mergeBTreeNodes([]*BTree{t}, children)
+		}
+		n := t.Concat(children[0])
+		if n.height == h2-1 {
+			return mergeBTreeNodes([]*BTree{n}, children[1:])
+		}
+		return mergeBTreeNodes(n.children, children[1:])
+	case h1 > h2: // join at the right spine of t
+		children := t.children
+		last := len(children) - 1
+		if h2 == h1-1 && t2.okChild() {
+			return mergeBTreeNodes(children, []*BTree{t2})
+		}
+		n := children[last].Concat(t2)
+		if n.height == h1-1 {
+			return mergeBTreeNodes(children[:last], []*BTree{n})
+		}
+		return mergeBTreeNodes(children[:last], n.children)
+	default:
+		if t.okChild() && t2.okChild() {
+			return newBTreeNode([]*BTree{t, t2})
+		}
+		if h1 == 0 {
+			return mergeBTreeLeaves(t, t2)
+		}
+		return mergeBTreeNodes(t.children, t2.children)
+	}
+}
+
+// mergeBTreeNodes returns a node of all children, or a node of two nodes if overflowed
+func mergeBTreeNodes(children1, children2 []*BTree) *BTree {
+	children := make([]*BTree, 0, len(children1)+len(children2))
+	children = append(children, children1...)
+	children = append(children, children2...)
+	if len(children) <= BTreeMaxChildren {
+		return newBTreeNode(children)
+	}
+	split := len(children) - BTreeMaxChildren/2
+	if split > BTreeMaxChildren {
+		split = BTreeMaxChildren
+	}
+	return newBTreeNode([]*BTree{
+		newBTreeNode(children[:split:split]),
+		newBTreeNode(children[split:]),
+	})
+}
+
+func mergeBTreeLeaves(t, t2 *BTree) *BTree {
+	bs := make([]byte, 0, len(t.content)+len(t2.content))
+	bs = append(bs, t.content...)
+	bs = append(bs, t2.content...)
+	if len(bs) <= BTreeMaxLeaf {
+		return &BTree{
+			content: bs,
+		}
+	}
+	l := len(bs) / 2
+	return newBTreeNode([]*BTree{
+		{content: bs[:l:l]},
+		{content: bs[l:]},
+	})
+}
+
+func (t *BTree) Split(n int) (out1, out2 *BTree) {
+	if t == nil {
+		return
+	}
+	if n <= 0 {
+		return nil, t
+	}
+	if n >= t.Len() {
+		return t, nil
+	}
+	if t.height == 0 { // leaf, halves share the content
+		out1 = &BTree{
+			content: t.content[:n:n],
+		}
+		out2 = &BTree{
+			content: t.content[n:],
+		}
+		return
+	}
+	c, start := t.child(n)
+	r1, r2 := t.children[c].Split(n - start)
+	out1 = fromBTreeChildren(t.children[:c]).Concat(r1)
+	out2 = r2.Concat(fromBTreeChildren(t.children[c+1:]))
+	return
+}
+
+func (t *BTree) Insert(n int, bs []byte) *BTree {
+	t1, t2 := t.Split(n)
+	return t1.Concat(NewBTreeFromBytes(bs)).Concat(t2)
+}
+
+func (t *BTree) Delete(n, l int) *BTree {
+	t1, t2 := t.Split(n)
+	_, t2 = t2.Split(l)
+	return t1.Concat(t2)
+}
+
+func (t *BTree) Sub(n, l int) []byte {
+	ret := make([]byte, 0, l)
+	t.Iter(n, func(bs []byte) bool {
+		if l >= len(bs) {
+			ret = append(ret, bs...)
+			l -= len(bs)
+			return true
+		}
+		ret = append(ret, bs[:l]...)
+		return false
+	})
+	return ret
+}
+
+func (t *BTree) Iter(offset int, fn func([]byte) bool) bool {
+	if t == nil || offset >= t.Len() {
+		return true
+	}
+	if t.height == 0 { // leaf
+		if offset < len(t.content) {
+			return fn(t.content[offset:])
+		}
+		return true
+	}
+	c, start := t.child(offset)
+	if !t.children[c].Iter(offset-start, fn) {
+		return false
+	}
+	for _, child := range t.children[c+1:] {
+		if !child.Iter(0, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *BTree) IterBackward(offset int, fn func([]byte) bool) bool {
+	if t == nil {
+		return true
+	}
+	if t.height == 0 { // leaf
+		if offset > len(t.content) {
+			offset = len(t.content)
+		}
+		if offset == 0 {
+			return true
+		}
+		return fn(reversedBytes(t.content[:offset]))
+	}
+	if offset > t.Len() {
+		offset = t.Len()
+	}
+	if offset == 0 {
+		return true
+	}
+	c, start := t.child(offset - 1)
+	if !t.children[c].IterBackward(offset-start, fn) {
+		return false
+	}
+	for i := c - 1; i >= 0; i-- {
+		if !t.children[i].IterBackward(t.children[i].Len(), fn) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,240 @@
+package rope
+
+import (
+	"bytes"
+	"fmt"
+	mrand "math/rand"
+	"testing"
+)
+
+// checkBTree checks structural invariants
+func checkBTree(t *BTree) error {
+	if t == nil {
+		return nil
+	}
+	var check func(t *BTree, root bool) error
+	check = func(t *BTree, root bool) error {
+		if !root && !t.okChild() {
+			return fmt.Errorf("underfull node at height %d", t.height)
+		}
+		if t.height == 0 {
+			if len(t.content) == 0 || len(t.content) > BTreeMaxLeaf {
+				return fmt.Errorf("bad leaf size %d", len(t.content))
+			}
+			return nil
+		}
+		if len(t.children) < 2 || len(t.children) > BTreeMaxChildren {
+			return fmt.Errorf("bad children count %d", len(t.children))
+		}
+		sum := 0
+		for i, c := range t.children {
+			if c.height != t.height-1 {
+				return fmt.Errorf("bad height")
+			}
+			sum += c.Len()
+			if t.offsets[i] != sum {
+				return fmt.Errorf("bad offset")
+			}
+			if err := check(c, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return check(t, true)
+}
+
+func TestNewBTree(t *testing.T) {
+	if NewBTreeFromBytes(nil) != nil {
+		t.Fatal()
+	}
+	for i := 0; i < 1024; i++ {
+		bs := getRandomBytes(i)
+		tree := NewBTreeFromBytes(bs)
+		if err := checkBTree(tree); err != nil {
+			t.Fatalf("%d %v", i, err)
+		}
+		if tree.Len() != i {
+			t.Fatal()
+		}
+		if !bytes.Equal(tree.Bytes(), bs) {
+			t.Fatal()
+		}
+		for j := range bs {
+			if tree.Index(j) != bs[j] {
+				t.Fatal()
+			}
+		}
+	}
+
+	bs := []byte("foobar")
+	tree := NewBTreeFromBytes(bs)
+	bs[0] = 'x'
+	if tree.Index(0) != 'f' {
+		t.Fatal()
+	}
+	if string(NewBTreeFromString("foobar").Bytes()) != "foobar" {
+		t.Fatal()
+	}
+	tree, err := NewBTreeFromReader(bytes.NewReader([]byte("foobar")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tree.Bytes()) != "foobar" {
+		t.Fatal()
+	}
+}
+
+func TestBTreeConcat(t *testing.T) {
+	for i := 0; i < 300; i++ {
+		for _, j := range []int{0, 1, 5, 17, 100, 300} {
+			bs1 := getRandomBytes(i)
+			bs2 := getRandomBytes(j)
+			tree := NewBTreeFromBytes(bs1).Concat(NewBTreeFromBytes(bs2))
+			if err := checkBTree(tree); err != nil {
+				t.Fatalf("%d %d %v", i, j, err)
+			}
+			if !bytes.Equal(tree.Bytes(), append(bs1, bs2...)) {
+				t.Fatal()
+			}
+		}
+	}
+}
+
+func TestBTreeSplit(t *testing.T) {
+	bs := getRandomBytes(2048)
+	tree := NewBTreeFromBytes(bs)
+	for i := -1; i <= len(bs)+1; i++ {
+		t1, t2 := tree.Split(i)
+		if err := checkBTree(t1); err != nil {
+			t.Fatal(err)
+		}
+		if err := checkBTree(t2); err != nil {
+			t.Fatal(err)
+		}
+		n := max(0, min(i, len(bs)))
+		if !bytes.Equal(t1.Bytes(), bs[:n]) {
+			t.Fatal()
+		}
+		if !bytes.Equal(t2.Bytes(), bs[n:]) {
+			t.Fatal()
+		}
+	}
+	if !bytes.Equal(tree.Bytes(), bs) {
+		t.Fatal()
+	}
+}
+
+func TestBTreeEdits(t *testing.T) {
+	bs := getRandomBytes(1024)
+	tree := NewBTreeFromBytes(bs)
+	for i := 0; i < 2000; i++ {
+		n := mrand.Intn(len(bs) + 1)
+		if mrand.Intn(2) == 0 {
+			ins := getRandomBytes(mrand.Intn(20))
+			tree = tree.Insert(n, ins)
+			bs = bytes.Join([][]byte{bs[:n], ins, bs[n:]}, nil)
+		} else {
+			l := mrand.Intn(20)
+			tree = tree.Delete(n, l)
+			end := min(n+l, len(bs))
+			bs = bytes.Join([][]byte{bs[:n], bs[end:]}, nil)
+		}
+		if err := checkBTree(tree); err != nil {
+			t.Fatal(err)
+		}
+		if tree.Len() != len(bs) {
+			t.Fatal()
+		}
+	}
+	if !bytes.Equal(tree.Bytes(), bs) {
+		t.Fatal()
+	}
+}
+
+func TestTextEdits(t *testing.T) {
+	testTextEdits(t, NewFromBytes)
+	testTextEdits(t, NewBTreeFromBytes)
+}
+
+func testTextEdits[T Text[T]](t *testing.T, fromBytes func([]byte) T) {
+	bs := getRandomBytes(256)
+	text := fromBytes(bs)
+	for i := 0; i < 200; i++ {
+		n := mrand.Intn(len(bs) + 1)
+		switch mrand.Intn(3) {
+		case 0:
+			ins := getRandomBytes(mrand.Intn(20))
+			text = text.Insert(n, ins)
+			bs = bytes.Join([][]byte{bs[:n], ins, bs[n:]}, nil)
+		case 1:
+			l := mrand.Intn(20)
+			text = text.Delete(n, l)
+			bs = bytes.Join([][]byte{bs[:n], bs[min(n+l, len(bs)):]}, nil)
+		default:
+			out1, out2 := text.Split(n)
+			text = out2.Concat(out1)
+			bs = bytes.Join([][]byte{bs[n:], bs[:n]}, nil)
+		}
+		if text.Len() != len(bs) || !bytes.Equal(text.Sub(0, len(bs)), bs) {
+			t.Fatalf("%T", text)
+		}
+	}
+	if !bytes.Equal(text.Bytes(), bs) {
+		t.Fatalf("%T", text)
+	}
+}
+
+func TestBTreeSub(t *testing.T) {
+	bs := getRandomBytes(128)
+	tree := NewBTreeFromBytes(bs)
+	for i := 0; i < len(bs); i++ {
+		for j := 0; j < len(bs); j++ {
+			end := min(i+j, len(bs))
+			if !bytes.Equal(tree.Sub(i, j), bs[i:end]) {
+				t.Fatal()
+			}
+		}
+	}
+}
+
+func TestBTreeIter(t *testing.T) {
+	bs := getRandomBytes(4096)
+	tree := NewBTreeFromBytes(bs)
+	for i := 0; i <= len(bs); i += 3 {
+		buf := new(bytes.Buffer)
+		tree.Iter(i, func(bs []byte) bool {
+			buf.Write(bs)
+			return true
+		})
+		if !bytes.Equal(buf.Bytes(), bs[i:]) {
+			t.Fatal()
+		}
+
+		buf.Reset()
+		tree.IterBackward(i, func(bs []byte) bool {
+			buf.Write(bs)
+			return true
+		})
+		if !bytes.Equal(buf.Bytes(), reversedBytes(bs[:i])) {
+			t.Fatal()
+		}
+	}
+
+	n := 0
+	tree.Iter(0, func([]byte) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Fatal()
+	}
+	n = 0
+	tree.IterBackward(tree.Len(), func([]byte) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Fatal()
+	}
+}
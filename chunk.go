@@ -0,0 +1,93 @@
+package rope
+
+import "fmt"
+
+type Chunker interface {
+	// Cut returns the length of the first leaf in bs, or 0 if more bytes are needed to decide
+	Cut(bs []byte) int
+}
+
+// LeafChunker decides leaf boundaries in NewFromReader.
+// Should be set before building any rope
+var LeafChunker Chunker = FixedChunker
+
+// FixedChunker cuts leaves of MaxLengthPerNode bytes
+var FixedChunker Chunker = fixedChunker{}
+
+type fixedChunker struct{}
+
+func (fixedChunker) Cut(bs []byte) int {
+	if len(bs) >= MaxLengthPerNode {
+		return MaxLengthPerNode
+	}
+	return 0
+}
+
+var gearTable = func() (table [256]uint64) {
+	// splitmix64, fixed seed so boundaries are stable across processes
+	x := uint64(0x726f7065)
+	for i := range table {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+	return
+}()
+
+type gearChunker struct {
+	min, avg, max int
+	maskS, maskL  uint64
+}
+
+// GearChunker returns a content defined chunker using the gear rolling hash with FastCDC normalized chunking.
+// Leaves are at least min and at most max bytes, about avg bytes on average.
+// max is capped at MaxLengthPerNode. It panics unless 0 < min <= avg <= max
+func GearChunker(min, avg, max int) Chunker {
+	if min <= 0 || min > avg || avg > max {
+		panic(fmt.Sprintf("rope: invalid gear chunker sizes: min %d, avg %d, max %d", min, avg, max))
+	}
+	bits := 0
+	for 1<<(bits+1) <= avg {
+		bits++
+	}
+	return &gearChunker{
+		min: min,
+		avg: avg,
+		max: max,
+		// more bits below avg, less bits above, to narrow the size distribution
+		maskS: ^uint64(0) << (64 - bits - 1),
+		maskL: ^uint64(0) << (64 - bits + 1),
+	}
+}
+
+func (c *gearChunker) limit() int {
+	if c.max > MaxLengthPerNode {
+		return MaxLengthPerNode
+	}
+	return c.max
+}
+
+func (c *gearChunker) Cut(bs []byte) int {
+	limit := c.limit()
+	n := len(bs)
+	if n > limit {
+		n = limit
+	}
+	var h uint64
+	for i := c.min; i < n; i++ {
+		h = h<<1 + gearTable[bs[i]]
+		mask := c.maskS
+		if i >= c.avg {
+			mask = c.maskL
+		}
+		if h&mask == 0 {
+			return i + 1
+		}
+	}
+	if len(bs) >= limit {
+		return limit
+	}
+	return 0
+}
@@ -0,0 +1,119 @@
+package rope
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFixedChunker(t *testing.T) {
+	if FixedChunker.Cut(nil) != 0 {
+		t.Fatal()
+	}
+	if FixedChunker.Cut(make([]byte, MaxLengthPerNode-1)) != 0 {
+		t.Fatal()
+	}
+	if FixedChunker.Cut(make([]byte, MaxLengthPerNode*3)) != MaxLengthPerNode {
+		t.Fatal()
+	}
+}
+
+func TestGearChunker(t *testing.T) {
+	defer func(n int) {
+		MaxLengthPerNode = n
+	}(MaxLengthPerNode)
+	MaxLengthPerNode = 256
+	chunker := GearChunker(16, 64, 256)
+
+	bs := getRandomBytes(64 * 1024)
+	for len(bs) > 0 {
+		n := chunker.Cut(bs)
+		if n == 0 {
+			if len(bs) >= 256 {
+				t.Fatal()
+			}
+			break
+		}
+		if n < 16 || n > 256 {
+			t.Fatalf("%d", n)
+		}
+		if chunker.Cut(bs[:n]) != n {
+			t.Fatal()
+		}
+		bs = bs[n:]
+	}
+
+	// capped at MaxLengthPerNode
+	MaxLengthPerNode = 32
+	if n := chunker.Cut(make([]byte, 1024)); n > 32 {
+		t.Fatalf("%d", n)
+	}
+
+	for _, sizes := range [][3]int{{0, 64, 256}, {-1, 64, 256}, {128, 64, 256}, {16, 512, 256}, {16, 64, 0}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%v", sizes)
+				}
+			}()
+			GearChunker(sizes[0], sizes[1], sizes[2])
+		}()
+	}
+}
+
+func TestContentDefinedLeaves(t *testing.T) {
+	defer func(n int, c Chunker) {
+		MaxLengthPerNode = n
+		LeafChunker = c
+	}(MaxLengthPerNode, LeafChunker)
+	MaxLengthPerNode = 256
+	bs := getRandomBytes(64 * 1024)
+	edited := append([]byte("x"), bs...)
+
+	leaves := func(r *Rope) map[*Rope]bool {
+		ret := make(map[*Rope]bool)
+		r.iterNodes(func(node *Rope) bool {
+			if len(node.content) > 0 {
+				ret[node] = true
+			}
+			return true
+		})
+		return ret
+	}
+	shared := func() (n int, total int) {
+		r1 := NewFromBytes(bs)
+		r2 := NewFromBytes(edited)
+		if !bytes.Equal(r1.Bytes(), bs) || !bytes.Equal(r2.Bytes(), edited) {
+			t.Fatal()
+		}
+		l1 := leaves(r1)
+		for leaf := range leaves(r2) {
+			if l1[leaf] {
+				n++
+			}
+		}
+		return n, len(l1)
+	}
+
+	// fixed size leaves shift
+	if n, total := shared(); n > total/10 {
+		t.Fatalf("%d %d", n, total)
+	}
+
+	LeafChunker = GearChunker(32, 128, 256)
+	if n, total := shared(); n < total*9/10 {
+		t.Fatalf("%d %d", n, total)
+	}
+
+	// concatenated pieces
+	r := NewFromBytes(nil)
+	for i := 0; i < len(bs); i += 100 {
+		end := i + 100
+		if end > len(bs) {
+			end = len(bs)
+		}
+		r = r.Concat(NewFromBytes(bs[i:end]))
+	}
+	if !bytes.Equal(r.Bytes(), bs) {
+		t.Fatal()
+	}
+}
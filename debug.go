@@ -0,0 +1,6 @@
+//go:build ropedebug
+
+package rope
+
+// debug validates trees after Concat, Split and rotations, panicking on invalid ones
+const debug = true
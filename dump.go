@@ -0,0 +1,244 @@
+package rope
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type DumpFormat int
+
+const (
+	// DumpText is indented text, one node per line
+	DumpText DumpFormat = iota
+	// DumpJSON is nested objects, or an array of them for multiple versions
+	DumpJSON
+	// DumpDOT is a Graphviz digraph, nodes shared by versions are drawn once
+	DumpDOT
+)
+
+type DumpOptions struct {
+	Serials bool
+	Heights bool
+	// Balance shows the height of the left child minus the height of the right child
+	Balance bool
+	// Shared marks nodes reachable from more than one of the dumped versions
+	Shared bool
+}
+
+// DumpTo writes the tree to w in format. opts may be nil
+func (r *Rope) DumpTo(w io.Writer, format DumpFormat, opts *DumpOptions) error {
+	return dumpVersions(w, format, opts, []*Rope{r}, false)
+}
+
+// DumpVersionsTo writes trees of versions to w in format, so that structural sharing can be inspected.
+// opts may be nil
+func DumpVersionsTo(w io.Writer, format DumpFormat, opts *DumpOptions, versions ...*Rope) error {
+	return dumpVersions(w, format, opts, versions, true)
+}
+
+type dumper struct {
+	w      io.Writer
+	opts   *DumpOptions
+	shared map[*Rope]bool
+	ids    map[*Rope]int
+	drawn  map[*Rope]bool
+	err    error
+}
+
+func dumpVersions(w io.Writer, format DumpFormat, opts *DumpOptions, versions []*Rope, multiple bool) error {
+	if opts == nil {
+		opts = new(DumpOptions)
+	}
+	d := &dumper{
+		w:     w,
+		opts:  opts,
+		ids:   make(map[*Rope]int),
+		drawn: make(map[*Rope]bool),
+	}
+	if opts.Shared {
+		d.shared = sharedNodes(versions)
+	}
+
+	switch format {
+	case DumpText:
+		for i, r := range versions {
+			if multiple {
+				d.printf("version %d\n", i)
+			}
+			d.text(r, 0, "")
+		}
+
+	case DumpJSON:
+		var v any
+		if multiple {
+			nodes := make([]*jsonNode, 0, len(versions))
+			for _, r := range versions {
+				nodes = append(nodes, d.json(r))
+			}
+			v = nodes
+		} else {
+			v = d.json(versions[0])
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+
+	case DumpDOT:
+		d.printf("digraph rope {\n\tnode [shape=box];\n")
+		for i, r := range versions {
+			if r == nil {
+				continue
+			}
+			d.printf("\tv%d [label=\"version %d\", shape=plaintext];\n", i, i)
+			d.printf("\tv%d -> n%d;\n", i, d.id(r))
+			d.dot(r)
+		}
+		d.printf("}\n")
+
+	default:
+		return fmt.Errorf("unknown dump format %d", format)
+	}
+	return d.err
+}
+
+// sharedNodes returns nodes reachable from more than one version
+func sharedNodes(versions []*Rope) map[*Rope]bool {
+	counts := make(map[*Rope]int)
+	for _, r := range versions {
+		seen := make(map[*Rope]bool)
+		r.iterNodes(func(node *Rope) bool {
+			if seen[node] {
+				return false
+			}
+			seen[node] = true
+			counts[node]++
+			return true
+		})
+	}
+	ret := make(map[*Rope]bool)
+	for node, n := range counts {
+		if n > 1 {
+			ret[node] = true
+		}
+	}
+	return ret
+}
+
+func (d *dumper) printf(format string, args ...any) {
+	if d.err != nil {
+		return
+	}
+	_, d.err = fmt.Fprintf(d.w, format, args...)
+}
+
+// id returns the node id in dot output, in the order of first visit
+func (d *dumper) id(r *Rope) int {
+	id, ok := d.ids[r]
+	if !ok {
+		id = len(d.ids)
+		d.ids[r] = id
+	}
+	return id
+}
+
+// attrs returns optional attributes of r as key=value pairs
+func (d *dumper) attrs(r *Rope) (ret []string) {
+	if d.opts.Serials {
+		ret = append(ret, fmt.Sprintf("serial=%d", r.serial))
+	}
+	if d.opts.Heights {
+		ret = append(ret, fmt.Sprintf("height=%d", r.height))
+	}
+	if d.opts.Balance && r.left != nil {
+		ret = append(ret, fmt.Sprintf("balance=%d", r.left.height-r.right.height))
+	}
+	if d.shared[r] {
+		ret = append(ret, "shared")
+	}
+	return
+}
+
+func (d *dumper) text(r *Rope, level int, prefix string) {
+	if r == nil {
+		return
+	}
+	r.ensure()
+	line := fmt.Sprintf("%s%s%d |%s|", strings.Repeat("  ", level), prefix, r.weight, r.content)
+	if attrs := d.attrs(r); len(attrs) > 0 {
+		line += " " + strings.Join(attrs, " ")
+	}
+	d.printf("%s\n", line)
+	d.text(r.left, level+1, "<")
+	d.text(r.right, level+1, ">")
+}
+
+type jsonNode struct {
+	Weight  int       `json:"weight"`
+	Content *string   `json:"content,omitempty"`
+	Serial  *int64    `json:"serial,omitempty"`
+	Height  *int      `json:"height,omitempty"`
+	Balance *int      `json:"balance,omitempty"`
+	Shared  bool      `json:"shared,omitempty"`
+	Left    *jsonNode `json:"left,omitempty"`
+	Right   *jsonNode `json:"right,omitempty"`
+}
+
+func (d *dumper) json(r *Rope) *jsonNode {
+	if r == nil {
+		return nil
+	}
+	r.ensure()
+	ret := &jsonNode{
+		Weight: r.weight,
+		Shared: d.shared[r],
+		Left:   d.json(r.left),
+		Right:  d.json(r.right),
+	}
+	if len(r.content) > 0 {
+		content := string(r.content)
+		ret.Content = &content
+	}
+	if d.opts.Serials {
+		ret.Serial = &r.serial
+	}
+	if d.opts.Heights {
+		ret.Height = &r.height
+	}
+	if d.opts.Balance && r.left != nil {
+		balance := r.left.height - r.right.height
+		ret.Balance = &balance
+	}
+	return ret
+}
+
+func (d *dumper) dot(r *Rope) {
+	if d.drawn[r] {
+		return
+	}
+	d.drawn[r] = true
+	id := d.id(r)
+	r.ensure()
+	label := strconv.Itoa(r.weight)
+	if len(r.content) > 0 {
+		label += " " + strconv.Quote(string(r.content))
+	}
+	if attrs := d.attrs(r); len(attrs) > 0 {
+		label += "\n" + strings.Join(attrs, " ")
+	}
+	style := ""
+	if d.shared[r] {
+		style = ", style=filled, fillcolor=lightblue"
+	}
+	d.printf("\tn%d [label=%s%s];\n", id, strconv.Quote(label), style)
+	if r.left != nil {
+		d.printf("\tn%d -> n%d [label=\"<\"];\n", id, d.id(r.left))
+		d.dot(r.left)
+	}
+	if r.right != nil {
+		d.printf("\tn%d -> n%d [label=\">\"];\n", id, d.id(r.right))
+		d.dot(r.right)
+	}
+}
@@ -0,0 +1,106 @@
+package rope
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDumpTo(t *testing.T) {
+	r := NewFromString("foobarbaz")
+	buf := new(strings.Builder)
+	if err := r.DumpTo(buf, DumpText, nil); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "8 ||\n  <8 |foobarba|\n  >1 |z|\n" {
+		t.Fatalf("%q", buf.String())
+	}
+
+	buf.Reset()
+	if err := r.DumpTo(buf, DumpText, &DumpOptions{
+		Heights: true,
+		Balance: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "8 || height=2 balance=0\n  <8 |foobarba| height=1\n  >1 |z| height=1\n" {
+		t.Fatalf("%q", buf.String())
+	}
+
+	buf.Reset()
+	if err := r.DumpTo(buf, DumpJSON, &DumpOptions{
+		Serials: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var node struct {
+		Weight int
+		Serial int64
+		Left   struct {
+			Content string
+		}
+	}
+	if err := json.Unmarshal([]byte(buf.String()), &node); err != nil {
+		t.Fatal(err)
+	}
+	if node.Weight != 8 || node.Serial != r.serial || node.Left.Content != "foobarba" {
+		t.Fatal()
+	}
+
+	if err := r.DumpTo(buf, DumpFormat(42), nil); err == nil {
+		t.Fatal()
+	}
+}
+
+func TestDumpVersionsTo(t *testing.T) {
+	r1 := NewFromString("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.,")
+	r2 := r1.Insert(3, []byte("foo"))
+	shared := r2.ShareStats(r1).SharedNodes
+
+	buf := new(strings.Builder)
+	if err := DumpVersionsTo(buf, DumpText, &DumpOptions{
+		Shared: true,
+	}, r1, r2); err != nil {
+		t.Fatal(err)
+	}
+	// shared subtrees are printed in both versions
+	if n := strings.Count(buf.String(), " shared\n"); n != 2*shared {
+		t.Fatalf("%d %d", n, shared)
+	}
+
+	buf.Reset()
+	if err := DumpVersionsTo(buf, DumpDOT, &DumpOptions{
+		Shared: true,
+	}, r1, r2); err != nil {
+		t.Fatal(err)
+	}
+	dot := buf.String()
+	if !strings.HasPrefix(dot, "digraph rope {") || !strings.HasSuffix(dot, "}\n") {
+		t.Fatal()
+	}
+	// nodes are drawn once
+	drawn := 0
+	for _, line := range strings.Split(dot, "\n") {
+		if strings.HasPrefix(line, "\tn") && strings.Contains(line, "[label=") && !strings.Contains(line, "->") {
+			drawn++
+		}
+	}
+	if nodes := r1.Stats().DistinctNodes + r2.ShareStats(r1).UniqueNodes; drawn != nodes {
+		t.Fatalf("%d %d", drawn, nodes)
+	}
+	if n := strings.Count(dot, "fillcolor"); n != shared {
+		t.Fatalf("%d %d", n, shared)
+	}
+
+	buf.Reset()
+	if err := DumpVersionsTo(buf, DumpJSON, nil, r1, nil); err != nil {
+		t.Fatal(err)
+	}
+	var nodes2 []any
+	if err := json.Unmarshal([]byte(buf.String()), &nodes2); err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes2) != 2 || nodes2[1] != nil {
+		t.Fatal()
+	}
+}
@@ -0,0 +1,347 @@
+package rope
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+type Encoding int
+
+const (
+	UTF8 Encoding = iota
+	UTF16LE
+	UTF16BE
+	UTF32LE
+	UTF32BE
+	Latin1
+	Windows1252
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case UTF8:
+		return "UTF-8"
+	case UTF16LE:
+		return "UTF-16LE"
+	case UTF16BE:
+		return "UTF-16BE"
+	case UTF32LE:
+		return "UTF-32LE"
+	case UTF32BE:
+		return "UTF-32BE"
+	case Latin1:
+		return "ISO-8859-1"
+	case Windows1252:
+		return "Windows-1252"
+	}
+	return fmt.Sprintf("Encoding(%d)", int(e))
+}
+
+func (e Encoding) bom() []byte {
+	switch e {
+	case UTF8:
+		return []byte{0xef, 0xbb, 0xbf}
+	case UTF16LE:
+		return []byte{0xff, 0xfe}
+	case UTF16BE:
+		return []byte{0xfe, 0xff}
+	case UTF32LE:
+		return []byte{0xff, 0xfe, 0, 0}
+	case UTF32BE:
+		return []byte{0, 0, 0xfe, 0xff}
+	}
+	return nil
+}
+
+// unitSize returns the number of bytes of code units
+func (e Encoding) unitSize() int {
+	switch e {
+	case UTF16LE, UTF16BE:
+		return 2
+	case UTF32LE, UTF32BE:
+		return 4
+	}
+	return 1
+}
+
+// EncodingInfo is the encoding of loaded text, kept to save it back the same way
+type EncodingInfo struct {
+	Encoding Encoding
+	// BOM is whether the text starts with a byte order mark. Ignored for Latin1 and Windows1252
+	BOM bool
+}
+
+var ErrUnencodable = errors.New("rune not encodable")
+
+// encodingSampleSize is the number of leading bytes to detect encodings
+const encodingSampleSize = 4096
+
+// DetectEncoding detects the encoding of text starting with prefix by byte order marks.
+// Without BOM, it is UTF8 if prefix is valid UTF-8, ignoring an incomplete rune at the end, or Windows1252 otherwise
+func DetectEncoding(prefix []byte) EncodingInfo {
+	// UTF-32LE before UTF-16LE, which is its prefix
+	for _, e := range []Encoding{UTF8, UTF32LE, UTF32BE, UTF16LE, UTF16BE} {
+		if bytes.HasPrefix(prefix, e.bom()) {
+			return EncodingInfo{Encoding: e, BOM: true}
+		}
+	}
+	for i := len(prefix) - 1; i >= max(len(prefix)-utf8.UTFMax+1, 0); i-- {
+		if utf8.RuneStart(prefix[i]) {
+			if !utf8.FullRune(prefix[i:]) {
+				prefix = prefix[:i]
+			}
+			break
+		}
+	}
+	if !utf8.Valid(prefix) {
+		return EncodingInfo{Encoding: Windows1252}
+	}
+	return EncodingInfo{Encoding: UTF8}
+}
+
+// NewFromReaderEncoding reads text in the encoding detected by DetectEncoding with the first 4096 bytes,
+// and returns a rope of the text transcoded to UTF-8 without BOM, streaming leaf by leaf
+func NewFromReaderEncoding(r io.Reader) (*Rope, EncodingInfo, error) {
+	br := bufio.NewReaderSize(r, encodingSampleSize)
+	prefix, err := br.Peek(encodingSampleSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, EncodingInfo{}, err
+	}
+	info := DetectEncoding(prefix)
+	if info.BOM {
+		br.Discard(len(info.Encoding.bom()))
+	}
+	ret, err := NewFromReader(NewDecoder(br, info.Encoding))
+	return ret, info, err
+}
+
+// LoadFile reads the file at path like NewFromReaderEncoding.
+// All bytes are copied, OpenFile views UTF-8 files lazily instead
+func LoadFile(path string) (*Rope, EncodingInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, EncodingInfo{}, err
+	}
+	defer f.Close()
+	return NewFromReaderEncoding(f)
+}
+
+// Encoder returns a SaveOptions.Encoder converting UTF-8 to the encoding, writing the BOM first if any.
+// Returns nil for UTF-8 without BOM
+func (e EncodingInfo) Encoder() func(io.Writer) io.WriteCloser {
+	if e == (EncodingInfo{Encoding: UTF8}) {
+		return nil
+	}
+	return func(w io.Writer) io.WriteCloser {
+		return NewEncoder(w, e)
+	}
+}
+
+// NewDecoder returns a reader of bytes of r in the encoding transcoded to UTF-8.
+// Invalid code units are decoded as U+FFFD. Bytes of UTF-8 are not validated
+func NewDecoder(r io.Reader, enc Encoding) io.Reader {
+	if enc == UTF8 {
+		return r
+	}
+	return &decoder{
+		r:   r,
+		enc: enc,
+		buf: make([]byte, MaxLengthPerNode),
+	}
+}
+
+type decoder struct {
+	r   io.Reader
+	enc Encoding
+	buf []byte
+	// undecoded bytes of an incomplete rune
+	pending []byte
+	// decoded bytes not read yet
+	out    []byte
+	outBuf []byte
+	err    error
+}
+
+func (d *decoder) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		n, err := d.r.Read(d.buf)
+		d.err = err
+		d.outBuf, d.pending = decodeUnits(d.enc, d.outBuf[:0], append(d.pending, d.buf[:n]...), err != nil)
+		d.out = d.outBuf
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+// decodeUnits appends UTF-8 of code units in src to dst, and returns bytes of an incomplete rune at the end unless final
+func decodeUnits(enc Encoding, dst, src []byte, final bool) ([]byte, []byte) {
+	size := enc.unitSize()
+loop:
+	for len(src) > 0 {
+		if len(src) < size {
+			if !final {
+				break
+			}
+			dst = utf8.AppendRune(dst, utf8.RuneError)
+			src = nil
+			break
+		}
+		ru, l := utf8.RuneError, size
+		switch enc {
+		case Latin1:
+			ru = rune(src[0])
+		case Windows1252:
+			ru = windows1252Rune(src[0])
+		case UTF16LE, UTF16BE:
+			order := byteOrder(enc)
+			ru = rune(order.Uint16(src))
+			if utf16.IsSurrogate(ru) {
+				if ru < 0xdc00 && len(src) < 4 && !final { // high surrogate, low one not read yet
+					break loop
+				}
+				if ru < 0xdc00 && len(src) >= 4 {
+					if r2 := utf16.DecodeRune(ru, rune(order.Uint16(src[2:]))); r2 != utf8.RuneError {
+						ru, l = r2, 4
+						break
+					}
+				}
+				ru = utf8.RuneError
+			}
+		case UTF32LE, UTF32BE:
+			ru = rune(byteOrder(enc).Uint32(src))
+			if !utf8.ValidRune(ru) {
+				ru = utf8.RuneError
+			}
+		}
+		dst = utf8.AppendRune(dst, ru)
+		src = src[l:]
+	}
+	return dst, bytes.Clone(src)
+}
+
+func byteOrder(enc Encoding) binary.ByteOrder {
+	if enc == UTF16BE || enc == UTF32BE {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// windows1252 maps bytes 0x80 to 0x9f. Undefined ones map to the same C1 controls, so they round trip
+var windows1252 = [32]rune{
+	0x20ac, 0x81, 0x201a, 0x0192, 0x201e, 0x2026, 0x2020, 0x2021,
+	0x02c6, 0x2030, 0x0160, 0x2039, 0x0152, 0x8d, 0x017d, 0x8f,
+	0x90, 0x2018, 0x2019, 0x201c, 0x201d, 0x2022, 0x2013, 0x2014,
+	0x02dc, 0x2122, 0x0161, 0x203a, 0x0153, 0x9d, 0x017e, 0x0178,
+}
+
+func windows1252Rune(b byte) rune {
+	if 0x80 <= b && b < 0xa0 {
+		return windows1252[b-0x80]
+	}
+	return rune(b)
+}
+
+// NewEncoder returns a writer converting UTF-8 to the encoding, writing the BOM first if any.
+// Invalid UTF-8 is encoded as U+FFFD. Runes not in Latin1 or Windows1252 fail with ErrUnencodable.
+// Close flushes an incomplete rune and the BOM of empty text, but does not close w
+func NewEncoder(w io.Writer, info EncodingInfo) io.WriteCloser {
+	enc := &encoder{
+		w:   w,
+		enc: info.Encoding,
+	}
+	if info.BOM {
+		enc.bom = info.Encoding.bom()
+	}
+	return enc
+}
+
+type encoder struct {
+	w   io.Writer
+	enc Encoding
+	// not written yet
+	bom []byte
+	// bytes of an incomplete rune
+	pending []byte
+	buf     []byte
+}
+
+func (e *encoder) Write(p []byte) (int, error) {
+	if err := e.encode(p, false); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (e *encoder) Close() error {
+	return e.encode(nil, true)
+}
+
+func (e *encoder) encode(p []byte, final bool) error {
+	buf := append(e.buf[:0], e.bom...)
+	e.bom = nil
+	src := p
+	if len(e.pending) > 0 {
+		src = append(e.pending, p...)
+		e.pending = nil
+	}
+	if e.enc == UTF8 {
+		buf = append(buf, src...)
+		src = nil
+	}
+	for len(src) > 0 {
+		if !final && !utf8.FullRune(src) {
+			e.pending = bytes.Clone(src)
+			break
+		}
+		ru, l := utf8.DecodeRune(src)
+		src = src[l:]
+		switch e.enc {
+		case Latin1, Windows1252:
+			b, ok := encodeByte(e.enc, ru)
+			if !ok {
+				return fmt.Errorf("%w: %U in %v", ErrUnencodable, ru, e.enc)
+			}
+			buf = append(buf, b)
+		case UTF16LE, UTF16BE:
+			order := byteOrder(e.enc).(binary.AppendByteOrder)
+			if r1, r2 := utf16.EncodeRune(ru); r1 != utf8.RuneError {
+				buf = order.AppendUint16(buf, uint16(r1))
+				ru = r2
+			}
+			buf = order.AppendUint16(buf, uint16(ru))
+		case UTF32LE, UTF32BE:
+			buf = byteOrder(e.enc).(binary.AppendByteOrder).AppendUint32(buf, uint32(ru))
+		}
+	}
+	e.buf = buf
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := e.w.Write(buf)
+	return err
+}
+
+func encodeByte(enc Encoding, ru rune) (byte, bool) {
+	if ru < 0x80 || 0xa0 <= ru && ru < 0x100 || enc == Latin1 && ru < 0x100 {
+		return byte(ru), true
+	}
+	if enc == Windows1252 {
+		for i, r := range windows1252 {
+			if r == ru {
+				return byte(0x80 + i), true
+			}
+		}
+	}
+	return 0, false
+}
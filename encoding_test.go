@@ -0,0 +1,202 @@
+package rope
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	mrand "math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDetectEncoding(t *testing.T) {
+	for _, c := range []struct {
+		prefix string
+		info   EncodingInfo
+	}{
+		{"", EncodingInfo{Encoding: UTF8}},
+		{"foo", EncodingInfo{Encoding: UTF8}},
+		{"\xef\xbb\xbffoo", EncodingInfo{Encoding: UTF8, BOM: true}},
+		{"\xff\xfef\x00", EncodingInfo{Encoding: UTF16LE, BOM: true}},
+		{"\xfe\xff\x00f", EncodingInfo{Encoding: UTF16BE, BOM: true}},
+		{"\xff\xfe\x00\x00f\x00\x00\x00", EncodingInfo{Encoding: UTF32LE, BOM: true}},
+		{"\x00\x00\xfe\xff\x00\x00\x00f", EncodingInfo{Encoding: UTF32BE, BOM: true}},
+		{"caf\xe9!", EncodingInfo{Encoding: Windows1252}},
+		{"caf\xc3", EncodingInfo{Encoding: UTF8}}, // rune cut by the sample size
+		{"caf\xc3\xa9", EncodingInfo{Encoding: UTF8}},
+	} {
+		if got := DetectEncoding([]byte(c.prefix)); got != c.info {
+			t.Fatalf("%q: got %+v", c.prefix, got)
+		}
+	}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	runes := map[Encoding][]rune{
+		Latin1:      {'a', '\n', 'é', 0xff, 0x85},
+		Windows1252: {'a', '\n', 'é', '€', 'Ÿ', 0x81},
+	}
+	for _, e := range []Encoding{UTF16LE, UTF16BE, UTF32LE, UTF32BE} {
+		runes[e] = []rune{'a', '\n', 'é', '€', '中', '😀', 0xffff}
+	}
+	runes[UTF8] = runes[UTF16LE]
+	for e, rs := range runes {
+		for _, bom := range []bool{false, true} {
+			info := EncodingInfo{Encoding: e, BOM: bom}
+			var text []rune
+			for i, n := 0, mrand.Intn(500); i < n; i++ {
+				text = append(text, rs[mrand.Intn(len(rs))])
+			}
+			utf8Text := []byte(string(text))
+
+			// encode in small writes
+			encoded := new(bytes.Buffer)
+			w := NewEncoder(encoded, info)
+			for bs := utf8Text; len(bs) > 0; {
+				n := min(len(bs), 1+mrand.Intn(5))
+				if _, err := w.Write(bs[:n]); err != nil {
+					t.Fatal(err)
+				}
+				bs = bs[n:]
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if bom && e.bom() != nil && !bytes.HasPrefix(encoded.Bytes(), e.bom()) {
+				t.Fatalf("%v: no BOM", e)
+			}
+
+			// decode from one byte reads
+			var r *Rope
+			var err error
+			if bom && e.bom() != nil || e == UTF8 {
+				var detected EncodingInfo
+				r, detected, err = NewFromReaderEncoding(iotest.OneByteReader(bytes.NewReader(encoded.Bytes())))
+				if detected != info {
+					t.Fatalf("%v: detected %+v", info, detected)
+				}
+			} else {
+				r, err = NewFromReader(NewDecoder(iotest.OneByteReader(bytes.NewReader(encoded.Bytes())), e))
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(r.Bytes(), utf8Text) {
+				t.Fatalf("%v: got %q, expected %q", info, r.Bytes(), utf8Text)
+			}
+		}
+	}
+}
+
+func TestEncodingInvalid(t *testing.T) {
+	for _, c := range []struct {
+		enc      Encoding
+		input    string
+		expected string
+	}{
+		{UTF16LE, "a\x00\x00\xd8b\x00", "a�b"}, // unpaired high surrogate
+		{UTF16BE, "\xdc\x00\x00a", "�a"},       // unpaired low surrogate
+		{UTF16LE, "a\x00b", "a�"},              // odd length
+		{UTF32LE, "\x00\x00\x11\x00a\x00\x00\x00", "�a"},
+		{Windows1252, "\x80\x8d\xe9", "€\u008dé"},
+	} {
+		r, err := NewFromReader(NewDecoder(bytes.NewReader([]byte(c.input)), c.enc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(r.Bytes()) != c.expected {
+			t.Fatalf("%q: got %q", c.input, r.Bytes())
+		}
+	}
+
+	w := NewEncoder(io.Discard, EncodingInfo{Encoding: Latin1})
+	if _, err := w.Write([]byte("€")); !errors.Is(err, ErrUnencodable) {
+		t.Fatal()
+	}
+	buf := new(bytes.Buffer)
+	w = NewEncoder(buf, EncodingInfo{Encoding: UTF16LE, BOM: true})
+	if _, err := w.Write([]byte("a\xe4")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "\xff\xfea\x00\xfd\xff" {
+		t.Fatalf("got %q", buf.Bytes())
+	}
+}
+
+func TestLoadSaveEncoding(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo")
+	content := "\xff\xfe" + "f\x00o\x00\xe9\x00\r\x00\n\x00=\xd8\x00\xde" // foé\r\n😀
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, info, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info != (EncodingInfo{Encoding: UTF16LE, BOM: true}) || string(r.Bytes()) != "foé\r\n😀" {
+		t.Fatalf("%+v %q", info, r.Bytes())
+	}
+	if err := SaveFile(path, r, &SaveOptions{
+		Encoder: info.Encoder(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(saved) != content {
+		t.Fatalf("got %q", saved)
+	}
+
+	if !errors.Is(SaveFile(path, r, &SaveOptions{
+		Encoder: EncodingInfo{Encoding: Windows1252}.Encoder(),
+	}), ErrUnencodable) {
+		t.Fatal()
+	}
+
+	// line endings are converted before encoding
+	if err := SaveFile(path, NewFromString("é\r\n€"), &SaveOptions{
+		LineEnding: LF,
+		Encoder:    EncodingInfo{Encoding: Windows1252}.Encoder(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	saved, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(saved) != "\xe9\n\x80" {
+		t.Fatalf("got %q", saved)
+	}
+
+	// UTF-8 without BOM
+	if err := os.WriteFile(path, []byte("foobar"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, info, err = LoadFile(path)
+	if err != nil || info != (EncodingInfo{Encoding: UTF8}) || string(r.Bytes()) != "foobar" {
+		t.Fatal()
+	}
+	// leaves do not refer to the file
+	_, keep := r.Split(3)
+	r = nil
+	runtime.GC()
+	runtime.GC()
+	if err := os.WriteFile(path, []byte("xxxxxx"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if string(keep.Bytes()) != "bar" {
+		t.Fatal()
+	}
+	if (EncodingInfo{Encoding: UTF8}).Encoder() != nil {
+		t.Fatal()
+	}
+}
@@ -0,0 +1,128 @@
+package rope
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// viewReader returns n bytes at off of the viewed file
+type viewReader func(off int64, n int) ([]byte, error)
+
+// OpenFile returns a rope viewing the file at path.
+// The file is memory mapped where supported, and nodes and leaves are created on first access.
+// The file must not be modified in place while the rope is in use; SaveFile replaces it by rename
+func OpenFile(path string) (*Rope, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if info.Size() == 0 {
+		f.Close()
+		return nil, nil
+	}
+	read, err := mapFile(f, info.Size())
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return newView(read, 0, int(info.Size()), MaxLengthPerNode), nil
+}
+
+// NewFromReaderAt returns a rope viewing size bytes of ra. Leaves are read on first access
+func NewFromReaderAt(ra io.ReaderAt, size int64) *Rope {
+	if size == 0 {
+		return nil
+	}
+	return newView(readerAtViewReader(ra), 0, int(size), MaxLengthPerNode)
+}
+
+func readerAtViewReader(ra io.ReaderAt) viewReader {
+	return func(off int64, n int) ([]byte, error) {
+		buf := make([]byte, n)
+		l, err := ra.ReadAt(buf, off)
+		if l == n {
+			return buf, nil
+		}
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+}
+
+// newView returns a node viewing size bytes at off.
+// Leaves are split evenly between children, so heights of children differ by at most one
+func newView(read viewReader, off int64, size int, max int) *Rope {
+	leaves := (size + max - 1) / max
+	if leaves == 1 {
+		return &Rope{
+			serial: atomic.AddInt64(&nextSerial, 1),
+			height: 1,
+			weight: size,
+			lazy: &lazyNode{
+				load: func(r *Rope) (err error) {
+					r.content, err = read(off, size)
+					return
+				},
+			},
+		}
+	}
+	// leaves of the left subtree
+	leftLeaves := (leaves + 1) / 2
+	height := 2
+	for n := leftLeaves; n > 1; n = (n + 1) / 2 {
+		height++
+	}
+	weight := leftLeaves * max
+	return &Rope{
+		serial: atomic.AddInt64(&nextSerial, 1),
+		height: height,
+		weight: weight,
+		lazy: &lazyNode{
+			load: func(r *Rope) error {
+				r.left = newView(read, off, weight, max)
+				r.right = newView(read, off+int64(weight), size-weight, max)
+				return nil
+			},
+		},
+	}
+}
+
+// writeFileAtomic writes to a temporary file in the same directory, syncs it, and renames it to path
+func writeFileAtomic(path string, perm os.FileMode, write func(io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
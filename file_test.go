@@ -0,0 +1,189 @@
+package rope
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+type countingReaderAt struct {
+	r     io.ReaderAt
+	reads int
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	c.reads++
+	return c.r.ReadAt(p, off)
+}
+
+func TestNewFromReaderAt(t *testing.T) {
+	if NewFromReaderAt(bytes.NewReader(nil), 0) != nil {
+		t.Fatal()
+	}
+
+	for _, n := range []int{1, 8, 9, 64, 1000, 4096} {
+		bs := getRandomBytes(n)
+		ra := &countingReaderAt{
+			r: bytes.NewReader(bs),
+		}
+		r := NewFromReaderAt(ra, int64(n))
+		if r.Len() != n {
+			t.Fatal()
+		}
+		if r.Index(n/2) != bs[n/2] {
+			t.Fatal()
+		}
+		// leaves on the right spine, and the indexed one
+		if ra.reads > 2 {
+			t.Fatalf("%d", ra.reads)
+		}
+		if !bytes.Equal(r.Bytes(), bs) {
+			t.Fatal()
+		}
+		if ra.reads != (n+MaxLengthPerNode-1)/MaxLengthPerNode {
+			t.Fatal()
+		}
+		// perfect trees of full leaves have the same shape as NewFromBytes
+		if leaves := n / MaxLengthPerNode; n%MaxLengthPerNode == 0 && leaves&(leaves-1) == 0 {
+			if !r.StructEqual(NewFromBytes(bs)) {
+				t.Fatal()
+			}
+		}
+	}
+
+	// read error
+	r := NewFromReaderAt(bytes.NewReader([]byte("foo")), 100)
+	func() {
+		defer func() {
+			p := recover()
+			if err, ok := p.(error); !ok || !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Fatal()
+			}
+		}()
+		r.Bytes()
+	}()
+}
+
+func TestOpenFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo")
+
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != nil {
+		t.Fatal()
+	}
+	if _, err := OpenFile(filepath.Join(dir, "bar")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal()
+	}
+
+	bs := getRandomBytes(4099)
+	if err := os.WriteFile(path, bs, 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, err = OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(r.Bytes(), bs) {
+		t.Fatal()
+	}
+
+	// edit and save back
+	r2 := r.Insert(100, []byte("foo")).Delete(2000, 1000)
+	r2 = r2.Concat(NewFromBytes([]byte("bar")))
+	expected := bytes.Join([][]byte{bs[:100], []byte("foo"), bs[100:1997], bs[2997:], []byte("bar")}, nil)
+	if !bytes.Equal(r2.Bytes(), expected) {
+		t.Fatal()
+	}
+	if err := SaveFile(path, r2, nil); err != nil {
+		t.Fatal(err)
+	}
+	// old version still readable
+	if !bytes.Equal(r.Bytes(), bs) {
+		t.Fatal()
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, expected) {
+		t.Fatal()
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatal()
+	}
+
+	r3, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r3.Equal(r2) {
+		t.Fatal()
+	}
+}
+
+func TestOpenFileGC(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo")
+	if err := os.WriteFile(path, []byte("foobar"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, keep := r.Split(3)
+	r = nil
+	runtime.GC()
+	runtime.GC()
+	if string(keep.Bytes()) != "bar" {
+		t.Fatal()
+	}
+
+	// the mapping is released after all leaves are loaded
+	if runtime.GOOS != "linux" {
+		return
+	}
+	path = filepath.Join(dir, "bar")
+	if err := os.WriteFile(path, getRandomBytes(4099), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mapped := func() bool {
+		maps, err := os.ReadFile("/proc/self/maps")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bytes.Contains(maps, []byte(path))
+	}
+	r, err = OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mapped() {
+		t.Fatal()
+	}
+	r.Bytes()
+	r = nil
+	for i := 0; mapped(); i++ {
+		if i == 100 {
+			t.Fatal("not unmapped")
+		}
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,189 @@
+package rope
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FileStore is a Store keeping each node in a file under dir/nodes, and root reference counts in dir/roots
+type FileStore struct {
+	dir   string
+	mu    sync.Mutex
+	roots map[Hash]int
+}
+
+var _ Store = new(FileStore)
+
+func OpenFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "nodes"), 0755); err != nil {
+		return nil, err
+	}
+	s := &FileStore{
+		dir:   dir,
+		roots: make(map[Hash]int),
+	}
+	f, err := os.Open(filepath.Join(dir, "roots"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("bad roots line: %q", scanner.Text())
+		}
+		h, err := ParseHash(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		s.roots[h] = n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func ParseHash(s string) (h Hash, err error) {
+	bs, err := hex.DecodeString(s)
+	if err != nil {
+		return
+	}
+	if len(bs) != len(h) {
+		return h, fmt.Errorf("bad hash: %q", s)
+	}
+	copy(h[:], bs)
+	return
+}
+
+func (s *FileStore) path(h Hash) string {
+	name := h.String()
+	return filepath.Join(s.dir, "nodes", name[:2], name)
+}
+
+func (s *FileStore) Get(h Hash) ([]byte, error) {
+	data, err := os.ReadFile(s.path(h))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, h)
+	}
+	return data, err
+}
+
+func (s *FileStore) Has(h Hash) (bool, error) {
+	_, err := os.Stat(s.path(h))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *FileStore) Put(h Hash, data []byte) error {
+	path := s.path(h)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, 0644, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+func (s *FileStore) Delete(h Hash) error {
+	err := os.Remove(s.path(h))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileStore) IterHashes(fn func(Hash) bool) error {
+	stop := errors.New("stop")
+	err := filepath.WalkDir(filepath.Join(s.dir, "nodes"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		h, err := ParseHash(d.Name())
+		if err != nil { // temp files
+			return nil
+		}
+		if !fn(h) {
+			return stop
+		}
+		return nil
+	})
+	if err == stop {
+		return nil
+	}
+	return err
+}
+
+func (s *FileStore) Ref(root Hash, delta int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := s.roots[root] + delta
+	if n < 0 {
+		return 0, fmt.Errorf("negative reference count: %v", root)
+	}
+	old, ok := s.roots[root]
+	if n == 0 {
+		delete(s.roots, root)
+	} else {
+		s.roots[root] = n
+	}
+	if err := s.writeRoots(); err != nil {
+		if ok {
+			s.roots[root] = old
+		} else {
+			delete(s.roots, root)
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+func (s *FileStore) writeRoots() error {
+	var b strings.Builder
+	for h, n := range s.roots {
+		fmt.Fprintf(&b, "%v %d\n", h, n)
+	}
+	return writeFileAtomic(filepath.Join(s.dir, "roots"), 0644, func(w io.Writer) error {
+		_, err := io.WriteString(w, b.String())
+		return err
+	})
+}
+
+func (s *FileStore) IterRoots(fn func(Hash) bool) error {
+	s.mu.Lock()
+	roots := make([]Hash, 0, len(s.roots))
+	for h := range s.roots {
+		roots = append(roots, h)
+	}
+	s.mu.Unlock()
+	for _, h := range roots {
+		if !fn(h) {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package rope
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := leafHash([]byte("foo"))
+	if ok, err := store.Has(h); err != nil || ok {
+		t.Fatal()
+	}
+	if _, err := store.Get(h); !errors.Is(err, ErrNotFound) {
+		t.Fatal()
+	}
+	if err := store.Put(h, []byte("foo")); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := store.Has(h); err != nil || !ok {
+		t.Fatal()
+	}
+	data, err := store.Get(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, []byte("foo")) {
+		t.Fatal()
+	}
+	n := 0
+	if err := store.IterHashes(func(h2 Hash) bool {
+		if h2 != h {
+			t.Fatal()
+		}
+		n++
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatal()
+	}
+	if err := store.Delete(h); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete(h); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := store.Has(h); err != nil || ok {
+		t.Fatal()
+	}
+
+	// roots
+	if n, err := store.Ref(h, 2); err != nil || n != 2 {
+		t.Fatal()
+	}
+	if _, err := store.Ref(h, -3); err == nil {
+		t.Fatal()
+	}
+	h2 := leafHash([]byte("bar"))
+	if _, err := store.Ref(h2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Ref(h2, -1); err != nil {
+		t.Fatal(err)
+	}
+
+	// reopen
+	store, err = OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roots []Hash
+	if err := store.IterRoots(func(h Hash) bool {
+		roots = append(roots, h)
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(roots) != 1 || roots[0] != h {
+		t.Fatal()
+	}
+	if n, err := store.Ref(h, -1); err != nil || n != 1 {
+		t.Fatal()
+	}
+}
+
+func TestParseHash(t *testing.T) {
+	h := leafHash([]byte("foo"))
+	h2, err := ParseHash(h.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h2 != h {
+		t.Fatal()
+	}
+	if _, err := ParseHash("foo"); err == nil {
+		t.Fatal()
+	}
+	if _, err := ParseHash("abcd"); err == nil {
+		t.Fatal()
+	}
+}
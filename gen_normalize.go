@@ -0,0 +1,236 @@
+//go:build ignore
+
+// gen_normalize generates normalize_tables.go and testdata/normalization.txt from golang.org/x/text/unicode/norm,
+// which is needed only to run it, and the simple case folding from CaseFolding.txt of the same version,
+// which is copied to testdata. -casefolding is its URL or local path
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var caseFolding = flag.String("casefolding", "https://www.unicode.org/Public/"+norm.Version+"/ucd/CaseFolding.txt", "URL or path of CaseFolding.txt")
+
+const (
+	hangulS     = 0xac00
+	hangulCount = 11172
+)
+
+func main() {
+	flag.Parse()
+	decomps := make(map[rune]string)
+	comps := make(map[[2]rune]rune)
+	type classRange struct {
+		first, last rune
+		class       uint8
+	}
+	var classes []classRange
+	for ru := rune(0); ru <= utf8.MaxRune; ru++ {
+		if !utf8.ValidRune(ru) {
+			continue
+		}
+		s := string(ru)
+		if class := norm.NFD.PropertiesString(s).CCC(); class != 0 {
+			if n := len(classes); n > 0 && classes[n-1].last == ru-1 && classes[n-1].class == class {
+				classes[n-1].last = ru
+			} else {
+				classes = append(classes, classRange{ru, ru, class})
+			}
+		}
+		if hangulS <= ru && ru < hangulS+hangulCount { // algorithmic
+			continue
+		}
+		d := norm.NFD.String(s)
+		if d == s {
+			continue
+		}
+		decomps[ru] = d
+		runes := []rune(d)
+		if len(runes) < 2 || norm.NFC.String(s) != s { // singletons, non-starter decompositions and exclusions
+			continue
+		}
+		// the primary composite of its decomposition without the last rune and the last rune
+		prefix := string(runes[:len(runes)-1])
+		first := []rune(norm.NFC.String(prefix))
+		if len(first) != 1 || norm.NFD.String(string(first)) != prefix {
+			panic(fmt.Sprintf("no composition pair of %U", ru))
+		}
+		comps[[2]rune{first[0], runes[len(runes)-1]}] = ru
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen_normalize.go from Unicode %s. DO NOT EDIT.\n\n", norm.Version)
+	buf.WriteString("package rope\n\n")
+	buf.WriteString("// decompositions are full canonical decompositions, except Hangul syllables\n")
+	buf.WriteString("var decompositions = map[rune]string{\n")
+	for i, ru := range sortedKeys(decomps) {
+		fmt.Fprintf(&buf, "%#x: %+q,", ru, decomps[ru])
+		if i%4 == 3 {
+			buf.WriteString("\n")
+		}
+	}
+	buf.WriteString("\n}\n\n")
+	buf.WriteString("// compositions are primary composites of pairs, except Hangul syllables\n")
+	buf.WriteString("var compositions = map[[2]rune]rune{\n")
+	pairs := make([][2]rune, 0, len(comps))
+	for pair := range comps {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return comps[pairs[i]] < comps[pairs[j]]
+	})
+	for i, pair := range pairs {
+		fmt.Fprintf(&buf, "{%#x, %#x}: %#x,", pair[0], pair[1], comps[pair])
+		if i%4 == 3 {
+			buf.WriteString("\n")
+		}
+	}
+	buf.WriteString("\n}\n\n")
+	buf.WriteString("// combiningClasses are ranges of runes of non-zero canonical combining classes\n")
+	buf.WriteString("var combiningClasses = []struct {\nfirst, last rune\nclass uint8\n}{\n")
+	for i, c := range classes {
+		fmt.Fprintf(&buf, "{%#x, %#x, %d},", c.first, c.last, c.class)
+		if i%4 == 3 {
+			buf.WriteString("\n")
+		}
+	}
+	buf.WriteString("\n}\n\n")
+
+	// common and simple mappings
+	foldingContent := read(*caseFolding)
+	if !strings.Contains(strings.SplitN(string(foldingContent), "\n", 2)[0], norm.Version) {
+		panic("CaseFolding.txt is not of Unicode " + norm.Version)
+	}
+	folds := make(map[rune]string)
+	for _, line := range strings.Split(string(foldingContent), "\n") {
+		fields := strings.Split(line, ";")
+		if strings.HasPrefix(line, "#") || len(fields) < 3 {
+			continue
+		}
+		if status := strings.TrimSpace(fields[1]); status != "C" && status != "S" {
+			continue
+		}
+		folds[parseRune(fields[0])] = string(parseRune(fields[2]))
+	}
+	buf.WriteString("// caseFolds are the simple case folding, C and S mappings of CaseFolding.txt\n")
+	buf.WriteString("var caseFolds = map[rune]rune{\n")
+	for i, ru := range sortedKeys(folds) {
+		fmt.Fprintf(&buf, "%#x: %#x,", ru, []rune(folds[ru])[0])
+		if i%6 == 5 {
+			buf.WriteString("\n")
+		}
+	}
+	buf.WriteString("\n}\n")
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile("normalize_tables.go", src, 0644); err != nil {
+		panic(err)
+	}
+
+	// test vectors in the format of NormalizationTest.txt, limited to canonical normalization
+	buf.Reset()
+	fmt.Fprintf(&buf, "# Generated by gen_normalize.go from Unicode %s in the format of NormalizationTest.txt.\n", norm.Version)
+	buf.WriteString("# Columns are source; NFC; NFD; NFKC; NFKD.\n")
+	buf.WriteString("# Runes not in Part1 are not changed by NFC and NFD, except Hangul syllables left out of it.\n")
+	line := func(runes ...rune) {
+		s := string(runes)
+		for _, col := range []string{s, norm.NFC.String(s), norm.NFD.String(s), norm.NFKC.String(s), norm.NFKD.String(s)} {
+			var hex []string
+			for _, ru := range col {
+				hex = append(hex, fmt.Sprintf("%04X", ru))
+			}
+			buf.WriteString(strings.Join(hex, " ") + ";")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("@Part0 # Specific cases\n")
+	for _, s := range []string{
+		"\u1e0a", "\u1e0c", "\u1e0a\u0323", "\u1e0c\u0307", "D\u0307\u0323", "D\u0323\u0307",
+		"\u01d5", "U\u0308\u0304", "\u1ebf", "\u00ea\u0301", "e\u0302\u0301",
+		"\u1100\uac00\u11a8", "\u1100\u1161\u11a8", "\u0b47\u0300\u0b3e", "\u09c7\u09be", "\u0f73",
+	} {
+		line([]rune(s)...)
+	}
+	buf.WriteString("@Part1 # Character by character test\n")
+	for ru := rune(0); ru <= utf8.MaxRune; ru++ {
+		s := string(ru)
+		if utf8.ValidRune(ru) && !(hangulS <= ru && ru < hangulS+hangulCount) && (norm.NFD.PropertiesString(s).CCC() != 0 || norm.NFC.String(s) != s || norm.NFD.String(s) != s) {
+			line(ru)
+		}
+	}
+	buf.WriteString("@Part2 # Canonical order test\n")
+	for _, c := range classes {
+		for ru := c.first; ru <= c.last; ru++ {
+			line('a', 0x315, 0x300, 0x5ae, ru, 0x300, 'b')
+		}
+	}
+	buf.WriteString("@Part3 # Composition with marks out of order\n")
+	for _, ru := range sortedKeys(decomps) {
+		if runes := []rune(decomps[ru]); len(runes) > 1 {
+			line(append([]rune{runes[0], 0x323}, runes[1:]...)...)
+			line(ru, 0x323)
+		}
+	}
+	if err := os.WriteFile("testdata/normalization.txt", buf.Bytes(), 0644); err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile("testdata/CaseFolding.txt", foldingContent, 0644); err != nil {
+		panic(err)
+	}
+}
+
+func parseRune(s string) rune {
+	ru, err := strconv.ParseUint(strings.TrimSpace(s), 16, 32)
+	if err != nil {
+		panic(err)
+	}
+	return rune(ru)
+}
+
+func read(name string) []byte {
+	if !strings.Contains(name, "://") {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			panic(err)
+		}
+		return content
+	}
+	resp, err := http.Get(name)
+	if err != nil {
+		panic(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		panic(fmt.Sprintf("%s: %s", name, resp.Status))
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
+
+func sortedKeys(m map[rune]string) []rune {
+	keys := make([]rune, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,261 @@
+//go:build ignore
+
+// gen_ucd generates tables of break properties and widths from the Unicode Character Database,
+// and copies its segmentation tests to testdata.
+// -ucd is the URL or the local directory of the UCD, in the layout of https://www.unicode.org/Public/<version>/ucd/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ucd = flag.String("ucd", "https://www.unicode.org/Public/17.0.0/ucd", "URL or directory of the UCD")
+
+func main() {
+	flag.Parse()
+	version := regexp.MustCompile(`-([0-9.]+)\.txt`).FindStringSubmatch(string(read("auxiliary/GraphemeBreakProperty.txt")))[1]
+
+	// grapheme cluster breaks
+	graphemes := make(table)
+	parse("auxiliary/GraphemeBreakProperty.txt", func(first, last rune, fields []string) {
+		graphemes.set(first, last, map[string]string{
+			"CR":                 "gbCR",
+			"LF":                 "gbLF",
+			"Control":            "gbControl",
+			"Extend":             "gbExtend",
+			"ZWJ":                "gbZWJ",
+			"Regional_Indicator": "gbRI",
+			"Prepend":            "gbPrepend",
+			"SpacingMark":        "gbSpacingMark",
+			"L":                  "gbL",
+			"V":                  "gbV",
+			"T":                  "gbT",
+			"LV":                 "gbLV",
+			"LVT":                "gbLVT",
+		}[fields[0]])
+	})
+	parse("emoji/emoji-data.txt", func(first, last rune, fields []string) {
+		if fields[0] == "Extended_Pictographic" {
+			graphemes.set(first, last, "gbExtPict")
+		}
+	})
+	conjuncts := make(table)
+	parse("DerivedCoreProperties.txt", func(first, last rune, fields []string) {
+		if fields[0] == "InCB" {
+			conjuncts.set(first, last, map[string]string{
+				"Consonant": "incbConsonant",
+				"Extend":    "incbExtend",
+				"Linker":    "incbLinker",
+			}[fields[1]])
+		}
+	})
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen_ucd.go from Unicode %s. DO NOT EDIT.\n\n", version)
+	buf.WriteString("package rope\n\n")
+	graphemes.write(&buf, "graphemeProps", "gbProp", "Grapheme_Cluster_Break and Extended_Pictographic of runes other than Other")
+	conjuncts.write(&buf, "conjunctProps", "incbProp", "Indic_Conjunct_Break of runes other than None")
+	write("grapheme_tables.go", buf.Bytes())
+	write("testdata/GraphemeBreakTest.txt", read("auxiliary/GraphemeBreakTest.txt"))
+
+	// word and sentence breaks
+	words := make(table)
+	parse("auxiliary/WordBreakProperty.txt", func(first, last rune, fields []string) {
+		words.set(first, last, map[string]string{
+			"CR":                 "wbCR",
+			"LF":                 "wbLF",
+			"Newline":            "wbNewline",
+			"Extend":             "wbExtend",
+			"ZWJ":                "wbZWJ",
+			"Regional_Indicator": "wbRI",
+			"Format":             "wbFormat",
+			"Katakana":           "wbKatakana",
+			"Hebrew_Letter":      "wbHebrewLetter",
+			"ALetter":            "wbALetter",
+			"Single_Quote":       "wbSingleQuote",
+			"Double_Quote":       "wbDoubleQuote",
+			"MidNumLet":          "wbMidNumLet",
+			"MidLetter":          "wbMidLetter",
+			"MidNum":             "wbMidNum",
+			"Numeric":            "wbNumeric",
+			"ExtendNumLet":       "wbExtendNumLet",
+			"WSegSpace":          "wbWSegSpace",
+		}[fields[0]])
+	})
+	sentences := make(table)
+	parse("auxiliary/SentenceBreakProperty.txt", func(first, last rune, fields []string) {
+		sentences.set(first, last, map[string]string{
+			"CR":        "sbCR",
+			"LF":        "sbLF",
+			"Sep":       "sbSep",
+			"Extend":    "sbExtend",
+			"Format":    "sbFormat",
+			"Sp":        "sbSp",
+			"Lower":     "sbLower",
+			"Upper":     "sbUpper",
+			"OLetter":   "sbOLetter",
+			"Numeric":   "sbNumeric",
+			"ATerm":     "sbATerm",
+			"STerm":     "sbSTerm",
+			"Close":     "sbClose",
+			"SContinue": "sbSContinue",
+		}[fields[0]])
+	})
+	buf.Reset()
+	fmt.Fprintf(&buf, "// Code generated by gen_ucd.go from Unicode %s. DO NOT EDIT.\n\n", version)
+	buf.WriteString("package rope\n\n")
+	words.write(&buf, "wordProps", "wbProp", "Word_Break of runes other than Other")
+	sentences.write(&buf, "sentenceProps", "sbProp", "Sentence_Break of runes other than Other")
+	write("segment_tables.go", buf.Bytes())
+	write("testdata/WordBreakTest.txt", read("auxiliary/WordBreakTest.txt"))
+	write("testdata/SentenceBreakTest.txt", read("auxiliary/SentenceBreakTest.txt"))
+
+	// wide runes, listed or by default
+	widths := make(map[rune]string)
+	setWidth := func(first, last rune, fields []string) {
+		for ru := first; ru <= last; ru++ {
+			widths[ru] = fields[0]
+		}
+	}
+	parseMissing("EastAsianWidth.txt", setWidth)
+	parse("EastAsianWidth.txt", setWidth)
+	wide := make(table)
+	for ru, w := range widths {
+		if w == "W" || w == "F" {
+			wide.set(ru, ru, "true")
+		}
+	}
+	buf.Reset()
+	fmt.Fprintf(&buf, "// Code generated by gen_ucd.go from Unicode %s. DO NOT EDIT.\n\n", version)
+	buf.WriteString("package rope\n\n")
+	wide.write(&buf, "wideRunes", "bool", "runes of East_Asian_Width Wide and Fullwidth")
+	write("width_tables.go", buf.Bytes())
+}
+
+// table maps runes to names of properties
+type table map[rune]string
+
+func (t table) set(first, last rune, prop string) {
+	if prop == "" {
+		panic(fmt.Sprintf("unknown property of %U", first))
+	}
+	for ru := first; ru <= last; ru++ {
+		if p, ok := t[ru]; ok && p != prop {
+			panic(fmt.Sprintf("%U is both %s and %s", ru, p, prop))
+		}
+		t[ru] = prop
+	}
+}
+
+// write writes t as sorted ranges
+func (t table) write(w io.Writer, name string, typ string, doc string) {
+	runes := make([]rune, 0, len(t))
+	for ru := range t {
+		runes = append(runes, ru)
+	}
+	sort.Slice(runes, func(i, j int) bool {
+		return runes[i] < runes[j]
+	})
+	fmt.Fprintf(w, "// %s are %s\n", name, doc)
+	fmt.Fprintf(w, "var %s = []propRange[%s]{\n", name, typ)
+	n := 0
+	for i := 0; i < len(runes); {
+		j := i
+		for j+1 < len(runes) && runes[j+1] == runes[j]+1 && t[runes[j+1]] == t[runes[i]] {
+			j++
+		}
+		fmt.Fprintf(w, "{%#x, %#x, %s},", runes[i], runes[j], t[runes[i]])
+		if n++; n%4 == 0 {
+			fmt.Fprintln(w)
+		}
+		i = j + 1
+	}
+	fmt.Fprint(w, "\n}\n\n")
+}
+
+// parse calls fn with ranges of data lines of a UCD file, and their fields without comments
+func parse(name string, fn func(first, last rune, fields []string)) {
+	for _, line := range strings.Split(string(read(name)), "\n") {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) != "" {
+			parseLine(line, fn)
+		}
+	}
+}
+
+// parseMissing calls fn with ranges of @missing lines of a UCD file, which are defaults of runes not listed
+func parseMissing(name string, fn func(first, last rune, fields []string)) {
+	for _, line := range strings.Split(string(read(name)), "\n") {
+		if line, ok := strings.CutPrefix(line, "# @missing:"); ok {
+			parseLine(line, fn)
+		}
+	}
+}
+
+func parseLine(line string, fn func(first, last rune, fields []string)) {
+	fields := strings.Split(line, ";")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	first, last, _ := strings.Cut(fields[0], "..")
+	if last == "" {
+		last = first
+	}
+	fn(parseRune(first), parseRune(last), fields[1:])
+}
+
+func parseRune(s string) rune {
+	ru, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		panic(err)
+	}
+	return rune(ru)
+}
+
+func read(name string) []byte {
+	if !strings.Contains(*ucd, "://") {
+		content, err := os.ReadFile(path.Join(*ucd, name))
+		if err != nil {
+			panic(err)
+		}
+		return content
+	}
+	resp, err := http.Get(*ucd + "/" + name)
+	if err != nil {
+		panic(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		panic(fmt.Sprintf("%s: %s", name, resp.Status))
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
+
+func write(name string, content []byte) {
+	if strings.HasSuffix(name, ".go") {
+		var err error
+		content, err = format.Source(content)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if err := os.WriteFile(name, content, 0644); err != nil {
+		panic(err)
+	}
+}
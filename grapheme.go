@@ -0,0 +1,334 @@
+package rope
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// Grapheme cluster segmentation by the extended grapheme cluster rules of UAX #29,
+// with properties from grapheme_tables.go, generated by gen_ucd.go
+
+//go:generate go run gen_ucd.go
+
+type gbProp int
+
+const (
+	gbOther gbProp = iota
+	gbSOT          // start of text
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRI
+	gbPrepend
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+	gbExtPict
+)
+
+// incbProp is the Indic_Conjunct_Break property
+type incbProp int
+
+const (
+	incbNone incbProp = iota
+	incbConsonant
+	incbExtend
+	incbLinker
+)
+
+// propRange is a range of runes of a property
+type propRange[P any] struct {
+	first, last rune
+	prop        P
+}
+
+// lookupProp returns the property of ru in sorted ranges, or the zero P
+func lookupProp[P any](ranges []propRange[P], ru rune) (ret P) {
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ru <= ranges[i].last
+	})
+	if i < len(ranges) && ranges[i].first <= ru {
+		ret = ranges[i].prop
+	}
+	return
+}
+
+func graphemeProp(r rune) gbProp {
+	if 0x20 <= r && r < 0x7f { // fast path for ASCII
+		return gbOther
+	}
+	return lookupProp(graphemeProps, r)
+}
+
+func conjunctProp(r rune) incbProp {
+	if r < 0x300 { // fast path for Latin
+		return incbNone
+	}
+	return lookupProp(conjunctProps, r)
+}
+
+// gbState is the segmentation state after a rune
+type gbState struct {
+	prev gbProp
+	// ri is the number of consecutive regional indicators ending at prev
+	ri int
+	// pict reports whether prev ends an extended pictographic followed by extends
+	pict bool
+	// pictZWJ reports whether prev is a ZWJ following pict
+	pictZWJ bool
+	// conjunct is incbConsonant if prev ends a consonant followed by extends,
+	// and incbLinker if they include a linker
+	conjunct incbProp
+}
+
+// next reports whether there is a boundary before ru, and updates the state
+func (s *gbState) next(ru rune) (ret bool) {
+	cur := graphemeProp(ru)
+	incb := conjunctProp(ru)
+	prev := s.prev
+	switch {
+	case prev == gbSOT: // GB1
+		ret = true
+	case prev == gbCR && cur == gbLF: // GB3
+		ret = false
+	case prev == gbControl || prev == gbCR || prev == gbLF: // GB4
+		ret = true
+	case cur == gbControl || cur == gbCR || cur == gbLF: // GB5
+		ret = true
+	case prev == gbL && (cur == gbL || cur == gbV || cur == gbLV || cur == gbLVT): // GB6
+		ret = false
+	case (prev == gbLV || prev == gbV) && (cur == gbV || cur == gbT): // GB7
+		ret = false
+	case (prev == gbLVT || prev == gbT) && cur == gbT: // GB8
+		ret = false
+	case cur == gbExtend || cur == gbZWJ || cur == gbSpacingMark: // GB9, GB9a
+		ret = false
+	case prev == gbPrepend: // GB9b
+		ret = false
+	case s.conjunct == incbLinker && incb == incbConsonant: // GB9c
+		ret = false
+	case prev == gbZWJ && cur == gbExtPict && s.pictZWJ: // GB11
+		ret = false
+	case prev == gbRI && cur == gbRI && s.ri%2 == 1: // GB12, GB13
+		ret = false
+	default: // GB999
+		ret = true
+	}
+
+	if cur == gbRI {
+		s.ri++
+	} else {
+		s.ri = 0
+	}
+	s.pictZWJ = cur == gbZWJ && s.pict
+	s.pict = cur == gbExtPict || cur == gbExtend && s.pict
+	switch {
+	case incb == incbConsonant:
+		s.conjunct = incbConsonant
+	case incb == incbLinker && s.conjunct != incbNone:
+		s.conjunct = incbLinker
+	case incb != incbExtend:
+		s.conjunct = incbNone
+	}
+	s.prev = cur
+	return
+}
+
+// runeBefore decodes the rune ending at offset
+func (r *Rope) runeBefore(offset int) (rune, int) {
+	start := max(offset-utf8.UTFMax, 0)
+	return utf8.DecodeLastRune(r.Sub(start, offset-start))
+}
+
+// iterRunes calls fn with runes from offset and their offsets, across leaves. Invalid bytes are RuneError of size 1
+func (r *Rope) iterRunes(offset int, fn func(ru rune, offset int, size int) bool) bool {
+	var carry []byte
+	return r.Iter(offset, func(bs []byte) bool {
+		if len(carry) > 0 {
+			carry = append(carry, bs...)
+			bs = carry
+		}
+		for len(bs) > 0 {
+			if !utf8.FullRune(bs) && offset+len(bs) < r.Len() { // continues in next leaf
+				break
+			}
+			ru, l := utf8.DecodeRune(bs)
+			if !fn(ru, offset, l) {
+				return false
+			}
+			offset += l
+			bs = bs[l:]
+		}
+		carry = append(carry[:0:0], bs...)
+		return true
+	})
+}
+
+// graphemeState returns the segmentation state at offset, from runes before it
+func (r *Rope) graphemeState(offset int) (s gbState) {
+	if offset <= 0 {
+		s.prev = gbSOT
+		return
+	}
+	ru, l := r.runeBefore(offset)
+	s.prev = graphemeProp(ru)
+	switch s.prev {
+	case gbRI:
+		for pos := offset; pos > 0; pos -= l {
+			if ru, l = r.runeBefore(pos); graphemeProp(ru) != gbRI {
+				break
+			}
+			s.ri++
+		}
+	case gbExtPict:
+		s.pict = true
+	case gbExtend, gbZWJ:
+		// ExtPict Extend* before
+		pos := offset - l
+		for pos > 0 {
+			ru, l := r.runeBefore(pos)
+			p := graphemeProp(ru)
+			if p == gbExtPict {
+				s.pict = s.prev == gbExtend
+				s.pictZWJ = s.prev == gbZWJ
+				break
+			}
+			if p != gbExtend {
+				break
+			}
+			pos -= l
+		}
+	}
+	// Consonant [Extend Linker]* before
+	linked := false
+	for pos := offset; pos > 0; pos -= l {
+		ru, l = r.runeBefore(pos)
+		switch conjunctProp(ru) {
+		case incbConsonant:
+			s.conjunct = incbConsonant
+			if linked {
+				s.conjunct = incbLinker
+			}
+			return
+		case incbLinker:
+			linked = true
+		case incbExtend:
+		default:
+			return
+		}
+	}
+	return
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xc0 != 0x80
+}
+
+// runeStart returns the start of the rune containing offset
+func (r *Rope) runeStart(offset int) int {
+	for i := 0; i < utf8.UTFMax-1 && offset > 0 && offset < r.Len() && !isRuneStart(r.Index(offset)); i++ {
+		offset--
+	}
+	return offset
+}
+
+// IsGraphemeBoundary reports whether offset is between grapheme clusters
+func (r *Rope) IsGraphemeBoundary(offset int) bool {
+	if offset <= 0 || offset >= r.Len() {
+		return true
+	}
+	if !isRuneStart(r.Index(offset)) {
+		return false
+	}
+	s := r.graphemeState(offset)
+	ru, _ := utf8.DecodeRune(r.Sub(offset, utf8.UTFMax))
+	return s.next(ru)
+}
+
+// IterGraphemes calls fn with ranges of grapheme clusters from offset, which should be a boundary
+func (r *Rope) IterGraphemes(offset int, fn func(start, end int) bool) bool {
+	s := r.graphemeState(offset)
+	start := offset
+	if !r.iterRunes(offset, func(ru rune, pos int, _ int) bool {
+		if s.next(ru) && pos > start {
+			if !fn(start, pos) {
+				return false
+			}
+			start = pos
+		}
+		return true
+	}) {
+		return false
+	}
+	if l := r.Len(); start < l {
+		return fn(start, l)
+	}
+	return true
+}
+
+// NextGrapheme returns the first grapheme cluster boundary after offset, or the length if none
+func (r *Rope) NextGrapheme(offset int) int {
+	l := r.Len()
+	if offset >= l {
+		return l
+	}
+	offset = r.runeStart(max(offset, 0))
+	s := r.graphemeState(offset)
+	ret := l
+	r.iterRunes(offset, func(ru rune, pos int, _ int) bool {
+		if s.next(ru) && pos > offset {
+			ret = pos
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// PrevGrapheme returns the last grapheme cluster boundary before offset, or 0 if none
+func (r *Rope) PrevGrapheme(offset int) int {
+	offset = min(offset, r.Len())
+	for offset > 0 {
+		_, l := r.runeBefore(offset)
+		offset -= l
+		if r.IsGraphemeBoundary(offset) {
+			return offset
+		}
+	}
+	return 0
+}
+
+// GraphemeCount returns the number of grapheme clusters
+func (r *Rope) GraphemeCount() (n int) {
+	r.IterGraphemes(0, func(_, _ int) bool {
+		n++
+		return true
+	})
+	return
+}
+
+// GraphemeFloor returns the last grapheme cluster boundary at or before offset
+func (r *Rope) GraphemeFloor(offset int) int {
+	if r.IsGraphemeBoundary(offset) {
+		return max(min(offset, r.Len()), 0)
+	}
+	return r.PrevGrapheme(offset)
+}
+
+// GraphemeCeil returns the first grapheme cluster boundary at or after offset
+func (r *Rope) GraphemeCeil(offset int) int {
+	if r.IsGraphemeBoundary(offset) {
+		return max(min(offset, r.Len()), 0)
+	}
+	return r.NextGrapheme(offset)
+}
+
+// SnapGraphemes expands [from, to) to grapheme cluster boundaries, so deleting it does not split clusters
+func (r *Rope) SnapGraphemes(from, to int) (int, int) {
+	return r.GraphemeFloor(from), r.GraphemeCeil(to)
+}
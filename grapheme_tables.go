@@ -0,0 +1,516 @@
+// Code generated by gen_ucd.go from Unicode 17.0.0. DO NOT EDIT.
+
+package rope
+
+// graphemeProps are Grapheme_Cluster_Break and Extended_Pictographic of runes other than Other
+var graphemeProps = []propRange[gbProp]{
+	{0x0, 0x9, gbControl}, {0xa, 0xa, gbLF}, {0xb, 0xc, gbControl}, {0xd, 0xd, gbCR},
+	{0xe, 0x1f, gbControl}, {0x7f, 0x9f, gbControl}, {0xa9, 0xa9, gbExtPict}, {0xad, 0xad, gbControl},
+	{0xae, 0xae, gbExtPict}, {0x300, 0x36f, gbExtend}, {0x483, 0x489, gbExtend}, {0x591, 0x5bd, gbExtend},
+	{0x5bf, 0x5bf, gbExtend}, {0x5c1, 0x5c2, gbExtend}, {0x5c4, 0x5c5, gbExtend}, {0x5c7, 0x5c7, gbExtend},
+	{0x600, 0x605, gbPrepend}, {0x610, 0x61a, gbExtend}, {0x61c, 0x61c, gbControl}, {0x64b, 0x65f, gbExtend},
+	{0x670, 0x670, gbExtend}, {0x6d6, 0x6dc, gbExtend}, {0x6dd, 0x6dd, gbPrepend}, {0x6df, 0x6e4, gbExtend},
+	{0x6e7, 0x6e8, gbExtend}, {0x6ea, 0x6ed, gbExtend}, {0x70f, 0x70f, gbPrepend}, {0x711, 0x711, gbExtend},
+	{0x730, 0x74a, gbExtend}, {0x7a6, 0x7b0, gbExtend}, {0x7eb, 0x7f3, gbExtend}, {0x7fd, 0x7fd, gbExtend},
+	{0x816, 0x819, gbExtend}, {0x81b, 0x823, gbExtend}, {0x825, 0x827, gbExtend}, {0x829, 0x82d, gbExtend},
+	{0x859, 0x85b, gbExtend}, {0x890, 0x891, gbPrepend}, {0x897, 0x89f, gbExtend}, {0x8ca, 0x8e1, gbExtend},
+	{0x8e2, 0x8e2, gbPrepend}, {0x8e3, 0x902, gbExtend}, {0x903, 0x903, gbSpacingMark}, {0x93a, 0x93a, gbExtend},
+	{0x93b, 0x93b, gbSpacingMark}, {0x93c, 0x93c, gbExtend}, {0x93e, 0x940, gbSpacingMark}, {0x941, 0x948, gbExtend},
+	{0x949, 0x94c, gbSpacingMark}, {0x94d, 0x94d, gbExtend}, {0x94e, 0x94f, gbSpacingMark}, {0x951, 0x957, gbExtend},
+	{0x962, 0x963, gbExtend}, {0x981, 0x981, gbExtend}, {0x982, 0x983, gbSpacingMark}, {0x9bc, 0x9bc, gbExtend},
+	{0x9be, 0x9be, gbExtend}, {0x9bf, 0x9c0, gbSpacingMark}, {0x9c1, 0x9c4, gbExtend}, {0x9c7, 0x9c8, gbSpacingMark},
+	{0x9cb, 0x9cc, gbSpacingMark}, {0x9cd, 0x9cd, gbExtend}, {0x9d7, 0x9d7, gbExtend}, {0x9e2, 0x9e3, gbExtend},
+	{0x9fe, 0x9fe, gbExtend}, {0xa01, 0xa02, gbExtend}, {0xa03, 0xa03, gbSpacingMark}, {0xa3c, 0xa3c, gbExtend},
+	{0xa3e, 0xa40, gbSpacingMark}, {0xa41, 0xa42, gbExtend}, {0xa47, 0xa48, gbExtend}, {0xa4b, 0xa4d, gbExtend},
+	{0xa51, 0xa51, gbExtend}, {0xa70, 0xa71, gbExtend}, {0xa75, 0xa75, gbExtend}, {0xa81, 0xa82, gbExtend},
+	{0xa83, 0xa83, gbSpacingMark}, {0xabc, 0xabc, gbExtend}, {0xabe, 0xac0, gbSpacingMark}, {0xac1, 0xac5, gbExtend},
+	{0xac7, 0xac8, gbExtend}, {0xac9, 0xac9, gbSpacingMark}, {0xacb, 0xacc, gbSpacingMark}, {0xacd, 0xacd, gbExtend},
+	{0xae2, 0xae3, gbExtend}, {0xafa, 0xaff, gbExtend}, {0xb01, 0xb01, gbExtend}, {0xb02, 0xb03, gbSpacingMark},
+	{0xb3c, 0xb3c, gbExtend}, {0xb3e, 0xb3f, gbExtend}, {0xb40, 0xb40, gbSpacingMark}, {0xb41, 0xb44, gbExtend},
+	{0xb47, 0xb48, gbSpacingMark}, {0xb4b, 0xb4c, gbSpacingMark}, {0xb4d, 0xb4d, gbExtend}, {0xb55, 0xb57, gbExtend},
+	{0xb62, 0xb63, gbExtend}, {0xb82, 0xb82, gbExtend}, {0xbbe, 0xbbe, gbExtend}, {0xbbf, 0xbbf, gbSpacingMark},
+	{0xbc0, 0xbc0, gbExtend}, {0xbc1, 0xbc2, gbSpacingMark}, {0xbc6, 0xbc8, gbSpacingMark}, {0xbca, 0xbcc, gbSpacingMark},
+	{0xbcd, 0xbcd, gbExtend}, {0xbd7, 0xbd7, gbExtend}, {0xc00, 0xc00, gbExtend}, {0xc01, 0xc03, gbSpacingMark},
+	{0xc04, 0xc04, gbExtend}, {0xc3c, 0xc3c, gbExtend}, {0xc3e, 0xc40, gbExtend}, {0xc41, 0xc44, gbSpacingMark},
+	{0xc46, 0xc48, gbExtend}, {0xc4a, 0xc4d, gbExtend}, {0xc55, 0xc56, gbExtend}, {0xc62, 0xc63, gbExtend},
+	{0xc81, 0xc81, gbExtend}, {0xc82, 0xc83, gbSpacingMark}, {0xcbc, 0xcbc, gbExtend}, {0xcbe, 0xcbe, gbSpacingMark},
+	{0xcbf, 0xcc0, gbExtend}, {0xcc1, 0xcc1, gbSpacingMark}, {0xcc2, 0xcc2, gbExtend}, {0xcc3, 0xcc4, gbSpacingMark},
+	{0xcc6, 0xcc8, gbExtend}, {0xcca, 0xccd, gbExtend}, {0xcd5, 0xcd6, gbExtend}, {0xce2, 0xce3, gbExtend},
+	{0xcf3, 0xcf3, gbSpacingMark}, {0xd00, 0xd01, gbExtend}, {0xd02, 0xd03, gbSpacingMark}, {0xd3b, 0xd3c, gbExtend},
+	{0xd3e, 0xd3e, gbExtend}, {0xd3f, 0xd40, gbSpacingMark}, {0xd41, 0xd44, gbExtend}, {0xd46, 0xd48, gbSpacingMark},
+	{0xd4a, 0xd4c, gbSpacingMark}, {0xd4d, 0xd4d, gbExtend}, {0xd4e, 0xd4e, gbPrepend}, {0xd57, 0xd57, gbExtend},
+	{0xd62, 0xd63, gbExtend}, {0xd81, 0xd81, gbExtend}, {0xd82, 0xd83, gbSpacingMark}, {0xdca, 0xdca, gbExtend},
+	{0xdcf, 0xdcf, gbExtend}, {0xdd0, 0xdd1, gbSpacingMark}, {0xdd2, 0xdd4, gbExtend}, {0xdd6, 0xdd6, gbExtend},
+	{0xdd8, 0xdde, gbSpacingMark}, {0xddf, 0xddf, gbExtend}, {0xdf2, 0xdf3, gbSpacingMark}, {0xe31, 0xe31, gbExtend},
+	{0xe33, 0xe33, gbSpacingMark}, {0xe34, 0xe3a, gbExtend}, {0xe47, 0xe4e, gbExtend}, {0xeb1, 0xeb1, gbExtend},
+	{0xeb3, 0xeb3, gbSpacingMark}, {0xeb4, 0xebc, gbExtend}, {0xec8, 0xece, gbExtend}, {0xf18, 0xf19, gbExtend},
+	{0xf35, 0xf35, gbExtend}, {0xf37, 0xf37, gbExtend}, {0xf39, 0xf39, gbExtend}, {0xf3e, 0xf3f, gbSpacingMark},
+	{0xf71, 0xf7e, gbExtend}, {0xf7f, 0xf7f, gbSpacingMark}, {0xf80, 0xf84, gbExtend}, {0xf86, 0xf87, gbExtend},
+	{0xf8d, 0xf97, gbExtend}, {0xf99, 0xfbc, gbExtend}, {0xfc6, 0xfc6, gbExtend}, {0x102d, 0x1030, gbExtend},
+	{0x1031, 0x1031, gbSpacingMark}, {0x1032, 0x1037, gbExtend}, {0x1039, 0x103a, gbExtend}, {0x103b, 0x103c, gbSpacingMark},
+	{0x103d, 0x103e, gbExtend}, {0x1056, 0x1057, gbSpacingMark}, {0x1058, 0x1059, gbExtend}, {0x105e, 0x1060, gbExtend},
+	{0x1071, 0x1074, gbExtend}, {0x1082, 0x1082, gbExtend}, {0x1084, 0x1084, gbSpacingMark}, {0x1085, 0x1086, gbExtend},
+	{0x108d, 0x108d, gbExtend}, {0x109d, 0x109d, gbExtend}, {0x1100, 0x115f, gbL}, {0x1160, 0x11a7, gbV},
+	{0x11a8, 0x11ff, gbT}, {0x135d, 0x135f, gbExtend}, {0x1712, 0x1715, gbExtend}, {0x1732, 0x1734, gbExtend},
+	{0x1752, 0x1753, gbExtend}, {0x1772, 0x1773, gbExtend}, {0x17b4, 0x17b5, gbExtend}, {0x17b6, 0x17b6, gbSpacingMark},
+	{0x17b7, 0x17bd, gbExtend}, {0x17be, 0x17c5, gbSpacingMark}, {0x17c6, 0x17c6, gbExtend}, {0x17c7, 0x17c8, gbSpacingMark},
+	{0x17c9, 0x17d3, gbExtend}, {0x17dd, 0x17dd, gbExtend}, {0x180b, 0x180d, gbExtend}, {0x180e, 0x180e, gbControl},
+	{0x180f, 0x180f, gbExtend}, {0x1885, 0x1886, gbExtend}, {0x18a9, 0x18a9, gbExtend}, {0x1920, 0x1922, gbExtend},
+	{0x1923, 0x1926, gbSpacingMark}, {0x1927, 0x1928, gbExtend}, {0x1929, 0x192b, gbSpacingMark}, {0x1930, 0x1931, gbSpacingMark},
+	{0x1932, 0x1932, gbExtend}, {0x1933, 0x1938, gbSpacingMark}, {0x1939, 0x193b, gbExtend}, {0x1a17, 0x1a18, gbExtend},
+	{0x1a19, 0x1a1a, gbSpacingMark}, {0x1a1b, 0x1a1b, gbExtend}, {0x1a55, 0x1a55, gbSpacingMark}, {0x1a56, 0x1a56, gbExtend},
+	{0x1a57, 0x1a57, gbSpacingMark}, {0x1a58, 0x1a5e, gbExtend}, {0x1a60, 0x1a60, gbExtend}, {0x1a62, 0x1a62, gbExtend},
+	{0x1a65, 0x1a6c, gbExtend}, {0x1a6d, 0x1a72, gbSpacingMark}, {0x1a73, 0x1a7c, gbExtend}, {0x1a7f, 0x1a7f, gbExtend},
+	{0x1ab0, 0x1add, gbExtend}, {0x1ae0, 0x1aeb, gbExtend}, {0x1b00, 0x1b03, gbExtend}, {0x1b04, 0x1b04, gbSpacingMark},
+	{0x1b34, 0x1b3d, gbExtend}, {0x1b3e, 0x1b41, gbSpacingMark}, {0x1b42, 0x1b44, gbExtend}, {0x1b6b, 0x1b73, gbExtend},
+	{0x1b80, 0x1b81, gbExtend}, {0x1b82, 0x1b82, gbSpacingMark}, {0x1ba1, 0x1ba1, gbSpacingMark}, {0x1ba2, 0x1ba5, gbExtend},
+	{0x1ba6, 0x1ba7, gbSpacingMark}, {0x1ba8, 0x1bad, gbExtend}, {0x1be6, 0x1be6, gbExtend}, {0x1be7, 0x1be7, gbSpacingMark},
+	{0x1be8, 0x1be9, gbExtend}, {0x1bea, 0x1bec, gbSpacingMark}, {0x1bed, 0x1bed, gbExtend}, {0x1bee, 0x1bee, gbSpacingMark},
+	{0x1bef, 0x1bf3, gbExtend}, {0x1c24, 0x1c2b, gbSpacingMark}, {0x1c2c, 0x1c33, gbExtend}, {0x1c34, 0x1c35, gbSpacingMark},
+	{0x1c36, 0x1c37, gbExtend}, {0x1cd0, 0x1cd2, gbExtend}, {0x1cd4, 0x1ce0, gbExtend}, {0x1ce1, 0x1ce1, gbSpacingMark},
+	{0x1ce2, 0x1ce8, gbExtend}, {0x1ced, 0x1ced, gbExtend}, {0x1cf4, 0x1cf4, gbExtend}, {0x1cf7, 0x1cf7, gbSpacingMark},
+	{0x1cf8, 0x1cf9, gbExtend}, {0x1dc0, 0x1dff, gbExtend}, {0x200b, 0x200b, gbControl}, {0x200c, 0x200c, gbExtend},
+	{0x200d, 0x200d, gbZWJ}, {0x200e, 0x200f, gbControl}, {0x2028, 0x202e, gbControl}, {0x203c, 0x203c, gbExtPict},
+	{0x2049, 0x2049, gbExtPict}, {0x2060, 0x206f, gbControl}, {0x20d0, 0x20f0, gbExtend}, {0x2122, 0x2122, gbExtPict},
+	{0x2139, 0x2139, gbExtPict}, {0x2194, 0x2199, gbExtPict}, {0x21a9, 0x21aa, gbExtPict}, {0x231a, 0x231b, gbExtPict},
+	{0x2328, 0x2328, gbExtPict}, {0x23cf, 0x23cf, gbExtPict}, {0x23e9, 0x23f3, gbExtPict}, {0x23f8, 0x23fa, gbExtPict},
+	{0x24c2, 0x24c2, gbExtPict}, {0x25aa, 0x25ab, gbExtPict}, {0x25b6, 0x25b6, gbExtPict}, {0x25c0, 0x25c0, gbExtPict},
+	{0x25fb, 0x25fe, gbExtPict}, {0x2600, 0x2604, gbExtPict}, {0x260e, 0x260e, gbExtPict}, {0x2611, 0x2611, gbExtPict},
+	{0x2614, 0x2615, gbExtPict}, {0x2618, 0x2618, gbExtPict}, {0x261d, 0x261d, gbExtPict}, {0x2620, 0x2620, gbExtPict},
+	{0x2622, 0x2623, gbExtPict}, {0x2626, 0x2626, gbExtPict}, {0x262a, 0x262a, gbExtPict}, {0x262e, 0x262f, gbExtPict},
+	{0x2638, 0x263a, gbExtPict}, {0x2640, 0x2640, gbExtPict}, {0x2642, 0x2642, gbExtPict}, {0x2648, 0x2653, gbExtPict},
+	{0x265f, 0x2660, gbExtPict}, {0x2663, 0x2663, gbExtPict}, {0x2665, 0x2666, gbExtPict}, {0x2668, 0x2668, gbExtPict},
+	{0x267b, 0x267b, gbExtPict}, {0x267e, 0x267f, gbExtPict}, {0x2692, 0x2697, gbExtPict}, {0x2699, 0x2699, gbExtPict},
+	{0x269b, 0x269c, gbExtPict}, {0x26a0, 0x26a1, gbExtPict}, {0x26a7, 0x26a7, gbExtPict}, {0x26aa, 0x26ab, gbExtPict},
+	{0x26b0, 0x26b1, gbExtPict}, {0x26bd, 0x26be, gbExtPict}, {0x26c4, 0x26c5, gbExtPict}, {0x26c8, 0x26c8, gbExtPict},
+	{0x26ce, 0x26cf, gbExtPict}, {0x26d1, 0x26d1, gbExtPict}, {0x26d3, 0x26d4, gbExtPict}, {0x26e9, 0x26ea, gbExtPict},
+	{0x26f0, 0x26f5, gbExtPict}, {0x26f7, 0x26fa, gbExtPict}, {0x26fd, 0x26fd, gbExtPict}, {0x2702, 0x2702, gbExtPict},
+	{0x2705, 0x2705, gbExtPict}, {0x2708, 0x270d, gbExtPict}, {0x270f, 0x270f, gbExtPict}, {0x2712, 0x2712, gbExtPict},
+	{0x2714, 0x2714, gbExtPict}, {0x2716, 0x2716, gbExtPict}, {0x271d, 0x271d, gbExtPict}, {0x2721, 0x2721, gbExtPict},
+	{0x2728, 0x2728, gbExtPict}, {0x2733, 0x2734, gbExtPict}, {0x2744, 0x2744, gbExtPict}, {0x2747, 0x2747, gbExtPict},
+	{0x274c, 0x274c, gbExtPict}, {0x274e, 0x274e, gbExtPict}, {0x2753, 0x2755, gbExtPict}, {0x2757, 0x2757, gbExtPict},
+	{0x2763, 0x2764, gbExtPict}, {0x2795, 0x2797, gbExtPict}, {0x27a1, 0x27a1, gbExtPict}, {0x27b0, 0x27b0, gbExtPict},
+	{0x27bf, 0x27bf, gbExtPict}, {0x2934, 0x2935, gbExtPict}, {0x2b05, 0x2b07, gbExtPict}, {0x2b1b, 0x2b1c, gbExtPict},
+	{0x2b50, 0x2b50, gbExtPict}, {0x2b55, 0x2b55, gbExtPict}, {0x2cef, 0x2cf1, gbExtend}, {0x2d7f, 0x2d7f, gbExtend},
+	{0x2de0, 0x2dff, gbExtend}, {0x302a, 0x302f, gbExtend}, {0x3030, 0x3030, gbExtPict}, {0x303d, 0x303d, gbExtPict},
+	{0x3099, 0x309a, gbExtend}, {0x3297, 0x3297, gbExtPict}, {0x3299, 0x3299, gbExtPict}, {0xa66f, 0xa672, gbExtend},
+	{0xa674, 0xa67d, gbExtend}, {0xa69e, 0xa69f, gbExtend}, {0xa6f0, 0xa6f1, gbExtend}, {0xa802, 0xa802, gbExtend},
+	{0xa806, 0xa806, gbExtend}, {0xa80b, 0xa80b, gbExtend}, {0xa823, 0xa824, gbSpacingMark}, {0xa825, 0xa826, gbExtend},
+	{0xa827, 0xa827, gbSpacingMark}, {0xa82c, 0xa82c, gbExtend}, {0xa880, 0xa881, gbSpacingMark}, {0xa8b4, 0xa8c3, gbSpacingMark},
+	{0xa8c4, 0xa8c5, gbExtend}, {0xa8e0, 0xa8f1, gbExtend}, {0xa8ff, 0xa8ff, gbExtend}, {0xa926, 0xa92d, gbExtend},
+	{0xa947, 0xa951, gbExtend}, {0xa952, 0xa952, gbSpacingMark}, {0xa953, 0xa953, gbExtend}, {0xa960, 0xa97c, gbL},
+	{0xa980, 0xa982, gbExtend}, {0xa983, 0xa983, gbSpacingMark}, {0xa9b3, 0xa9b3, gbExtend}, {0xa9b4, 0xa9b5, gbSpacingMark},
+	{0xa9b6, 0xa9b9, gbExtend}, {0xa9ba, 0xa9bb, gbSpacingMark}, {0xa9bc, 0xa9bd, gbExtend}, {0xa9be, 0xa9bf, gbSpacingMark},
+	{0xa9c0, 0xa9c0, gbExtend}, {0xa9e5, 0xa9e5, gbExtend}, {0xaa29, 0xaa2e, gbExtend}, {0xaa2f, 0xaa30, gbSpacingMark},
+	{0xaa31, 0xaa32, gbExtend}, {0xaa33, 0xaa34, gbSpacingMark}, {0xaa35, 0xaa36, gbExtend}, {0xaa43, 0xaa43, gbExtend},
+	{0xaa4c, 0xaa4c, gbExtend}, {0xaa4d, 0xaa4d, gbSpacingMark}, {0xaa7c, 0xaa7c, gbExtend}, {0xaab0, 0xaab0, gbExtend},
+	{0xaab2, 0xaab4, gbExtend}, {0xaab7, 0xaab8, gbExtend}, {0xaabe, 0xaabf, gbExtend}, {0xaac1, 0xaac1, gbExtend},
+	{0xaaeb, 0xaaeb, gbSpacingMark}, {0xaaec, 0xaaed, gbExtend}, {0xaaee, 0xaaef, gbSpacingMark}, {0xaaf5, 0xaaf5, gbSpacingMark},
+	{0xaaf6, 0xaaf6, gbExtend}, {0xabe3, 0xabe4, gbSpacingMark}, {0xabe5, 0xabe5, gbExtend}, {0xabe6, 0xabe7, gbSpacingMark},
+	{0xabe8, 0xabe8, gbExtend}, {0xabe9, 0xabea, gbSpacingMark}, {0xabec, 0xabec, gbSpacingMark}, {0xabed, 0xabed, gbExtend},
+	{0xac00, 0xac00, gbLV}, {0xac01, 0xac1b, gbLVT}, {0xac1c, 0xac1c, gbLV}, {0xac1d, 0xac37, gbLVT},
+	{0xac38, 0xac38, gbLV}, {0xac39, 0xac53, gbLVT}, {0xac54, 0xac54, gbLV}, {0xac55, 0xac6f, gbLVT},
+	{0xac70, 0xac70, gbLV}, {0xac71, 0xac8b, gbLVT}, {0xac8c, 0xac8c, gbLV}, {0xac8d, 0xaca7, gbLVT},
+	{0xaca8, 0xaca8, gbLV}, {0xaca9, 0xacc3, gbLVT}, {0xacc4, 0xacc4, gbLV}, {0xacc5, 0xacdf, gbLVT},
+	{0xace0, 0xace0, gbLV}, {0xace1, 0xacfb, gbLVT}, {0xacfc, 0xacfc, gbLV}, {0xacfd, 0xad17, gbLVT},
+	{0xad18, 0xad18, gbLV}, {0xad19, 0xad33, gbLVT}, {0xad34, 0xad34, gbLV}, {0xad35, 0xad4f, gbLVT},
+	{0xad50, 0xad50, gbLV}, {0xad51, 0xad6b, gbLVT}, {0xad6c, 0xad6c, gbLV}, {0xad6d, 0xad87, gbLVT},
+	{0xad88, 0xad88, gbLV}, {0xad89, 0xada3, gbLVT}, {0xada4, 0xada4, gbLV}, {0xada5, 0xadbf, gbLVT},
+	{0xadc0, 0xadc0, gbLV}, {0xadc1, 0xaddb, gbLVT}, {0xaddc, 0xaddc, gbLV}, {0xaddd, 0xadf7, gbLVT},
+	{0xadf8, 0xadf8, gbLV}, {0xadf9, 0xae13, gbLVT}, {0xae14, 0xae14, gbLV}, {0xae15, 0xae2f, gbLVT},
+	{0xae30, 0xae30, gbLV}, {0xae31, 0xae4b, gbLVT}, {0xae4c, 0xae4c, gbLV}, {0xae4d, 0xae67, gbLVT},
+	{0xae68, 0xae68, gbLV}, {0xae69, 0xae83, gbLVT}, {0xae84, 0xae84, gbLV}, {0xae85, 0xae9f, gbLVT},
+	{0xaea0, 0xaea0, gbLV}, {0xaea1, 0xaebb, gbLVT}, {0xaebc, 0xaebc, gbLV}, {0xaebd, 0xaed7, gbLVT},
+	{0xaed8, 0xaed8, gbLV}, {0xaed9, 0xaef3, gbLVT}, {0xaef4, 0xaef4, gbLV}, {0xaef5, 0xaf0f, gbLVT},
+	{0xaf10, 0xaf10, gbLV}, {0xaf11, 0xaf2b, gbLVT}, {0xaf2c, 0xaf2c, gbLV}, {0xaf2d, 0xaf47, gbLVT},
+	{0xaf48, 0xaf48, gbLV}, {0xaf49, 0xaf63, gbLVT}, {0xaf64, 0xaf64, gbLV}, {0xaf65, 0xaf7f, gbLVT},
+	{0xaf80, 0xaf80, gbLV}, {0xaf81, 0xaf9b, gbLVT}, {0xaf9c, 0xaf9c, gbLV}, {0xaf9d, 0xafb7, gbLVT},
+	{0xafb8, 0xafb8, gbLV}, {0xafb9, 0xafd3, gbLVT}, {0xafd4, 0xafd4, gbLV}, {0xafd5, 0xafef, gbLVT},
+	{0xaff0, 0xaff0, gbLV}, {0xaff1, 0xb00b, gbLVT}, {0xb00c, 0xb00c, gbLV}, {0xb00d, 0xb027, gbLVT},
+	{0xb028, 0xb028, gbLV}, {0xb029, 0xb043, gbLVT}, {0xb044, 0xb044, gbLV}, {0xb045, 0xb05f, gbLVT},
+	{0xb060, 0xb060, gbLV}, {0xb061, 0xb07b, gbLVT}, {0xb07c, 0xb07c, gbLV}, {0xb07d, 0xb097, gbLVT},
+	{0xb098, 0xb098, gbLV}, {0xb099, 0xb0b3, gbLVT}, {0xb0b4, 0xb0b4, gbLV}, {0xb0b5, 0xb0cf, gbLVT},
+	{0xb0d0, 0xb0d0, gbLV}, {0xb0d1, 0xb0eb, gbLVT}, {0xb0ec, 0xb0ec, gbLV}, {0xb0ed, 0xb107, gbLVT},
+	{0xb108, 0xb108, gbLV}, {0xb109, 0xb123, gbLVT}, {0xb124, 0xb124, gbLV}, {0xb125, 0xb13f, gbLVT},
+	{0xb140, 0xb140, gbLV}, {0xb141, 0xb15b, gbLVT}, {0xb15c, 0xb15c, gbLV}, {0xb15d, 0xb177, gbLVT},
+	{0xb178, 0xb178, gbLV}, {0xb179, 0xb193, gbLVT}, {0xb194, 0xb194, gbLV}, {0xb195, 0xb1af, gbLVT},
+	{0xb1b0, 0xb1b0, gbLV}, {0xb1b1, 0xb1cb, gbLVT}, {0xb1cc, 0xb1cc, gbLV}, {0xb1cd, 0xb1e7, gbLVT},
+	{0xb1e8, 0xb1e8, gbLV}, {0xb1e9, 0xb203, gbLVT}, {0xb204, 0xb204, gbLV}, {0xb205, 0xb21f, gbLVT},
+	{0xb220, 0xb220, gbLV}, {0xb221, 0xb23b, gbLVT}, {0xb23c, 0xb23c, gbLV}, {0xb23d, 0xb257, gbLVT},
+	{0xb258, 0xb258, gbLV}, {0xb259, 0xb273, gbLVT}, {0xb274, 0xb274, gbLV}, {0xb275, 0xb28f, gbLVT},
+	{0xb290, 0xb290, gbLV}, {0xb291, 0xb2ab, gbLVT}, {0xb2ac, 0xb2ac, gbLV}, {0xb2ad, 0xb2c7, gbLVT},
+	{0xb2c8, 0xb2c8, gbLV}, {0xb2c9, 0xb2e3, gbLVT}, {0xb2e4, 0xb2e4, gbLV}, {0xb2e5, 0xb2ff, gbLVT},
+	{0xb300, 0xb300, gbLV}, {0xb301, 0xb31b, gbLVT}, {0xb31c, 0xb31c, gbLV}, {0xb31d, 0xb337, gbLVT},
+	{0xb338, 0xb338, gbLV}, {0xb339, 0xb353, gbLVT}, {0xb354, 0xb354, gbLV}, {0xb355, 0xb36f, gbLVT},
+	{0xb370, 0xb370, gbLV}, {0xb371, 0xb38b, gbLVT}, {0xb38c, 0xb38c, gbLV}, {0xb38d, 0xb3a7, gbLVT},
+	{0xb3a8, 0xb3a8, gbLV}, {0xb3a9, 0xb3c3, gbLVT}, {0xb3c4, 0xb3c4, gbLV}, {0xb3c5, 0xb3df, gbLVT},
+	{0xb3e0, 0xb3e0, gbLV}, {0xb3e1, 0xb3fb, gbLVT}, {0xb3fc, 0xb3fc, gbLV}, {0xb3fd, 0xb417, gbLVT},
+	{0xb418, 0xb418, gbLV}, {0xb419, 0xb433, gbLVT}, {0xb434, 0xb434, gbLV}, {0xb435, 0xb44f, gbLVT},
+	{0xb450, 0xb450, gbLV}, {0xb451, 0xb46b, gbLVT}, {0xb46c, 0xb46c, gbLV}, {0xb46d, 0xb487, gbLVT},
+	{0xb488, 0xb488, gbLV}, {0xb489, 0xb4a3, gbLVT}, {0xb4a4, 0xb4a4, gbLV}, {0xb4a5, 0xb4bf, gbLVT},
+	{0xb4c0, 0xb4c0, gbLV}, {0xb4c1, 0xb4db, gbLVT}, {0xb4dc, 0xb4dc, gbLV}, {0xb4dd, 0xb4f7, gbLVT},
+	{0xb4f8, 0xb4f8, gbLV}, {0xb4f9, 0xb513, gbLVT}, {0xb514, 0xb514, gbLV}, {0xb515, 0xb52f, gbLVT},
+	{0xb530, 0xb530, gbLV}, {0xb531, 0xb54b, gbLVT}, {0xb54c, 0xb54c, gbLV}, {0xb54d, 0xb567, gbLVT},
+	{0xb568, 0xb568, gbLV}, {0xb569, 0xb583, gbLVT}, {0xb584, 0xb584, gbLV}, {0xb585, 0xb59f, gbLVT},
+	{0xb5a0, 0xb5a0, gbLV}, {0xb5a1, 0xb5bb, gbLVT}, {0xb5bc, 0xb5bc, gbLV}, {0xb5bd, 0xb5d7, gbLVT},
+	{0xb5d8, 0xb5d8, gbLV}, {0xb5d9, 0xb5f3, gbLVT}, {0xb5f4, 0xb5f4, gbLV}, {0xb5f5, 0xb60f, gbLVT},
+	{0xb610, 0xb610, gbLV}, {0xb611, 0xb62b, gbLVT}, {0xb62c, 0xb62c, gbLV}, {0xb62d, 0xb647, gbLVT},
+	{0xb648, 0xb648, gbLV}, {0xb649, 0xb663, gbLVT}, {0xb664, 0xb664, gbLV}, {0xb665, 0xb67f, gbLVT},
+	{0xb680, 0xb680, gbLV}, {0xb681, 0xb69b, gbLVT}, {0xb69c, 0xb69c, gbLV}, {0xb69d, 0xb6b7, gbLVT},
+	{0xb6b8, 0xb6b8, gbLV}, {0xb6b9, 0xb6d3, gbLVT}, {0xb6d4, 0xb6d4, gbLV}, {0xb6d5, 0xb6ef, gbLVT},
+	{0xb6f0, 0xb6f0, gbLV}, {0xb6f1, 0xb70b, gbLVT}, {0xb70c, 0xb70c, gbLV}, {0xb70d, 0xb727, gbLVT},
+	{0xb728, 0xb728, gbLV}, {0xb729, 0xb743, gbLVT}, {0xb744, 0xb744, gbLV}, {0xb745, 0xb75f, gbLVT},
+	{0xb760, 0xb760, gbLV}, {0xb761, 0xb77b, gbLVT}, {0xb77c, 0xb77c, gbLV}, {0xb77d, 0xb797, gbLVT},
+	{0xb798, 0xb798, gbLV}, {0xb799, 0xb7b3, gbLVT}, {0xb7b4, 0xb7b4, gbLV}, {0xb7b5, 0xb7cf, gbLVT},
+	{0xb7d0, 0xb7d0, gbLV}, {0xb7d1, 0xb7eb, gbLVT}, {0xb7ec, 0xb7ec, gbLV}, {0xb7ed, 0xb807, gbLVT},
+	{0xb808, 0xb808, gbLV}, {0xb809, 0xb823, gbLVT}, {0xb824, 0xb824, gbLV}, {0xb825, 0xb83f, gbLVT},
+	{0xb840, 0xb840, gbLV}, {0xb841, 0xb85b, gbLVT}, {0xb85c, 0xb85c, gbLV}, {0xb85d, 0xb877, gbLVT},
+	{0xb878, 0xb878, gbLV}, {0xb879, 0xb893, gbLVT}, {0xb894, 0xb894, gbLV}, {0xb895, 0xb8af, gbLVT},
+	{0xb8b0, 0xb8b0, gbLV}, {0xb8b1, 0xb8cb, gbLVT}, {0xb8cc, 0xb8cc, gbLV}, {0xb8cd, 0xb8e7, gbLVT},
+	{0xb8e8, 0xb8e8, gbLV}, {0xb8e9, 0xb903, gbLVT}, {0xb904, 0xb904, gbLV}, {0xb905, 0xb91f, gbLVT},
+	{0xb920, 0xb920, gbLV}, {0xb921, 0xb93b, gbLVT}, {0xb93c, 0xb93c, gbLV}, {0xb93d, 0xb957, gbLVT},
+	{0xb958, 0xb958, gbLV}, {0xb959, 0xb973, gbLVT}, {0xb974, 0xb974, gbLV}, {0xb975, 0xb98f, gbLVT},
+	{0xb990, 0xb990, gbLV}, {0xb991, 0xb9ab, gbLVT}, {0xb9ac, 0xb9ac, gbLV}, {0xb9ad, 0xb9c7, gbLVT},
+	{0xb9c8, 0xb9c8, gbLV}, {0xb9c9, 0xb9e3, gbLVT}, {0xb9e4, 0xb9e4, gbLV}, {0xb9e5, 0xb9ff, gbLVT},
+	{0xba00, 0xba00, gbLV}, {0xba01, 0xba1b, gbLVT}, {0xba1c, 0xba1c, gbLV}, {0xba1d, 0xba37, gbLVT},
+	{0xba38, 0xba38, gbLV}, {0xba39, 0xba53, gbLVT}, {0xba54, 0xba54, gbLV}, {0xba55, 0xba6f, gbLVT},
+	{0xba70, 0xba70, gbLV}, {0xba71, 0xba8b, gbLVT}, {0xba8c, 0xba8c, gbLV}, {0xba8d, 0xbaa7, gbLVT},
+	{0xbaa8, 0xbaa8, gbLV}, {0xbaa9, 0xbac3, gbLVT}, {0xbac4, 0xbac4, gbLV}, {0xbac5, 0xbadf, gbLVT},
+	{0xbae0, 0xbae0, gbLV}, {0xbae1, 0xbafb, gbLVT}, {0xbafc, 0xbafc, gbLV}, {0xbafd, 0xbb17, gbLVT},
+	{0xbb18, 0xbb18, gbLV}, {0xbb19, 0xbb33, gbLVT}, {0xbb34, 0xbb34, gbLV}, {0xbb35, 0xbb4f, gbLVT},
+	{0xbb50, 0xbb50, gbLV}, {0xbb51, 0xbb6b, gbLVT}, {0xbb6c, 0xbb6c, gbLV}, {0xbb6d, 0xbb87, gbLVT},
+	{0xbb88, 0xbb88, gbLV}, {0xbb89, 0xbba3, gbLVT}, {0xbba4, 0xbba4, gbLV}, {0xbba5, 0xbbbf, gbLVT},
+	{0xbbc0, 0xbbc0, gbLV}, {0xbbc1, 0xbbdb, gbLVT}, {0xbbdc, 0xbbdc, gbLV}, {0xbbdd, 0xbbf7, gbLVT},
+	{0xbbf8, 0xbbf8, gbLV}, {0xbbf9, 0xbc13, gbLVT}, {0xbc14, 0xbc14, gbLV}, {0xbc15, 0xbc2f, gbLVT},
+	{0xbc30, 0xbc30, gbLV}, {0xbc31, 0xbc4b, gbLVT}, {0xbc4c, 0xbc4c, gbLV}, {0xbc4d, 0xbc67, gbLVT},
+	{0xbc68, 0xbc68, gbLV}, {0xbc69, 0xbc83, gbLVT}, {0xbc84, 0xbc84, gbLV}, {0xbc85, 0xbc9f, gbLVT},
+	{0xbca0, 0xbca0, gbLV}, {0xbca1, 0xbcbb, gbLVT}, {0xbcbc, 0xbcbc, gbLV}, {0xbcbd, 0xbcd7, gbLVT},
+	{0xbcd8, 0xbcd8, gbLV}, {0xbcd9, 0xbcf3, gbLVT}, {0xbcf4, 0xbcf4, gbLV}, {0xbcf5, 0xbd0f, gbLVT},
+	{0xbd10, 0xbd10, gbLV}, {0xbd11, 0xbd2b, gbLVT}, {0xbd2c, 0xbd2c, gbLV}, {0xbd2d, 0xbd47, gbLVT},
+	{0xbd48, 0xbd48, gbLV}, {0xbd49, 0xbd63, gbLVT}, {0xbd64, 0xbd64, gbLV}, {0xbd65, 0xbd7f, gbLVT},
+	{0xbd80, 0xbd80, gbLV}, {0xbd81, 0xbd9b, gbLVT}, {0xbd9c, 0xbd9c, gbLV}, {0xbd9d, 0xbdb7, gbLVT},
+	{0xbdb8, 0xbdb8, gbLV}, {0xbdb9, 0xbdd3, gbLVT}, {0xbdd4, 0xbdd4, gbLV}, {0xbdd5, 0xbdef, gbLVT},
+	{0xbdf0, 0xbdf0, gbLV}, {0xbdf1, 0xbe0b, gbLVT}, {0xbe0c, 0xbe0c, gbLV}, {0xbe0d, 0xbe27, gbLVT},
+	{0xbe28, 0xbe28, gbLV}, {0xbe29, 0xbe43, gbLVT}, {0xbe44, 0xbe44, gbLV}, {0xbe45, 0xbe5f, gbLVT},
+	{0xbe60, 0xbe60, gbLV}, {0xbe61, 0xbe7b, gbLVT}, {0xbe7c, 0xbe7c, gbLV}, {0xbe7d, 0xbe97, gbLVT},
+	{0xbe98, 0xbe98, gbLV}, {0xbe99, 0xbeb3, gbLVT}, {0xbeb4, 0xbeb4, gbLV}, {0xbeb5, 0xbecf, gbLVT},
+	{0xbed0, 0xbed0, gbLV}, {0xbed1, 0xbeeb, gbLVT}, {0xbeec, 0xbeec, gbLV}, {0xbeed, 0xbf07, gbLVT},
+	{0xbf08, 0xbf08, gbLV}, {0xbf09, 0xbf23, gbLVT}, {0xbf24, 0xbf24, gbLV}, {0xbf25, 0xbf3f, gbLVT},
+	{0xbf40, 0xbf40, gbLV}, {0xbf41, 0xbf5b, gbLVT}, {0xbf5c, 0xbf5c, gbLV}, {0xbf5d, 0xbf77, gbLVT},
+	{0xbf78, 0xbf78, gbLV}, {0xbf79, 0xbf93, gbLVT}, {0xbf94, 0xbf94, gbLV}, {0xbf95, 0xbfaf, gbLVT},
+	{0xbfb0, 0xbfb0, gbLV}, {0xbfb1, 0xbfcb, gbLVT}, {0xbfcc, 0xbfcc, gbLV}, {0xbfcd, 0xbfe7, gbLVT},
+	{0xbfe8, 0xbfe8, gbLV}, {0xbfe9, 0xc003, gbLVT}, {0xc004, 0xc004, gbLV}, {0xc005, 0xc01f, gbLVT},
+	{0xc020, 0xc020, gbLV}, {0xc021, 0xc03b, gbLVT}, {0xc03c, 0xc03c, gbLV}, {0xc03d, 0xc057, gbLVT},
+	{0xc058, 0xc058, gbLV}, {0xc059, 0xc073, gbLVT}, {0xc074, 0xc074, gbLV}, {0xc075, 0xc08f, gbLVT},
+	{0xc090, 0xc090, gbLV}, {0xc091, 0xc0ab, gbLVT}, {0xc0ac, 0xc0ac, gbLV}, {0xc0ad, 0xc0c7, gbLVT},
+	{0xc0c8, 0xc0c8, gbLV}, {0xc0c9, 0xc0e3, gbLVT}, {0xc0e4, 0xc0e4, gbLV}, {0xc0e5, 0xc0ff, gbLVT},
+	{0xc100, 0xc100, gbLV}, {0xc101, 0xc11b, gbLVT}, {0xc11c, 0xc11c, gbLV}, {0xc11d, 0xc137, gbLVT},
+	{0xc138, 0xc138, gbLV}, {0xc139, 0xc153, gbLVT}, {0xc154, 0xc154, gbLV}, {0xc155, 0xc16f, gbLVT},
+	{0xc170, 0xc170, gbLV}, {0xc171, 0xc18b, gbLVT}, {0xc18c, 0xc18c, gbLV}, {0xc18d, 0xc1a7, gbLVT},
+	{0xc1a8, 0xc1a8, gbLV}, {0xc1a9, 0xc1c3, gbLVT}, {0xc1c4, 0xc1c4, gbLV}, {0xc1c5, 0xc1df, gbLVT},
+	{0xc1e0, 0xc1e0, gbLV}, {0xc1e1, 0xc1fb, gbLVT}, {0xc1fc, 0xc1fc, gbLV}, {0xc1fd, 0xc217, gbLVT},
+	{0xc218, 0xc218, gbLV}, {0xc219, 0xc233, gbLVT}, {0xc234, 0xc234, gbLV}, {0xc235, 0xc24f, gbLVT},
+	{0xc250, 0xc250, gbLV}, {0xc251, 0xc26b, gbLVT}, {0xc26c, 0xc26c, gbLV}, {0xc26d, 0xc287, gbLVT},
+	{0xc288, 0xc288, gbLV}, {0xc289, 0xc2a3, gbLVT}, {0xc2a4, 0xc2a4, gbLV}, {0xc2a5, 0xc2bf, gbLVT},
+	{0xc2c0, 0xc2c0, gbLV}, {0xc2c1, 0xc2db, gbLVT}, {0xc2dc, 0xc2dc, gbLV}, {0xc2dd, 0xc2f7, gbLVT},
+	{0xc2f8, 0xc2f8, gbLV}, {0xc2f9, 0xc313, gbLVT}, {0xc314, 0xc314, gbLV}, {0xc315, 0xc32f, gbLVT},
+	{0xc330, 0xc330, gbLV}, {0xc331, 0xc34b, gbLVT}, {0xc34c, 0xc34c, gbLV}, {0xc34d, 0xc367, gbLVT},
+	{0xc368, 0xc368, gbLV}, {0xc369, 0xc383, gbLVT}, {0xc384, 0xc384, gbLV}, {0xc385, 0xc39f, gbLVT},
+	{0xc3a0, 0xc3a0, gbLV}, {0xc3a1, 0xc3bb, gbLVT}, {0xc3bc, 0xc3bc, gbLV}, {0xc3bd, 0xc3d7, gbLVT},
+	{0xc3d8, 0xc3d8, gbLV}, {0xc3d9, 0xc3f3, gbLVT}, {0xc3f4, 0xc3f4, gbLV}, {0xc3f5, 0xc40f, gbLVT},
+	{0xc410, 0xc410, gbLV}, {0xc411, 0xc42b, gbLVT}, {0xc42c, 0xc42c, gbLV}, {0xc42d, 0xc447, gbLVT},
+	{0xc448, 0xc448, gbLV}, {0xc449, 0xc463, gbLVT}, {0xc464, 0xc464, gbLV}, {0xc465, 0xc47f, gbLVT},
+	{0xc480, 0xc480, gbLV}, {0xc481, 0xc49b, gbLVT}, {0xc49c, 0xc49c, gbLV}, {0xc49d, 0xc4b7, gbLVT},
+	{0xc4b8, 0xc4b8, gbLV}, {0xc4b9, 0xc4d3, gbLVT}, {0xc4d4, 0xc4d4, gbLV}, {0xc4d5, 0xc4ef, gbLVT},
+	{0xc4f0, 0xc4f0, gbLV}, {0xc4f1, 0xc50b, gbLVT}, {0xc50c, 0xc50c, gbLV}, {0xc50d, 0xc527, gbLVT},
+	{0xc528, 0xc528, gbLV}, {0xc529, 0xc543, gbLVT}, {0xc544, 0xc544, gbLV}, {0xc545, 0xc55f, gbLVT},
+	{0xc560, 0xc560, gbLV}, {0xc561, 0xc57b, gbLVT}, {0xc57c, 0xc57c, gbLV}, {0xc57d, 0xc597, gbLVT},
+	{0xc598, 0xc598, gbLV}, {0xc599, 0xc5b3, gbLVT}, {0xc5b4, 0xc5b4, gbLV}, {0xc5b5, 0xc5cf, gbLVT},
+	{0xc5d0, 0xc5d0, gbLV}, {0xc5d1, 0xc5eb, gbLVT}, {0xc5ec, 0xc5ec, gbLV}, {0xc5ed, 0xc607, gbLVT},
+	{0xc608, 0xc608, gbLV}, {0xc609, 0xc623, gbLVT}, {0xc624, 0xc624, gbLV}, {0xc625, 0xc63f, gbLVT},
+	{0xc640, 0xc640, gbLV}, {0xc641, 0xc65b, gbLVT}, {0xc65c, 0xc65c, gbLV}, {0xc65d, 0xc677, gbLVT},
+	{0xc678, 0xc678, gbLV}, {0xc679, 0xc693, gbLVT}, {0xc694, 0xc694, gbLV}, {0xc695, 0xc6af, gbLVT},
+	{0xc6b0, 0xc6b0, gbLV}, {0xc6b1, 0xc6cb, gbLVT}, {0xc6cc, 0xc6cc, gbLV}, {0xc6cd, 0xc6e7, gbLVT},
+	{0xc6e8, 0xc6e8, gbLV}, {0xc6e9, 0xc703, gbLVT}, {0xc704, 0xc704, gbLV}, {0xc705, 0xc71f, gbLVT},
+	{0xc720, 0xc720, gbLV}, {0xc721, 0xc73b, gbLVT}, {0xc73c, 0xc73c, gbLV}, {0xc73d, 0xc757, gbLVT},
+	{0xc758, 0xc758, gbLV}, {0xc759, 0xc773, gbLVT}, {0xc774, 0xc774, gbLV}, {0xc775, 0xc78f, gbLVT},
+	{0xc790, 0xc790, gbLV}, {0xc791, 0xc7ab, gbLVT}, {0xc7ac, 0xc7ac, gbLV}, {0xc7ad, 0xc7c7, gbLVT},
+	{0xc7c8, 0xc7c8, gbLV}, {0xc7c9, 0xc7e3, gbLVT}, {0xc7e4, 0xc7e4, gbLV}, {0xc7e5, 0xc7ff, gbLVT},
+	{0xc800, 0xc800, gbLV}, {0xc801, 0xc81b, gbLVT}, {0xc81c, 0xc81c, gbLV}, {0xc81d, 0xc837, gbLVT},
+	{0xc838, 0xc838, gbLV}, {0xc839, 0xc853, gbLVT}, {0xc854, 0xc854, gbLV}, {0xc855, 0xc86f, gbLVT},
+	{0xc870, 0xc870, gbLV}, {0xc871, 0xc88b, gbLVT}, {0xc88c, 0xc88c, gbLV}, {0xc88d, 0xc8a7, gbLVT},
+	{0xc8a8, 0xc8a8, gbLV}, {0xc8a9, 0xc8c3, gbLVT}, {0xc8c4, 0xc8c4, gbLV}, {0xc8c5, 0xc8df, gbLVT},
+	{0xc8e0, 0xc8e0, gbLV}, {0xc8e1, 0xc8fb, gbLVT}, {0xc8fc, 0xc8fc, gbLV}, {0xc8fd, 0xc917, gbLVT},
+	{0xc918, 0xc918, gbLV}, {0xc919, 0xc933, gbLVT}, {0xc934, 0xc934, gbLV}, {0xc935, 0xc94f, gbLVT},
+	{0xc950, 0xc950, gbLV}, {0xc951, 0xc96b, gbLVT}, {0xc96c, 0xc96c, gbLV}, {0xc96d, 0xc987, gbLVT},
+	{0xc988, 0xc988, gbLV}, {0xc989, 0xc9a3, gbLVT}, {0xc9a4, 0xc9a4, gbLV}, {0xc9a5, 0xc9bf, gbLVT},
+	{0xc9c0, 0xc9c0, gbLV}, {0xc9c1, 0xc9db, gbLVT}, {0xc9dc, 0xc9dc, gbLV}, {0xc9dd, 0xc9f7, gbLVT},
+	{0xc9f8, 0xc9f8, gbLV}, {0xc9f9, 0xca13, gbLVT}, {0xca14, 0xca14, gbLV}, {0xca15, 0xca2f, gbLVT},
+	{0xca30, 0xca30, gbLV}, {0xca31, 0xca4b, gbLVT}, {0xca4c, 0xca4c, gbLV}, {0xca4d, 0xca67, gbLVT},
+	{0xca68, 0xca68, gbLV}, {0xca69, 0xca83, gbLVT}, {0xca84, 0xca84, gbLV}, {0xca85, 0xca9f, gbLVT},
+	{0xcaa0, 0xcaa0, gbLV}, {0xcaa1, 0xcabb, gbLVT}, {0xcabc, 0xcabc, gbLV}, {0xcabd, 0xcad7, gbLVT},
+	{0xcad8, 0xcad8, gbLV}, {0xcad9, 0xcaf3, gbLVT}, {0xcaf4, 0xcaf4, gbLV}, {0xcaf5, 0xcb0f, gbLVT},
+	{0xcb10, 0xcb10, gbLV}, {0xcb11, 0xcb2b, gbLVT}, {0xcb2c, 0xcb2c, gbLV}, {0xcb2d, 0xcb47, gbLVT},
+	{0xcb48, 0xcb48, gbLV}, {0xcb49, 0xcb63, gbLVT}, {0xcb64, 0xcb64, gbLV}, {0xcb65, 0xcb7f, gbLVT},
+	{0xcb80, 0xcb80, gbLV}, {0xcb81, 0xcb9b, gbLVT}, {0xcb9c, 0xcb9c, gbLV}, {0xcb9d, 0xcbb7, gbLVT},
+	{0xcbb8, 0xcbb8, gbLV}, {0xcbb9, 0xcbd3, gbLVT}, {0xcbd4, 0xcbd4, gbLV}, {0xcbd5, 0xcbef, gbLVT},
+	{0xcbf0, 0xcbf0, gbLV}, {0xcbf1, 0xcc0b, gbLVT}, {0xcc0c, 0xcc0c, gbLV}, {0xcc0d, 0xcc27, gbLVT},
+	{0xcc28, 0xcc28, gbLV}, {0xcc29, 0xcc43, gbLVT}, {0xcc44, 0xcc44, gbLV}, {0xcc45, 0xcc5f, gbLVT},
+	{0xcc60, 0xcc60, gbLV}, {0xcc61, 0xcc7b, gbLVT}, {0xcc7c, 0xcc7c, gbLV}, {0xcc7d, 0xcc97, gbLVT},
+	{0xcc98, 0xcc98, gbLV}, {0xcc99, 0xccb3, gbLVT}, {0xccb4, 0xccb4, gbLV}, {0xccb5, 0xcccf, gbLVT},
+	{0xccd0, 0xccd0, gbLV}, {0xccd1, 0xcceb, gbLVT}, {0xccec, 0xccec, gbLV}, {0xcced, 0xcd07, gbLVT},
+	{0xcd08, 0xcd08, gbLV}, {0xcd09, 0xcd23, gbLVT}, {0xcd24, 0xcd24, gbLV}, {0xcd25, 0xcd3f, gbLVT},
+	{0xcd40, 0xcd40, gbLV}, {0xcd41, 0xcd5b, gbLVT}, {0xcd5c, 0xcd5c, gbLV}, {0xcd5d, 0xcd77, gbLVT},
+	{0xcd78, 0xcd78, gbLV}, {0xcd79, 0xcd93, gbLVT}, {0xcd94, 0xcd94, gbLV}, {0xcd95, 0xcdaf, gbLVT},
+	{0xcdb0, 0xcdb0, gbLV}, {0xcdb1, 0xcdcb, gbLVT}, {0xcdcc, 0xcdcc, gbLV}, {0xcdcd, 0xcde7, gbLVT},
+	{0xcde8, 0xcde8, gbLV}, {0xcde9, 0xce03, gbLVT}, {0xce04, 0xce04, gbLV}, {0xce05, 0xce1f, gbLVT},
+	{0xce20, 0xce20, gbLV}, {0xce21, 0xce3b, gbLVT}, {0xce3c, 0xce3c, gbLV}, {0xce3d, 0xce57, gbLVT},
+	{0xce58, 0xce58, gbLV}, {0xce59, 0xce73, gbLVT}, {0xce74, 0xce74, gbLV}, {0xce75, 0xce8f, gbLVT},
+	{0xce90, 0xce90, gbLV}, {0xce91, 0xceab, gbLVT}, {0xceac, 0xceac, gbLV}, {0xcead, 0xcec7, gbLVT},
+	{0xcec8, 0xcec8, gbLV}, {0xcec9, 0xcee3, gbLVT}, {0xcee4, 0xcee4, gbLV}, {0xcee5, 0xceff, gbLVT},
+	{0xcf00, 0xcf00, gbLV}, {0xcf01, 0xcf1b, gbLVT}, {0xcf1c, 0xcf1c, gbLV}, {0xcf1d, 0xcf37, gbLVT},
+	{0xcf38, 0xcf38, gbLV}, {0xcf39, 0xcf53, gbLVT}, {0xcf54, 0xcf54, gbLV}, {0xcf55, 0xcf6f, gbLVT},
+	{0xcf70, 0xcf70, gbLV}, {0xcf71, 0xcf8b, gbLVT}, {0xcf8c, 0xcf8c, gbLV}, {0xcf8d, 0xcfa7, gbLVT},
+	{0xcfa8, 0xcfa8, gbLV}, {0xcfa9, 0xcfc3, gbLVT}, {0xcfc4, 0xcfc4, gbLV}, {0xcfc5, 0xcfdf, gbLVT},
+	{0xcfe0, 0xcfe0, gbLV}, {0xcfe1, 0xcffb, gbLVT}, {0xcffc, 0xcffc, gbLV}, {0xcffd, 0xd017, gbLVT},
+	{0xd018, 0xd018, gbLV}, {0xd019, 0xd033, gbLVT}, {0xd034, 0xd034, gbLV}, {0xd035, 0xd04f, gbLVT},
+	{0xd050, 0xd050, gbLV}, {0xd051, 0xd06b, gbLVT}, {0xd06c, 0xd06c, gbLV}, {0xd06d, 0xd087, gbLVT},
+	{0xd088, 0xd088, gbLV}, {0xd089, 0xd0a3, gbLVT}, {0xd0a4, 0xd0a4, gbLV}, {0xd0a5, 0xd0bf, gbLVT},
+	{0xd0c0, 0xd0c0, gbLV}, {0xd0c1, 0xd0db, gbLVT}, {0xd0dc, 0xd0dc, gbLV}, {0xd0dd, 0xd0f7, gbLVT},
+	{0xd0f8, 0xd0f8, gbLV}, {0xd0f9, 0xd113, gbLVT}, {0xd114, 0xd114, gbLV}, {0xd115, 0xd12f, gbLVT},
+	{0xd130, 0xd130, gbLV}, {0xd131, 0xd14b, gbLVT}, {0xd14c, 0xd14c, gbLV}, {0xd14d, 0xd167, gbLVT},
+	{0xd168, 0xd168, gbLV}, {0xd169, 0xd183, gbLVT}, {0xd184, 0xd184, gbLV}, {0xd185, 0xd19f, gbLVT},
+	{0xd1a0, 0xd1a0, gbLV}, {0xd1a1, 0xd1bb, gbLVT}, {0xd1bc, 0xd1bc, gbLV}, {0xd1bd, 0xd1d7, gbLVT},
+	{0xd1d8, 0xd1d8, gbLV}, {0xd1d9, 0xd1f3, gbLVT}, {0xd1f4, 0xd1f4, gbLV}, {0xd1f5, 0xd20f, gbLVT},
+	{0xd210, 0xd210, gbLV}, {0xd211, 0xd22b, gbLVT}, {0xd22c, 0xd22c, gbLV}, {0xd22d, 0xd247, gbLVT},
+	{0xd248, 0xd248, gbLV}, {0xd249, 0xd263, gbLVT}, {0xd264, 0xd264, gbLV}, {0xd265, 0xd27f, gbLVT},
+	{0xd280, 0xd280, gbLV}, {0xd281, 0xd29b, gbLVT}, {0xd29c, 0xd29c, gbLV}, {0xd29d, 0xd2b7, gbLVT},
+	{0xd2b8, 0xd2b8, gbLV}, {0xd2b9, 0xd2d3, gbLVT}, {0xd2d4, 0xd2d4, gbLV}, {0xd2d5, 0xd2ef, gbLVT},
+	{0xd2f0, 0xd2f0, gbLV}, {0xd2f1, 0xd30b, gbLVT}, {0xd30c, 0xd30c, gbLV}, {0xd30d, 0xd327, gbLVT},
+	{0xd328, 0xd328, gbLV}, {0xd329, 0xd343, gbLVT}, {0xd344, 0xd344, gbLV}, {0xd345, 0xd35f, gbLVT},
+	{0xd360, 0xd360, gbLV}, {0xd361, 0xd37b, gbLVT}, {0xd37c, 0xd37c, gbLV}, {0xd37d, 0xd397, gbLVT},
+	{0xd398, 0xd398, gbLV}, {0xd399, 0xd3b3, gbLVT}, {0xd3b4, 0xd3b4, gbLV}, {0xd3b5, 0xd3cf, gbLVT},
+	{0xd3d0, 0xd3d0, gbLV}, {0xd3d1, 0xd3eb, gbLVT}, {0xd3ec, 0xd3ec, gbLV}, {0xd3ed, 0xd407, gbLVT},
+	{0xd408, 0xd408, gbLV}, {0xd409, 0xd423, gbLVT}, {0xd424, 0xd424, gbLV}, {0xd425, 0xd43f, gbLVT},
+	{0xd440, 0xd440, gbLV}, {0xd441, 0xd45b, gbLVT}, {0xd45c, 0xd45c, gbLV}, {0xd45d, 0xd477, gbLVT},
+	{0xd478, 0xd478, gbLV}, {0xd479, 0xd493, gbLVT}, {0xd494, 0xd494, gbLV}, {0xd495, 0xd4af, gbLVT},
+	{0xd4b0, 0xd4b0, gbLV}, {0xd4b1, 0xd4cb, gbLVT}, {0xd4cc, 0xd4cc, gbLV}, {0xd4cd, 0xd4e7, gbLVT},
+	{0xd4e8, 0xd4e8, gbLV}, {0xd4e9, 0xd503, gbLVT}, {0xd504, 0xd504, gbLV}, {0xd505, 0xd51f, gbLVT},
+	{0xd520, 0xd520, gbLV}, {0xd521, 0xd53b, gbLVT}, {0xd53c, 0xd53c, gbLV}, {0xd53d, 0xd557, gbLVT},
+	{0xd558, 0xd558, gbLV}, {0xd559, 0xd573, gbLVT}, {0xd574, 0xd574, gbLV}, {0xd575, 0xd58f, gbLVT},
+	{0xd590, 0xd590, gbLV}, {0xd591, 0xd5ab, gbLVT}, {0xd5ac, 0xd5ac, gbLV}, {0xd5ad, 0xd5c7, gbLVT},
+	{0xd5c8, 0xd5c8, gbLV}, {0xd5c9, 0xd5e3, gbLVT}, {0xd5e4, 0xd5e4, gbLV}, {0xd5e5, 0xd5ff, gbLVT},
+	{0xd600, 0xd600, gbLV}, {0xd601, 0xd61b, gbLVT}, {0xd61c, 0xd61c, gbLV}, {0xd61d, 0xd637, gbLVT},
+	{0xd638, 0xd638, gbLV}, {0xd639, 0xd653, gbLVT}, {0xd654, 0xd654, gbLV}, {0xd655, 0xd66f, gbLVT},
+	{0xd670, 0xd670, gbLV}, {0xd671, 0xd68b, gbLVT}, {0xd68c, 0xd68c, gbLV}, {0xd68d, 0xd6a7, gbLVT},
+	{0xd6a8, 0xd6a8, gbLV}, {0xd6a9, 0xd6c3, gbLVT}, {0xd6c4, 0xd6c4, gbLV}, {0xd6c5, 0xd6df, gbLVT},
+	{0xd6e0, 0xd6e0, gbLV}, {0xd6e1, 0xd6fb, gbLVT}, {0xd6fc, 0xd6fc, gbLV}, {0xd6fd, 0xd717, gbLVT},
+	{0xd718, 0xd718, gbLV}, {0xd719, 0xd733, gbLVT}, {0xd734, 0xd734, gbLV}, {0xd735, 0xd74f, gbLVT},
+	{0xd750, 0xd750, gbLV}, {0xd751, 0xd76b, gbLVT}, {0xd76c, 0xd76c, gbLV}, {0xd76d, 0xd787, gbLVT},
+	{0xd788, 0xd788, gbLV}, {0xd789, 0xd7a3, gbLVT}, {0xd7b0, 0xd7c6, gbV}, {0xd7cb, 0xd7fb, gbT},
+	{0xfb1e, 0xfb1e, gbExtend}, {0xfe00, 0xfe0f, gbExtend}, {0xfe20, 0xfe2f, gbExtend}, {0xfeff, 0xfeff, gbControl},
+	{0xff9e, 0xff9f, gbExtend}, {0xfff0, 0xfffb, gbControl}, {0x101fd, 0x101fd, gbExtend}, {0x102e0, 0x102e0, gbExtend},
+	{0x10376, 0x1037a, gbExtend}, {0x10a01, 0x10a03, gbExtend}, {0x10a05, 0x10a06, gbExtend}, {0x10a0c, 0x10a0f, gbExtend},
+	{0x10a38, 0x10a3a, gbExtend}, {0x10a3f, 0x10a3f, gbExtend}, {0x10ae5, 0x10ae6, gbExtend}, {0x10d24, 0x10d27, gbExtend},
+	{0x10d69, 0x10d6d, gbExtend}, {0x10eab, 0x10eac, gbExtend}, {0x10efa, 0x10eff, gbExtend}, {0x10f46, 0x10f50, gbExtend},
+	{0x10f82, 0x10f85, gbExtend}, {0x11000, 0x11000, gbSpacingMark}, {0x11001, 0x11001, gbExtend}, {0x11002, 0x11002, gbSpacingMark},
+	{0x11038, 0x11046, gbExtend}, {0x11070, 0x11070, gbExtend}, {0x11073, 0x11074, gbExtend}, {0x1107f, 0x11081, gbExtend},
+	{0x11082, 0x11082, gbSpacingMark}, {0x110b0, 0x110b2, gbSpacingMark}, {0x110b3, 0x110b6, gbExtend}, {0x110b7, 0x110b8, gbSpacingMark},
+	{0x110b9, 0x110ba, gbExtend}, {0x110bd, 0x110bd, gbPrepend}, {0x110c2, 0x110c2, gbExtend}, {0x110cd, 0x110cd, gbPrepend},
+	{0x11100, 0x11102, gbExtend}, {0x11127, 0x1112b, gbExtend}, {0x1112c, 0x1112c, gbSpacingMark}, {0x1112d, 0x11134, gbExtend},
+	{0x11145, 0x11146, gbSpacingMark}, {0x11173, 0x11173, gbExtend}, {0x11180, 0x11181, gbExtend}, {0x11182, 0x11182, gbSpacingMark},
+	{0x111b3, 0x111b5, gbSpacingMark}, {0x111b6, 0x111be, gbExtend}, {0x111bf, 0x111bf, gbSpacingMark}, {0x111c0, 0x111c0, gbExtend},
+	{0x111c2, 0x111c3, gbPrepend}, {0x111c9, 0x111cc, gbExtend}, {0x111ce, 0x111ce, gbSpacingMark}, {0x111cf, 0x111cf, gbExtend},
+	{0x1122c, 0x1122e, gbSpacingMark}, {0x1122f, 0x11231, gbExtend}, {0x11232, 0x11233, gbSpacingMark}, {0x11234, 0x11237, gbExtend},
+	{0x1123e, 0x1123e, gbExtend}, {0x11241, 0x11241, gbExtend}, {0x112df, 0x112df, gbExtend}, {0x112e0, 0x112e2, gbSpacingMark},
+	{0x112e3, 0x112ea, gbExtend}, {0x11300, 0x11301, gbExtend}, {0x11302, 0x11303, gbSpacingMark}, {0x1133b, 0x1133c, gbExtend},
+	{0x1133e, 0x1133e, gbExtend}, {0x1133f, 0x1133f, gbSpacingMark}, {0x11340, 0x11340, gbExtend}, {0x11341, 0x11344, gbSpacingMark},
+	{0x11347, 0x11348, gbSpacingMark}, {0x1134b, 0x1134c, gbSpacingMark}, {0x1134d, 0x1134d, gbExtend}, {0x11357, 0x11357, gbExtend},
+	{0x11362, 0x11363, gbSpacingMark}, {0x11366, 0x1136c, gbExtend}, {0x11370, 0x11374, gbExtend}, {0x113b8, 0x113b8, gbExtend},
+	{0x113b9, 0x113ba, gbSpacingMark}, {0x113bb, 0x113c0, gbExtend}, {0x113c2, 0x113c2, gbExtend}, {0x113c5, 0x113c5, gbExtend},
+	{0x113c7, 0x113c9, gbExtend}, {0x113ca, 0x113ca, gbSpacingMark}, {0x113cc, 0x113cd, gbSpacingMark}, {0x113ce, 0x113d0, gbExtend},
+	{0x113d1, 0x113d1, gbPrepend}, {0x113d2, 0x113d2, gbExtend}, {0x113e1, 0x113e2, gbExtend}, {0x11435, 0x11437, gbSpacingMark},
+	{0x11438, 0x1143f, gbExtend}, {0x11440, 0x11441, gbSpacingMark}, {0x11442, 0x11444, gbExtend}, {0x11445, 0x11445, gbSpacingMark},
+	{0x11446, 0x11446, gbExtend}, {0x1145e, 0x1145e, gbExtend}, {0x114b0, 0x114b0, gbExtend}, {0x114b1, 0x114b2, gbSpacingMark},
+	{0x114b3, 0x114b8, gbExtend}, {0x114b9, 0x114b9, gbSpacingMark}, {0x114ba, 0x114ba, gbExtend}, {0x114bb, 0x114bc, gbSpacingMark},
+	{0x114bd, 0x114bd, gbExtend}, {0x114be, 0x114be, gbSpacingMark}, {0x114bf, 0x114c0, gbExtend}, {0x114c1, 0x114c1, gbSpacingMark},
+	{0x114c2, 0x114c3, gbExtend}, {0x115af, 0x115af, gbExtend}, {0x115b0, 0x115b1, gbSpacingMark}, {0x115b2, 0x115b5, gbExtend},
+	{0x115b8, 0x115bb, gbSpacingMark}, {0x115bc, 0x115bd, gbExtend}, {0x115be, 0x115be, gbSpacingMark}, {0x115bf, 0x115c0, gbExtend},
+	{0x115dc, 0x115dd, gbExtend}, {0x11630, 0x11632, gbSpacingMark}, {0x11633, 0x1163a, gbExtend}, {0x1163b, 0x1163c, gbSpacingMark},
+	{0x1163d, 0x1163d, gbExtend}, {0x1163e, 0x1163e, gbSpacingMark}, {0x1163f, 0x11640, gbExtend}, {0x116ab, 0x116ab, gbExtend},
+	{0x116ac, 0x116ac, gbSpacingMark}, {0x116ad, 0x116ad, gbExtend}, {0x116ae, 0x116af, gbSpacingMark}, {0x116b0, 0x116b7, gbExtend},
+	{0x1171d, 0x1171d, gbExtend}, {0x1171e, 0x1171e, gbSpacingMark}, {0x1171f, 0x1171f, gbExtend}, {0x11722, 0x11725, gbExtend},
+	{0x11726, 0x11726, gbSpacingMark}, {0x11727, 0x1172b, gbExtend}, {0x1182c, 0x1182e, gbSpacingMark}, {0x1182f, 0x11837, gbExtend},
+	{0x11838, 0x11838, gbSpacingMark}, {0x11839, 0x1183a, gbExtend}, {0x11930, 0x11930, gbExtend}, {0x11931, 0x11935, gbSpacingMark},
+	{0x11937, 0x11938, gbSpacingMark}, {0x1193b, 0x1193e, gbExtend}, {0x1193f, 0x1193f, gbPrepend}, {0x11940, 0x11940, gbSpacingMark},
+	{0x11941, 0x11941, gbPrepend}, {0x11942, 0x11942, gbSpacingMark}, {0x11943, 0x11943, gbExtend}, {0x119d1, 0x119d3, gbSpacingMark},
+	{0x119d4, 0x119d7, gbExtend}, {0x119da, 0x119db, gbExtend}, {0x119dc, 0x119df, gbSpacingMark}, {0x119e0, 0x119e0, gbExtend},
+	{0x119e4, 0x119e4, gbSpacingMark}, {0x11a01, 0x11a0a, gbExtend}, {0x11a33, 0x11a38, gbExtend}, {0x11a39, 0x11a39, gbSpacingMark},
+	{0x11a3b, 0x11a3e, gbExtend}, {0x11a47, 0x11a47, gbExtend}, {0x11a51, 0x11a56, gbExtend}, {0x11a57, 0x11a58, gbSpacingMark},
+	{0x11a59, 0x11a5b, gbExtend}, {0x11a84, 0x11a89, gbPrepend}, {0x11a8a, 0x11a96, gbExtend}, {0x11a97, 0x11a97, gbSpacingMark},
+	{0x11a98, 0x11a99, gbExtend}, {0x11b60, 0x11b60, gbExtend}, {0x11b61, 0x11b61, gbSpacingMark}, {0x11b62, 0x11b64, gbExtend},
+	{0x11b65, 0x11b65, gbSpacingMark}, {0x11b66, 0x11b66, gbExtend}, {0x11b67, 0x11b67, gbSpacingMark}, {0x11c2f, 0x11c2f, gbSpacingMark},
+	{0x11c30, 0x11c36, gbExtend}, {0x11c38, 0x11c3d, gbExtend}, {0x11c3e, 0x11c3e, gbSpacingMark}, {0x11c3f, 0x11c3f, gbExtend},
+	{0x11c92, 0x11ca7, gbExtend}, {0x11ca9, 0x11ca9, gbSpacingMark}, {0x11caa, 0x11cb0, gbExtend}, {0x11cb1, 0x11cb1, gbSpacingMark},
+	{0x11cb2, 0x11cb3, gbExtend}, {0x11cb4, 0x11cb4, gbSpacingMark}, {0x11cb5, 0x11cb6, gbExtend}, {0x11d31, 0x11d36, gbExtend},
+	{0x11d3a, 0x11d3a, gbExtend}, {0x11d3c, 0x11d3d, gbExtend}, {0x11d3f, 0x11d45, gbExtend}, {0x11d46, 0x11d46, gbPrepend},
+	{0x11d47, 0x11d47, gbExtend}, {0x11d8a, 0x11d8e, gbSpacingMark}, {0x11d90, 0x11d91, gbExtend}, {0x11d93, 0x11d94, gbSpacingMark},
+	{0x11d95, 0x11d95, gbExtend}, {0x11d96, 0x11d96, gbSpacingMark}, {0x11d97, 0x11d97, gbExtend}, {0x11ef3, 0x11ef4, gbExtend},
+	{0x11ef5, 0x11ef6, gbSpacingMark}, {0x11f00, 0x11f01, gbExtend}, {0x11f02, 0x11f02, gbPrepend}, {0x11f03, 0x11f03, gbSpacingMark},
+	{0x11f34, 0x11f35, gbSpacingMark}, {0x11f36, 0x11f3a, gbExtend}, {0x11f3e, 0x11f3f, gbSpacingMark}, {0x11f40, 0x11f42, gbExtend},
+	{0x11f5a, 0x11f5a, gbExtend}, {0x13430, 0x1343f, gbControl}, {0x13440, 0x13440, gbExtend}, {0x13447, 0x13455, gbExtend},
+	{0x1611e, 0x16129, gbExtend}, {0x1612a, 0x1612c, gbSpacingMark}, {0x1612d, 0x1612f, gbExtend}, {0x16af0, 0x16af4, gbExtend},
+	{0x16b30, 0x16b36, gbExtend}, {0x16d63, 0x16d63, gbV}, {0x16d67, 0x16d6a, gbV}, {0x16f4f, 0x16f4f, gbExtend},
+	{0x16f51, 0x16f87, gbSpacingMark}, {0x16f8f, 0x16f92, gbExtend}, {0x16fe4, 0x16fe4, gbExtend}, {0x16ff0, 0x16ff1, gbExtend},
+	{0x1bc9d, 0x1bc9e, gbExtend}, {0x1bca0, 0x1bca3, gbControl}, {0x1cf00, 0x1cf2d, gbExtend}, {0x1cf30, 0x1cf46, gbExtend},
+	{0x1d165, 0x1d169, gbExtend}, {0x1d16d, 0x1d172, gbExtend}, {0x1d173, 0x1d17a, gbControl}, {0x1d17b, 0x1d182, gbExtend},
+	{0x1d185, 0x1d18b, gbExtend}, {0x1d1aa, 0x1d1ad, gbExtend}, {0x1d242, 0x1d244, gbExtend}, {0x1da00, 0x1da36, gbExtend},
+	{0x1da3b, 0x1da6c, gbExtend}, {0x1da75, 0x1da75, gbExtend}, {0x1da84, 0x1da84, gbExtend}, {0x1da9b, 0x1da9f, gbExtend},
+	{0x1daa1, 0x1daaf, gbExtend}, {0x1e000, 0x1e006, gbExtend}, {0x1e008, 0x1e018, gbExtend}, {0x1e01b, 0x1e021, gbExtend},
+	{0x1e023, 0x1e024, gbExtend}, {0x1e026, 0x1e02a, gbExtend}, {0x1e08f, 0x1e08f, gbExtend}, {0x1e130, 0x1e136, gbExtend},
+	{0x1e2ae, 0x1e2ae, gbExtend}, {0x1e2ec, 0x1e2ef, gbExtend}, {0x1e4ec, 0x1e4ef, gbExtend}, {0x1e5ee, 0x1e5ef, gbExtend},
+	{0x1e6e3, 0x1e6e3, gbExtend}, {0x1e6e6, 0x1e6e6, gbExtend}, {0x1e6ee, 0x1e6ef, gbExtend}, {0x1e6f5, 0x1e6f5, gbExtend},
+	{0x1e8d0, 0x1e8d6, gbExtend}, {0x1e944, 0x1e94a, gbExtend}, {0x1f004, 0x1f004, gbExtPict}, {0x1f02c, 0x1f02f, gbExtPict},
+	{0x1f094, 0x1f09f, gbExtPict}, {0x1f0af, 0x1f0b0, gbExtPict}, {0x1f0c0, 0x1f0c0, gbExtPict}, {0x1f0cf, 0x1f0d0, gbExtPict},
+	{0x1f0f6, 0x1f0ff, gbExtPict}, {0x1f170, 0x1f171, gbExtPict}, {0x1f17e, 0x1f17f, gbExtPict}, {0x1f18e, 0x1f18e, gbExtPict},
+	{0x1f191, 0x1f19a, gbExtPict}, {0x1f1ae, 0x1f1e5, gbExtPict}, {0x1f1e6, 0x1f1ff, gbRI}, {0x1f201, 0x1f20f, gbExtPict},
+	{0x1f21a, 0x1f21a, gbExtPict}, {0x1f22f, 0x1f22f, gbExtPict}, {0x1f232, 0x1f23a, gbExtPict}, {0x1f23c, 0x1f23f, gbExtPict},
+	{0x1f249, 0x1f25f, gbExtPict}, {0x1f266, 0x1f321, gbExtPict}, {0x1f324, 0x1f393, gbExtPict}, {0x1f396, 0x1f397, gbExtPict},
+	{0x1f399, 0x1f39b, gbExtPict}, {0x1f39e, 0x1f3f0, gbExtPict}, {0x1f3f3, 0x1f3f5, gbExtPict}, {0x1f3f7, 0x1f3fa, gbExtPict},
+	{0x1f3fb, 0x1f3ff, gbExtend}, {0x1f400, 0x1f4fd, gbExtPict}, {0x1f4ff, 0x1f53d, gbExtPict}, {0x1f549, 0x1f54e, gbExtPict},
+	{0x1f550, 0x1f567, gbExtPict}, {0x1f56f, 0x1f570, gbExtPict}, {0x1f573, 0x1f57a, gbExtPict}, {0x1f587, 0x1f587, gbExtPict},
+	{0x1f58a, 0x1f58d, gbExtPict}, {0x1f590, 0x1f590, gbExtPict}, {0x1f595, 0x1f596, gbExtPict}, {0x1f5a4, 0x1f5a5, gbExtPict},
+	{0x1f5a8, 0x1f5a8, gbExtPict}, {0x1f5b1, 0x1f5b2, gbExtPict}, {0x1f5bc, 0x1f5bc, gbExtPict}, {0x1f5c2, 0x1f5c4, gbExtPict},
+	{0x1f5d1, 0x1f5d3, gbExtPict}, {0x1f5dc, 0x1f5de, gbExtPict}, {0x1f5e1, 0x1f5e1, gbExtPict}, {0x1f5e3, 0x1f5e3, gbExtPict},
+	{0x1f5e8, 0x1f5e8, gbExtPict}, {0x1f5ef, 0x1f5ef, gbExtPict}, {0x1f5f3, 0x1f5f3, gbExtPict}, {0x1f5fa, 0x1f64f, gbExtPict},
+	{0x1f680, 0x1f6c5, gbExtPict}, {0x1f6cb, 0x1f6d2, gbExtPict}, {0x1f6d5, 0x1f6e5, gbExtPict}, {0x1f6e9, 0x1f6e9, gbExtPict},
+	{0x1f6eb, 0x1f6f0, gbExtPict}, {0x1f6f3, 0x1f6ff, gbExtPict}, {0x1f7da, 0x1f7ff, gbExtPict}, {0x1f80c, 0x1f80f, gbExtPict},
+	{0x1f848, 0x1f84f, gbExtPict}, {0x1f85a, 0x1f85f, gbExtPict}, {0x1f888, 0x1f88f, gbExtPict}, {0x1f8ae, 0x1f8af, gbExtPict},
+	{0x1f8bc, 0x1f8bf, gbExtPict}, {0x1f8c2, 0x1f8cf, gbExtPict}, {0x1f8d9, 0x1f8ff, gbExtPict}, {0x1f90c, 0x1f93a, gbExtPict},
+	{0x1f93c, 0x1f945, gbExtPict}, {0x1f947, 0x1f9ff, gbExtPict}, {0x1fa58, 0x1fa5f, gbExtPict}, {0x1fa6e, 0x1faff, gbExtPict},
+	{0x1fc00, 0x1fffd, gbExtPict}, {0xe0000, 0xe001f, gbControl}, {0xe0020, 0xe007f, gbExtend}, {0xe0080, 0xe00ff, gbControl},
+	{0xe0100, 0xe01ef, gbExtend}, {0xe01f0, 0xe0fff, gbControl},
+}
+
+// conjunctProps are Indic_Conjunct_Break of runes other than None
+var conjunctProps = []propRange[incbProp]{
+	{0x300, 0x36f, incbExtend}, {0x483, 0x489, incbExtend}, {0x591, 0x5bd, incbExtend}, {0x5bf, 0x5bf, incbExtend},
+	{0x5c1, 0x5c2, incbExtend}, {0x5c4, 0x5c5, incbExtend}, {0x5c7, 0x5c7, incbExtend}, {0x610, 0x61a, incbExtend},
+	{0x64b, 0x65f, incbExtend}, {0x670, 0x670, incbExtend}, {0x6d6, 0x6dc, incbExtend}, {0x6df, 0x6e4, incbExtend},
+	{0x6e7, 0x6e8, incbExtend}, {0x6ea, 0x6ed, incbExtend}, {0x711, 0x711, incbExtend}, {0x730, 0x74a, incbExtend},
+	{0x7a6, 0x7b0, incbExtend}, {0x7eb, 0x7f3, incbExtend}, {0x7fd, 0x7fd, incbExtend}, {0x816, 0x819, incbExtend},
+	{0x81b, 0x823, incbExtend}, {0x825, 0x827, incbExtend}, {0x829, 0x82d, incbExtend}, {0x859, 0x85b, incbExtend},
+	{0x897, 0x89f, incbExtend}, {0x8ca, 0x8e1, incbExtend}, {0x8e3, 0x902, incbExtend}, {0x915, 0x939, incbConsonant},
+	{0x93a, 0x93a, incbExtend}, {0x93c, 0x93c, incbExtend}, {0x941, 0x948, incbExtend}, {0x94d, 0x94d, incbLinker},
+	{0x951, 0x957, incbExtend}, {0x958, 0x95f, incbConsonant}, {0x962, 0x963, incbExtend}, {0x978, 0x97f, incbConsonant},
+	{0x981, 0x981, incbExtend}, {0x995, 0x9a8, incbConsonant}, {0x9aa, 0x9b0, incbConsonant}, {0x9b2, 0x9b2, incbConsonant},
+	{0x9b6, 0x9b9, incbConsonant}, {0x9bc, 0x9bc, incbExtend}, {0x9be, 0x9be, incbExtend}, {0x9c1, 0x9c4, incbExtend},
+	{0x9cd, 0x9cd, incbLinker}, {0x9d7, 0x9d7, incbExtend}, {0x9dc, 0x9dd, incbConsonant}, {0x9df, 0x9df, incbConsonant},
+	{0x9e2, 0x9e3, incbExtend}, {0x9f0, 0x9f1, incbConsonant}, {0x9fe, 0x9fe, incbExtend}, {0xa01, 0xa02, incbExtend},
+	{0xa3c, 0xa3c, incbExtend}, {0xa41, 0xa42, incbExtend}, {0xa47, 0xa48, incbExtend}, {0xa4b, 0xa4d, incbExtend},
+	{0xa51, 0xa51, incbExtend}, {0xa70, 0xa71, incbExtend}, {0xa75, 0xa75, incbExtend}, {0xa81, 0xa82, incbExtend},
+	{0xa95, 0xaa8, incbConsonant}, {0xaaa, 0xab0, incbConsonant}, {0xab2, 0xab3, incbConsonant}, {0xab5, 0xab9, incbConsonant},
+	{0xabc, 0xabc, incbExtend}, {0xac1, 0xac5, incbExtend}, {0xac7, 0xac8, incbExtend}, {0xacd, 0xacd, incbLinker},
+	{0xae2, 0xae3, incbExtend}, {0xaf9, 0xaf9, incbConsonant}, {0xafa, 0xaff, incbExtend}, {0xb01, 0xb01, incbExtend},
+	{0xb15, 0xb28, incbConsonant}, {0xb2a, 0xb30, incbConsonant}, {0xb32, 0xb33, incbConsonant}, {0xb35, 0xb39, incbConsonant},
+	{0xb3c, 0xb3c, incbExtend}, {0xb3e, 0xb3f, incbExtend}, {0xb41, 0xb44, incbExtend}, {0xb4d, 0xb4d, incbLinker},
+	{0xb55, 0xb57, incbExtend}, {0xb5c, 0xb5d, incbConsonant}, {0xb5f, 0xb5f, incbConsonant}, {0xb62, 0xb63, incbExtend},
+	{0xb71, 0xb71, incbConsonant}, {0xb82, 0xb82, incbExtend}, {0xbbe, 0xbbe, incbExtend}, {0xbc0, 0xbc0, incbExtend},
+	{0xbcd, 0xbcd, incbExtend}, {0xbd7, 0xbd7, incbExtend}, {0xc00, 0xc00, incbExtend}, {0xc04, 0xc04, incbExtend},
+	{0xc15, 0xc28, incbConsonant}, {0xc2a, 0xc39, incbConsonant}, {0xc3c, 0xc3c, incbExtend}, {0xc3e, 0xc40, incbExtend},
+	{0xc46, 0xc48, incbExtend}, {0xc4a, 0xc4c, incbExtend}, {0xc4d, 0xc4d, incbLinker}, {0xc55, 0xc56, incbExtend},
+	{0xc58, 0xc5a, incbConsonant}, {0xc62, 0xc63, incbExtend}, {0xc81, 0xc81, incbExtend}, {0xcbc, 0xcbc, incbExtend},
+	{0xcbf, 0xcc0, incbExtend}, {0xcc2, 0xcc2, incbExtend}, {0xcc6, 0xcc8, incbExtend}, {0xcca, 0xccd, incbExtend},
+	{0xcd5, 0xcd6, incbExtend}, {0xce2, 0xce3, incbExtend}, {0xd00, 0xd01, incbExtend}, {0xd15, 0xd3a, incbConsonant},
+	{0xd3b, 0xd3c, incbExtend}, {0xd3e, 0xd3e, incbExtend}, {0xd41, 0xd44, incbExtend}, {0xd4d, 0xd4d, incbLinker},
+	{0xd57, 0xd57, incbExtend}, {0xd62, 0xd63, incbExtend}, {0xd81, 0xd81, incbExtend}, {0xdca, 0xdca, incbExtend},
+	{0xdcf, 0xdcf, incbExtend}, {0xdd2, 0xdd4, incbExtend}, {0xdd6, 0xdd6, incbExtend}, {0xddf, 0xddf, incbExtend},
+	{0xe31, 0xe31, incbExtend}, {0xe34, 0xe3a, incbExtend}, {0xe47, 0xe4e, incbExtend}, {0xeb1, 0xeb1, incbExtend},
+	{0xeb4, 0xebc, incbExtend}, {0xec8, 0xece, incbExtend}, {0xf18, 0xf19, incbExtend}, {0xf35, 0xf35, incbExtend},
+	{0xf37, 0xf37, incbExtend}, {0xf39, 0xf39, incbExtend}, {0xf71, 0xf7e, incbExtend}, {0xf80, 0xf84, incbExtend},
+	{0xf86, 0xf87, incbExtend}, {0xf8d, 0xf97, incbExtend}, {0xf99, 0xfbc, incbExtend}, {0xfc6, 0xfc6, incbExtend},
+	{0x1000, 0x102a, incbConsonant}, {0x102d, 0x1030, incbExtend}, {0x1032, 0x1037, incbExtend}, {0x1039, 0x1039, incbLinker},
+	{0x103a, 0x103a, incbExtend}, {0x103d, 0x103e, incbExtend}, {0x103f, 0x103f, incbConsonant}, {0x1050, 0x1055, incbConsonant},
+	{0x1058, 0x1059, incbExtend}, {0x105a, 0x105d, incbConsonant}, {0x105e, 0x1060, incbExtend}, {0x1061, 0x1061, incbConsonant},
+	{0x1065, 0x1066, incbConsonant}, {0x106e, 0x1070, incbConsonant}, {0x1071, 0x1074, incbExtend}, {0x1075, 0x1081, incbConsonant},
+	{0x1082, 0x1082, incbExtend}, {0x1085, 0x1086, incbExtend}, {0x108d, 0x108d, incbExtend}, {0x108e, 0x108e, incbConsonant},
+	{0x109d, 0x109d, incbExtend}, {0x135d, 0x135f, incbExtend}, {0x1712, 0x1715, incbExtend}, {0x1732, 0x1734, incbExtend},
+	{0x1752, 0x1753, incbExtend}, {0x1772, 0x1773, incbExtend}, {0x1780, 0x17b3, incbConsonant}, {0x17b4, 0x17b5, incbExtend},
+	{0x17b7, 0x17bd, incbExtend}, {0x17c6, 0x17c6, incbExtend}, {0x17c9, 0x17d1, incbExtend}, {0x17d2, 0x17d2, incbLinker},
+	{0x17d3, 0x17d3, incbExtend}, {0x17dd, 0x17dd, incbExtend}, {0x180b, 0x180d, incbExtend}, {0x180f, 0x180f, incbExtend},
+	{0x1885, 0x1886, incbExtend}, {0x18a9, 0x18a9, incbExtend}, {0x1920, 0x1922, incbExtend}, {0x1927, 0x1928, incbExtend},
+	{0x1932, 0x1932, incbExtend}, {0x1939, 0x193b, incbExtend}, {0x1a17, 0x1a18, incbExtend}, {0x1a1b, 0x1a1b, incbExtend},
+	{0x1a20, 0x1a54, incbConsonant}, {0x1a56, 0x1a56, incbExtend}, {0x1a58, 0x1a5e, incbExtend}, {0x1a60, 0x1a60, incbLinker},
+	{0x1a62, 0x1a62, incbExtend}, {0x1a65, 0x1a6c, incbExtend}, {0x1a73, 0x1a7c, incbExtend}, {0x1a7f, 0x1a7f, incbExtend},
+	{0x1ab0, 0x1add, incbExtend}, {0x1ae0, 0x1aeb, incbExtend}, {0x1b00, 0x1b03, incbExtend}, {0x1b0b, 0x1b0c, incbConsonant},
+	{0x1b13, 0x1b33, incbConsonant}, {0x1b34, 0x1b3d, incbExtend}, {0x1b42, 0x1b43, incbExtend}, {0x1b44, 0x1b44, incbLinker},
+	{0x1b45, 0x1b4c, incbConsonant}, {0x1b6b, 0x1b73, incbExtend}, {0x1b80, 0x1b81, incbExtend}, {0x1b83, 0x1ba0, incbConsonant},
+	{0x1ba2, 0x1ba5, incbExtend}, {0x1ba8, 0x1baa, incbExtend}, {0x1bab, 0x1bab, incbLinker}, {0x1bac, 0x1bad, incbExtend},
+	{0x1bae, 0x1baf, incbConsonant}, {0x1bbb, 0x1bbd, incbConsonant}, {0x1be6, 0x1be6, incbExtend}, {0x1be8, 0x1be9, incbExtend},
+	{0x1bed, 0x1bed, incbExtend}, {0x1bef, 0x1bf3, incbExtend}, {0x1c2c, 0x1c33, incbExtend}, {0x1c36, 0x1c37, incbExtend},
+	{0x1cd0, 0x1cd2, incbExtend}, {0x1cd4, 0x1ce0, incbExtend}, {0x1ce2, 0x1ce8, incbExtend}, {0x1ced, 0x1ced, incbExtend},
+	{0x1cf4, 0x1cf4, incbExtend}, {0x1cf8, 0x1cf9, incbExtend}, {0x1dc0, 0x1dff, incbExtend}, {0x200d, 0x200d, incbExtend},
+	{0x20d0, 0x20f0, incbExtend}, {0x2cef, 0x2cf1, incbExtend}, {0x2d7f, 0x2d7f, incbExtend}, {0x2de0, 0x2dff, incbExtend},
+	{0x302a, 0x302f, incbExtend}, {0x3099, 0x309a, incbExtend}, {0xa66f, 0xa672, incbExtend}, {0xa674, 0xa67d, incbExtend},
+	{0xa69e, 0xa69f, incbExtend}, {0xa6f0, 0xa6f1, incbExtend}, {0xa802, 0xa802, incbExtend}, {0xa806, 0xa806, incbExtend},
+	{0xa80b, 0xa80b, incbExtend}, {0xa825, 0xa826, incbExtend}, {0xa82c, 0xa82c, incbExtend}, {0xa8c4, 0xa8c5, incbExtend},
+	{0xa8e0, 0xa8f1, incbExtend}, {0xa8ff, 0xa8ff, incbExtend}, {0xa926, 0xa92d, incbExtend}, {0xa947, 0xa951, incbExtend},
+	{0xa953, 0xa953, incbExtend}, {0xa980, 0xa982, incbExtend}, {0xa989, 0xa98b, incbConsonant}, {0xa98f, 0xa9b2, incbConsonant},
+	{0xa9b3, 0xa9b3, incbExtend}, {0xa9b6, 0xa9b9, incbExtend}, {0xa9bc, 0xa9bd, incbExtend}, {0xa9c0, 0xa9c0, incbLinker},
+	{0xa9e0, 0xa9e4, incbConsonant}, {0xa9e5, 0xa9e5, incbExtend}, {0xa9e7, 0xa9ef, incbConsonant}, {0xa9fa, 0xa9fe, incbConsonant},
+	{0xaa29, 0xaa2e, incbExtend}, {0xaa31, 0xaa32, incbExtend}, {0xaa35, 0xaa36, incbExtend}, {0xaa43, 0xaa43, incbExtend},
+	{0xaa4c, 0xaa4c, incbExtend}, {0xaa60, 0xaa6f, incbConsonant}, {0xaa71, 0xaa73, incbConsonant}, {0xaa7a, 0xaa7a, incbConsonant},
+	{0xaa7c, 0xaa7c, incbExtend}, {0xaa7e, 0xaa7f, incbConsonant}, {0xaab0, 0xaab0, incbExtend}, {0xaab2, 0xaab4, incbExtend},
+	{0xaab7, 0xaab8, incbExtend}, {0xaabe, 0xaabf, incbExtend}, {0xaac1, 0xaac1, incbExtend}, {0xaae0, 0xaaea, incbConsonant},
+	{0xaaec, 0xaaed, incbExtend}, {0xaaf6, 0xaaf6, incbLinker}, {0xabc0, 0xabda, incbConsonant}, {0xabe5, 0xabe5, incbExtend},
+	{0xabe8, 0xabe8, incbExtend}, {0xabed, 0xabed, incbExtend}, {0xfb1e, 0xfb1e, incbExtend}, {0xfe00, 0xfe0f, incbExtend},
+	{0xfe20, 0xfe2f, incbExtend}, {0xff9e, 0xff9f, incbExtend}, {0x101fd, 0x101fd, incbExtend}, {0x102e0, 0x102e0, incbExtend},
+	{0x10376, 0x1037a, incbExtend}, {0x10a00, 0x10a00, incbConsonant}, {0x10a01, 0x10a03, incbExtend}, {0x10a05, 0x10a06, incbExtend},
+	{0x10a0c, 0x10a0f, incbExtend}, {0x10a10, 0x10a13, incbConsonant}, {0x10a15, 0x10a17, incbConsonant}, {0x10a19, 0x10a35, incbConsonant},
+	{0x10a38, 0x10a3a, incbExtend}, {0x10a3f, 0x10a3f, incbLinker}, {0x10ae5, 0x10ae6, incbExtend}, {0x10d24, 0x10d27, incbExtend},
+	{0x10d69, 0x10d6d, incbExtend}, {0x10eab, 0x10eac, incbExtend}, {0x10efa, 0x10eff, incbExtend}, {0x10f46, 0x10f50, incbExtend},
+	{0x10f82, 0x10f85, incbExtend}, {0x11001, 0x11001, incbExtend}, {0x11038, 0x11046, incbExtend}, {0x11070, 0x11070, incbExtend},
+	{0x11073, 0x11074, incbExtend}, {0x1107f, 0x11081, incbExtend}, {0x110b3, 0x110b6, incbExtend}, {0x110b9, 0x110ba, incbExtend},
+	{0x110c2, 0x110c2, incbExtend}, {0x11100, 0x11102, incbExtend}, {0x11103, 0x11126, incbConsonant}, {0x11127, 0x1112b, incbExtend},
+	{0x1112d, 0x11132, incbExtend}, {0x11133, 0x11133, incbLinker}, {0x11134, 0x11134, incbExtend}, {0x11144, 0x11144, incbConsonant},
+	{0x11147, 0x11147, incbConsonant}, {0x11173, 0x11173, incbExtend}, {0x11180, 0x11181, incbExtend}, {0x111b6, 0x111be, incbExtend},
+	{0x111c0, 0x111c0, incbExtend}, {0x111c9, 0x111cc, incbExtend}, {0x111cf, 0x111cf, incbExtend}, {0x1122f, 0x11231, incbExtend},
+	{0x11234, 0x11237, incbExtend}, {0x1123e, 0x1123e, incbExtend}, {0x11241, 0x11241, incbExtend}, {0x112df, 0x112df, incbExtend},
+	{0x112e3, 0x112ea, incbExtend}, {0x11300, 0x11301, incbExtend}, {0x1133b, 0x1133c, incbExtend}, {0x1133e, 0x1133e, incbExtend},
+	{0x11340, 0x11340, incbExtend}, {0x1134d, 0x1134d, incbExtend}, {0x11357, 0x11357, incbExtend}, {0x11366, 0x1136c, incbExtend},
+	{0x11370, 0x11374, incbExtend}, {0x11380, 0x11389, incbConsonant}, {0x1138b, 0x1138b, incbConsonant}, {0x1138e, 0x1138e, incbConsonant},
+	{0x11390, 0x113b5, incbConsonant}, {0x113b8, 0x113b8, incbExtend}, {0x113bb, 0x113c0, incbExtend}, {0x113c2, 0x113c2, incbExtend},
+	{0x113c5, 0x113c5, incbExtend}, {0x113c7, 0x113c9, incbExtend}, {0x113ce, 0x113cf, incbExtend}, {0x113d0, 0x113d0, incbLinker},
+	{0x113d2, 0x113d2, incbExtend}, {0x113e1, 0x113e2, incbExtend}, {0x11438, 0x1143f, incbExtend}, {0x11442, 0x11444, incbExtend},
+	{0x11446, 0x11446, incbExtend}, {0x1145e, 0x1145e, incbExtend}, {0x114b0, 0x114b0, incbExtend}, {0x114b3, 0x114b8, incbExtend},
+	{0x114ba, 0x114ba, incbExtend}, {0x114bd, 0x114bd, incbExtend}, {0x114bf, 0x114c0, incbExtend}, {0x114c2, 0x114c3, incbExtend},
+	{0x115af, 0x115af, incbExtend}, {0x115b2, 0x115b5, incbExtend}, {0x115bc, 0x115bd, incbExtend}, {0x115bf, 0x115c0, incbExtend},
+	{0x115dc, 0x115dd, incbExtend}, {0x11633, 0x1163a, incbExtend}, {0x1163d, 0x1163d, incbExtend}, {0x1163f, 0x11640, incbExtend},
+	{0x116ab, 0x116ab, incbExtend}, {0x116ad, 0x116ad, incbExtend}, {0x116b0, 0x116b7, incbExtend}, {0x1171d, 0x1171d, incbExtend},
+	{0x1171f, 0x1171f, incbExtend}, {0x11722, 0x11725, incbExtend}, {0x11727, 0x1172b, incbExtend}, {0x1182f, 0x11837, incbExtend},
+	{0x11839, 0x1183a, incbExtend}, {0x11900, 0x11906, incbConsonant}, {0x11909, 0x11909, incbConsonant}, {0x1190c, 0x11913, incbConsonant},
+	{0x11915, 0x11916, incbConsonant}, {0x11918, 0x1192f, incbConsonant}, {0x11930, 0x11930, incbExtend}, {0x1193b, 0x1193d, incbExtend},
+	{0x1193e, 0x1193e, incbLinker}, {0x11943, 0x11943, incbExtend}, {0x119d4, 0x119d7, incbExtend}, {0x119da, 0x119db, incbExtend},
+	{0x119e0, 0x119e0, incbExtend}, {0x11a00, 0x11a00, incbConsonant}, {0x11a01, 0x11a0a, incbExtend}, {0x11a0b, 0x11a32, incbConsonant},
+	{0x11a33, 0x11a38, incbExtend}, {0x11a3b, 0x11a3e, incbExtend}, {0x11a47, 0x11a47, incbLinker}, {0x11a50, 0x11a50, incbConsonant},
+	{0x11a51, 0x11a56, incbExtend}, {0x11a59, 0x11a5b, incbExtend}, {0x11a5c, 0x11a83, incbConsonant}, {0x11a8a, 0x11a96, incbExtend},
+	{0x11a98, 0x11a98, incbExtend}, {0x11a99, 0x11a99, incbLinker}, {0x11b60, 0x11b60, incbExtend}, {0x11b62, 0x11b64, incbExtend},
+	{0x11b66, 0x11b66, incbExtend}, {0x11c30, 0x11c36, incbExtend}, {0x11c38, 0x11c3d, incbExtend}, {0x11c3f, 0x11c3f, incbExtend},
+	{0x11c92, 0x11ca7, incbExtend}, {0x11caa, 0x11cb0, incbExtend}, {0x11cb2, 0x11cb3, incbExtend}, {0x11cb5, 0x11cb6, incbExtend},
+	{0x11d31, 0x11d36, incbExtend}, {0x11d3a, 0x11d3a, incbExtend}, {0x11d3c, 0x11d3d, incbExtend}, {0x11d3f, 0x11d45, incbExtend},
+	{0x11d47, 0x11d47, incbExtend}, {0x11d90, 0x11d91, incbExtend}, {0x11d95, 0x11d95, incbExtend}, {0x11d97, 0x11d97, incbExtend},
+	{0x11ef3, 0x11ef4, incbExtend}, {0x11f00, 0x11f01, incbExtend}, {0x11f04, 0x11f10, incbConsonant}, {0x11f12, 0x11f33, incbConsonant},
+	{0x11f36, 0x11f3a, incbExtend}, {0x11f40, 0x11f41, incbExtend}, {0x11f42, 0x11f42, incbLinker}, {0x11f5a, 0x11f5a, incbExtend},
+	{0x13440, 0x13440, incbExtend}, {0x13447, 0x13455, incbExtend}, {0x1611e, 0x16129, incbExtend}, {0x1612d, 0x1612f, incbExtend},
+	{0x16af0, 0x16af4, incbExtend}, {0x16b30, 0x16b36, incbExtend}, {0x16f4f, 0x16f4f, incbExtend}, {0x16f8f, 0x16f92, incbExtend},
+	{0x16fe4, 0x16fe4, incbExtend}, {0x16ff0, 0x16ff1, incbExtend}, {0x1bc9d, 0x1bc9e, incbExtend}, {0x1cf00, 0x1cf2d, incbExtend},
+	{0x1cf30, 0x1cf46, incbExtend}, {0x1d165, 0x1d169, incbExtend}, {0x1d16d, 0x1d172, incbExtend}, {0x1d17b, 0x1d182, incbExtend},
+	{0x1d185, 0x1d18b, incbExtend}, {0x1d1aa, 0x1d1ad, incbExtend}, {0x1d242, 0x1d244, incbExtend}, {0x1da00, 0x1da36, incbExtend},
+	{0x1da3b, 0x1da6c, incbExtend}, {0x1da75, 0x1da75, incbExtend}, {0x1da84, 0x1da84, incbExtend}, {0x1da9b, 0x1da9f, incbExtend},
+	{0x1daa1, 0x1daaf, incbExtend}, {0x1e000, 0x1e006, incbExtend}, {0x1e008, 0x1e018, incbExtend}, {0x1e01b, 0x1e021, incbExtend},
+	{0x1e023, 0x1e024, incbExtend}, {0x1e026, 0x1e02a, incbExtend}, {0x1e08f, 0x1e08f, incbExtend}, {0x1e130, 0x1e136, incbExtend},
+	{0x1e2ae, 0x1e2ae, incbExtend}, {0x1e2ec, 0x1e2ef, incbExtend}, {0x1e4ec, 0x1e4ef, incbExtend}, {0x1e5ee, 0x1e5ef, incbExtend},
+	{0x1e6e3, 0x1e6e3, incbExtend}, {0x1e6e6, 0x1e6e6, incbExtend}, {0x1e6ee, 0x1e6ef, incbExtend}, {0x1e6f5, 0x1e6f5, incbExtend},
+	{0x1e8d0, 0x1e8d6, incbExtend}, {0x1e944, 0x1e94a, incbExtend}, {0x1f3fb, 0x1f3ff, incbExtend}, {0xe0020, 0xe007f, incbExtend},
+	{0xe0100, 0xe01ef, incbExtend},
+}
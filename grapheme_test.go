@@ -0,0 +1,201 @@
+package rope
+
+import (
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	cases := []struct {
+		text     string
+		clusters []string
+	}{
+		{"", nil},
+		{"abc", []string{"a", "b", "c"}},
+		{"a\r\nb\n\r", []string{"a", "\r\n", "b", "\n", "\r"}},
+		// combining marks
+		{"éé̈x", []string{"é", "é̈", "x"}},
+		// spacing mark
+		{"कि", []string{"कि"}},
+		// flags
+		{"🇯🇵🇺🇸🇫", []string{"🇯🇵", "🇺🇸", "🇫"}},
+		// ZWJ sequences and modifiers
+		{"👨‍👩‍👧x👍🏽", []string{"👨‍👩‍👧", "x", "👍🏽"}},
+		{"a‍👩", []string{"a‍", "👩"}},
+		{"❤️🏴\U000e0067\U000e0062\U000e007f", []string{"❤️", "🏴\U000e0067\U000e0062\U000e007f"}},
+		// Hangul
+		{"한국각ᄀ", []string{"한", "국", "각", "ᄀ"}},
+		{"각각ᆨᆨ", []string{"각", "각ᆨᆨ"}},
+		// controls
+		{"á​́", []string{"á", "​", "́"}},
+		// prepend
+		{"؀a", []string{"؀a"}},
+		// Indic conjuncts and halfwidth voiced marks
+		{"क्षि", []string{"क्षि"}},
+		{"क्‍षa", []string{"क्‍ष", "a"}},
+		{"कक्", []string{"क", "क्"}},
+		{"กำ", []string{"กำ"}},
+		{"ｶﾞ", []string{"ｶﾞ"}},
+	}
+
+	for _, c := range cases {
+		r := NewFromBytes([]byte(c.text))
+		var clusters []string
+		var bounds []int
+		r.IterGraphemes(0, func(start, end int) bool {
+			clusters = append(clusters, string(r.Sub(start, end-start)))
+			bounds = append(bounds, start)
+			return true
+		})
+		if !slices.Equal(clusters, c.clusters) {
+			t.Fatalf("%q: got %q", c.text, clusters)
+		}
+		if r.GraphemeCount() != len(c.clusters) {
+			t.Fatal()
+		}
+		bounds = append(bounds, r.Len())
+		if len(c.clusters) == 0 {
+			bounds = []int{0}
+		}
+
+		// next and prev
+		for i := 0; i+1 < len(bounds); i++ {
+			if n := r.NextGrapheme(bounds[i]); n != bounds[i+1] {
+				t.Fatalf("%q: next of %d is %d", c.text, bounds[i], n)
+			}
+			if p := r.PrevGrapheme(bounds[i+1]); p != bounds[i] {
+				t.Fatalf("%q: prev of %d is %d", c.text, bounds[i+1], p)
+			}
+		}
+
+		// every offset
+		for offset := 0; offset <= r.Len(); offset++ {
+			i, found := slices.BinarySearch(bounds, offset)
+			if r.IsGraphemeBoundary(offset) != found {
+				t.Fatalf("%q: boundary at %d", c.text, offset)
+			}
+			floor, ceil := bounds[max(i-1, 0)], bounds[min(i, len(bounds)-1)]
+			if found {
+				floor = offset
+			}
+			if f, e := r.SnapGraphemes(offset, offset); f != floor || e != ceil {
+				t.Fatalf("%q: snap %d: %d %d", c.text, offset, f, e)
+			}
+			if offset < r.Len() && r.NextGrapheme(offset) != bounds[i+boolInt(found)] {
+				t.Fatalf("%q: next of %d", c.text, offset)
+			}
+			if offset > 0 && r.PrevGrapheme(offset) != bounds[i-1] {
+				t.Fatalf("%q: prev of %d", c.text, offset)
+			}
+		}
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestGraphemesAcrossLeaves(t *testing.T) {
+	cluster := "👨‍👩‍👧‍👦"
+	text := strings.Repeat("🇯🇵"+cluster+"é\r\n", 20)
+	r := NewFromBytes([]byte(text))
+	if r.Len() <= MaxLengthPerNode {
+		t.Fatal()
+	}
+	if n := r.GraphemeCount(); n != 80 {
+		t.Fatalf("got %d", n)
+	}
+	offset := 0
+	for i := 0; i < 80; i++ {
+		next := r.NextGrapheme(offset)
+		if r.PrevGrapheme(next) != offset {
+			t.Fatal()
+		}
+		offset = next
+	}
+	if offset != r.Len() {
+		t.Fatal()
+	}
+
+	// deleting snapped ranges keeps clusters
+	from, to := r.SnapGraphemes(10, 11)
+	if from != 8 || to != 8+len(cluster) {
+		t.Fatalf("got %d %d", from, to)
+	}
+	r2 := r.Delete(from, to-from)
+	if r2.GraphemeCount() != 79 {
+		t.Fatal()
+	}
+}
+
+type breakTest struct {
+	text   string
+	bounds []int
+	line   string
+}
+
+func (b breakTest) segments() (ret []string) {
+	for i := 0; i+1 < len(b.bounds); i++ {
+		ret = append(ret, b.text[b.bounds[i]:b.bounds[i+1]])
+	}
+	return
+}
+
+// readBreakTests reads tests in the format of GraphemeBreakTest.txt
+func readBreakTests(t *testing.T, name string) (ret []breakTest) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields, _, _ := strings.Cut(line, "#")
+		if strings.TrimSpace(fields) == "" {
+			continue
+		}
+		var test breakTest
+		test.line = line
+		for _, field := range strings.Fields(fields) {
+			switch field {
+			case "÷":
+				test.bounds = append(test.bounds, len(test.text))
+			case "×":
+			default:
+				ru, err := strconv.ParseUint(field, 16, 32)
+				if err != nil {
+					t.Fatal(err)
+				}
+				test.text += string(rune(ru))
+			}
+		}
+		ret = append(ret, test)
+	}
+	if len(ret) == 0 {
+		t.Fatalf("no tests in %s", name)
+	}
+	return
+}
+
+func TestGraphemeBreakVectors(t *testing.T) {
+	for _, test := range readBreakTests(t, "testdata/GraphemeBreakTest.txt") {
+		r := NewFromBytes([]byte(test.text))
+		bounds := []int{0}
+		r.IterGraphemes(0, func(_, end int) bool {
+			bounds = append(bounds, end)
+			return true
+		})
+		if !slices.Equal(bounds, test.bounds) {
+			t.Fatalf("%s: got %v", test.line, bounds)
+		}
+		for offset := 0; offset <= r.Len(); offset++ {
+			if _, found := slices.BinarySearch(test.bounds, offset); r.IsGraphemeBoundary(offset) != found {
+				t.Fatalf("%s: boundary at %d", test.line, offset)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Package avl joins and splits persistent height balanced binary trees.
+// It holds the balancing shared by the rope, the seq package and the companion trees of attributes, intervals and wrapped lines,
+// which differ in what nodes hold and how positions are located
+package avl
+
+// MaxLeafLength is the default maximum length of leaves, shared by the byte rope and package seq
+const MaxLeafLength = 128
+
+// Tree is the operations of a tree type. N is a pointer type, whose nil value is the empty tree
+type Tree[N comparable] interface {
+	// Height returns the height of a non nil node, which is 1 for leaves
+	Height(n N) int
+	// Children returns children of a non nil internal node, loading or adjusting them as the tree requires
+	Children(n N) (left, right N)
+	// Node returns the internal node of left and right, which are non nil and balanced
+	Node(left, right N) N
+	// Cut returns the trees before and after pos if n splits without descending, like at leaves or at the ends
+	Cut(n N, pos int) (out1, out2 N, ok bool)
+	// Descend returns whether pos is in the right child of the internal node n, and the position in that child
+	Descend(n, left, right N, pos int) (inRight bool, childPos int)
+}
+
+// Concat joins trees of different heights at the spine of the higher one, rotating on the way up, in O(|h1 - h2|) time
+func Concat[N comparable](t Tree[N], a, b N) N {
+	var zero N
+	if a == zero {
+		return b
+	}
+	if b == zero {
+		return a
+	}
+	if t.Height(a) > t.Height(b)+1 { // join at the right spine of a
+		left, right := t.Children(a)
+		return Balance(t, left, Concat(t, right, b))
+	}
+	if t.Height(b) > t.Height(a)+1 { // join at the left spine of b
+		left, right := t.Children(b)
+		return Balance(t, Concat(t, a, left), right)
+	}
+	return t.Node(a, b)
+}
+
+// Balance returns a node of left and right, whose heights differ by at most two, rotating if necessary
+func Balance[N comparable](t Tree[N], left, right N) N {
+	if t.Height(left) > t.Height(right)+1 {
+		ll, lr := t.Children(left)
+		if t.Height(ll) >= t.Height(lr) { // single rotation
+			return t.Node(ll, t.Node(lr, right))
+		}
+		// double rotation
+		lrl, lrr := t.Children(lr)
+		return t.Node(t.Node(ll, lrl), t.Node(lrr, right))
+	}
+	if t.Height(right) > t.Height(left)+1 {
+		rl, rr := t.Children(right)
+		if t.Height(rr) >= t.Height(rl) { // single rotation
+			return t.Node(t.Node(left, rl), rr)
+		}
+		// double rotation
+		rll, rlr := t.Children(rl)
+		return t.Node(t.Node(left, rll), t.Node(rlr, rr))
+	}
+	return t.Node(left, right)
+}
+
+// Split returns the trees before and after pos, as a sequence of joins in O(log n) time
+func Split[N comparable](t Tree[N], n N, pos int) (out1, out2 N) {
+	var zero N
+	if n == zero {
+		return
+	}
+	if out1, out2, ok := t.Cut(n, pos); ok {
+		return out1, out2
+	}
+	left, right := t.Children(n)
+	inRight, childPos := t.Descend(n, left, right, pos)
+	if inRight {
+		r1, r2 := Split(t, right, childPos)
+		return Concat(t, left, r1), r2
+	}
+	l1, l2 := Split(t, left, childPos)
+	return l1, Concat(t, l2, right)
+}
@@ -0,0 +1,365 @@
+package rope
+
+import "github.com/reusee/rope/internal/avl"
+
+// Stickiness decides whether an interval grows when bytes are inserted at its edges
+type Stickiness int
+
+const (
+	// NeverGrows keeps inserts at both edges outside
+	NeverGrows Stickiness = iota
+	// AlwaysGrows takes inserts at both edges inside
+	AlwaysGrows
+	// GrowsBefore takes inserts at the start inside
+	GrowsBefore
+	// GrowsAfter takes inserts at the end inside
+	GrowsAfter
+)
+
+func (s Stickiness) growsBefore() bool {
+	return s == AlwaysGrows || s == GrowsBefore
+}
+
+func (s Stickiness) growsAfter() bool {
+	return s == AlwaysGrows || s == GrowsAfter
+}
+
+// Interval is the half open range [Start, End) of a decoration
+type Interval struct {
+	// ID is assigned by Intervals.Add
+	ID         int
+	Start      int
+	End        int
+	Stickiness Stickiness
+	Value      any
+}
+
+func (iv Interval) overlaps(from, to int) bool {
+	if iv.Start == iv.End { // empty intervals overlap if inside
+		return from <= iv.Start && iv.Start < to
+	}
+	return iv.Start < to && from < iv.End
+}
+
+// Intervals is a persistent set of intervals over a rope, ordered by starts.
+// Edits return new versions with intervals moved, so versions can be kept in step with rope versions.
+// Intervals are in an AVL tree with lazily applied shifts, augmented with start and end bounds.
+// The zero value is empty
+type Intervals struct {
+	root   *ivNode
+	nextID int
+}
+
+type ivNode struct {
+	left   *ivNode
+	right  *ivNode
+	iv     Interval // of leaf
+	delta  int      // added to positions in children
+	height int
+	count  int
+	// with delta applied
+	minStart int
+	maxStart int
+	maxEnd   int
+}
+
+func newIvLeaf(iv Interval) *ivNode {
+	return &ivNode{
+		iv:       iv,
+		height:   1,
+		count:    1,
+		minStart: iv.Start,
+		maxStart: iv.Start,
+		maxEnd:   iv.End,
+	}
+}
+
+func newIvNode(left, right *ivNode) *ivNode {
+	return &ivNode{
+		left:     left,
+		right:    right,
+		height:   max(left.height, right.height) + 1,
+		count:    left.count + right.count,
+		minStart: left.minStart,
+		maxStart: right.maxStart,
+		maxEnd:   max(left.maxEnd, right.maxEnd),
+	}
+}
+
+// shift returns n with positions moved by d
+func (n *ivNode) shift(d int) *ivNode {
+	if n == nil || d == 0 {
+		return n
+	}
+	c := *n
+	if c.left == nil { // leaf
+		c.iv.Start += d
+		c.iv.End += d
+	} else {
+		c.delta += d
+	}
+	c.minStart += d
+	c.maxStart += d
+	c.maxEnd += d
+	return &c
+}
+
+// children returns children with the delta applied
+func (n *ivNode) children() (*ivNode, *ivNode) {
+	return n.left.shift(n.delta), n.right.shift(n.delta)
+}
+
+func (n *ivNode) concat(n2 *ivNode) *ivNode {
+	return avl.Concat(ivTree{}, n, n2)
+}
+
+// split returns intervals starting before p, and the others
+func (n *ivNode) split(p int) (out1, out2 *ivNode) {
+	return avl.Split(ivTree{}, n, p)
+}
+
+type ivTree struct{}
+
+func (ivTree) Height(n *ivNode) int {
+	return n.height
+}
+
+func (ivTree) Children(n *ivNode) (*ivNode, *ivNode) {
+	return n.children()
+}
+
+func (ivTree) Node(left, right *ivNode) *ivNode {
+	return newIvNode(left, right)
+}
+
+func (ivTree) Cut(n *ivNode, p int) (*ivNode, *ivNode, bool) {
+	if n.maxStart < p {
+		return n, nil, true
+	}
+	if n.minStart >= p {
+		return nil, n, true
+	}
+	return nil, nil, false
+}
+
+func (ivTree) Descend(_, _, right *ivNode, p int) (bool, int) {
+	return right.minStart < p, p
+}
+
+// iter calls fn with intervals in order, skipping subtrees not passing the filter.
+// off is the offset of positions of n
+func (n *ivNode) iter(off int, filter func(n *ivNode, off int) bool, fn func(Interval) bool) bool {
+	if n == nil || !filter(n, off) {
+		return true
+	}
+	if n.left == nil { // leaf
+		iv := n.iv
+		iv.Start += off
+		iv.End += off
+		return fn(iv)
+	}
+	if !n.left.iter(off+n.delta, filter, fn) {
+		return false
+	}
+	return n.right.iter(off+n.delta, filter, fn)
+}
+
+func (n *ivNode) collect() (ret []Interval) {
+	n.iter(0, func(*ivNode, int) bool {
+		return true
+	}, func(iv Interval) bool {
+		ret = append(ret, iv)
+		return true
+	})
+	return
+}
+
+// mapEnds returns n with fn applied to intervals ending at or after p
+func (n *ivNode) mapEnds(p int, fn func(Interval) Interval) *ivNode {
+	if n == nil || n.maxEnd < p {
+		return n
+	}
+	if n.left == nil { // leaf
+		return newIvLeaf(fn(n.iv))
+	}
+	left, right := n.children()
+	return newIvNode(left.mapEnds(p, fn), right.mapEnds(p, fn))
+}
+
+// buildIntervals returns the tree of ivs in order
+func buildIntervals(ivs []Interval) *ivNode {
+	switch len(ivs) {
+	case 0:
+		return nil
+	case 1:
+		return newIvLeaf(ivs[0])
+	}
+	half := len(ivs) / 2
+	return newIvNode(buildIntervals(ivs[:half]), buildIntervals(ivs[half:]))
+}
+
+func (s Intervals) Len() int {
+	if s.root == nil {
+		return 0
+	}
+	return s.root.count
+}
+
+// Add returns the new version with iv added, and iv with ID assigned. End is at least Start
+func (s Intervals) Add(iv Interval) (Intervals, Interval) {
+	iv.ID = s.nextID
+	iv.End = max(iv.End, iv.Start)
+	n1, n2 := s.root.split(iv.Start + 1)
+	return Intervals{
+		root:   n1.concat(newIvLeaf(iv)).concat(n2),
+		nextID: s.nextID + 1,
+	}, iv
+}
+
+// Remove returns the new version without iv, which is located by ID and Start
+func (s Intervals) Remove(iv Interval) Intervals {
+	n1, rest := s.root.split(iv.Start)
+	same, n2 := rest.split(iv.Start + 1)
+	var kept []Interval
+	for _, i := range same.collect() {
+		if i.ID != iv.ID {
+			kept = append(kept, i)
+		}
+	}
+	s.root = n1.concat(buildIntervals(kept)).concat(n2)
+	return s
+}
+
+// Filter returns the new version with intervals that fn returns true for
+func (s Intervals) Filter(fn func(Interval) bool) Intervals {
+	var kept []Interval
+	for _, iv := range s.root.collect() {
+		if fn(iv) {
+			kept = append(kept, iv)
+		}
+	}
+	s.root = buildIntervals(kept)
+	return s
+}
+
+// All returns all intervals ordered by starts
+func (s Intervals) All() []Interval {
+	return s.root.collect()
+}
+
+// Query returns intervals overlapping [from, to), ordered by starts.
+// Empty intervals overlap if they are in the range
+func (s Intervals) Query(from, to int) (ret []Interval) {
+	s.root.iter(0, func(n *ivNode, off int) bool {
+		return off+n.minStart < to && off+n.maxEnd >= from
+	}, func(iv Interval) bool {
+		if iv.overlaps(from, to) {
+			ret = append(ret, iv)
+		}
+		return true
+	})
+	return
+}
+
+// Stab returns intervals containing offset, ordered by starts
+func (s Intervals) Stab(offset int) (ret []Interval) {
+	s.root.iter(0, func(n *ivNode, off int) bool {
+		return off+n.minStart <= offset && off+n.maxEnd > offset
+	}, func(iv Interval) bool {
+		if iv.Start <= offset && offset < iv.End {
+			ret = append(ret, iv)
+		}
+		return true
+	})
+	return
+}
+
+// Insert returns the new version with intervals moved by inserting l bytes at p.
+// Intervals at p grow or not by their stickiness
+func (s Intervals) Insert(p, l int) Intervals {
+	if l <= 0 {
+		return s
+	}
+	before, rest := s.root.split(p)
+	at, after := rest.split(p + 1)
+	after = after.shift(l)
+	// starting before p
+	before = before.mapEnds(p, func(iv Interval) Interval {
+		if iv.End > p || iv.Stickiness.growsAfter() {
+			iv.End += l
+		}
+		return iv
+	})
+	// starting at p, those growing before are kept at p, so the order is kept
+	var kept, moved []Interval
+	for _, iv := range at.collect() {
+		if iv.End > p || iv.Stickiness.growsAfter() {
+			iv.End += l
+		}
+		if iv.Stickiness.growsBefore() {
+			kept = append(kept, iv)
+		} else {
+			iv.Start += l
+			iv.End = max(iv.End, iv.Start)
+			moved = append(moved, iv)
+		}
+	}
+	s.root = before.concat(buildIntervals(kept)).concat(buildIntervals(moved)).concat(after)
+	return s
+}
+
+// Delete returns the new version with intervals moved by deleting l bytes at p.
+// Intervals in the deleted range become empty at p
+func (s Intervals) Delete(p, l int) Intervals {
+	if l <= 0 {
+		return s
+	}
+	before, rest := s.root.split(p)
+	inside, after := rest.split(p + l)
+	after = after.shift(-l)
+	before = before.mapEnds(p+1, func(iv Interval) Interval {
+		if iv.End > p+l {
+			iv.End -= l
+		} else {
+			iv.End = p
+		}
+		return iv
+	})
+	ivs := inside.collect()
+	for i, iv := range ivs {
+		ivs[i].Start = p
+		ivs[i].End = max(p, iv.End-l)
+	}
+	s.root = before.concat(buildIntervals(ivs)).concat(after)
+	return s
+}
+
+// Replace returns the new version with intervals moved by replacing l bytes at p with n bytes
+func (s Intervals) Replace(p, l, n int) Intervals {
+	return s.Delete(p, l).Insert(p, n)
+}
+
+// Decorated is a rope with intervals moved by its edits
+type Decorated struct {
+	Text      *Rope
+	Intervals Intervals
+}
+
+func (d Decorated) Insert(n int, bs []byte) Decorated {
+	return Decorated{
+		Text:      d.Text.Insert(n, bs),
+		Intervals: d.Intervals.Insert(n, len(bs)),
+	}
+}
+
+func (d Decorated) Delete(n, l int) Decorated {
+	l = max(min(l, d.Text.Len()-n), 0)
+	return Decorated{
+		Text:      d.Text.Delete(n, l),
+		Intervals: d.Intervals.Delete(n, l),
+	}
+}
+
+func (d Decorated) Replace(n, l int, bs []byte) Decorated {
+	return d.Delete(n, l).Insert(n, bs)
+}
@@ -0,0 +1,175 @@
+package rope
+
+import (
+	mrand "math/rand"
+	"sort"
+	"testing"
+)
+
+func TestIntervals(t *testing.T) {
+	var s Intervals
+	var model []Interval
+	length := 1000
+	versions := []Intervals{}
+	models := [][]Interval{}
+
+	equal := func(a, b []Interval) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i].ID != b[i].ID || a[i].Start != b[i].Start || a[i].End != b[i].End {
+				return false
+			}
+		}
+		return true
+	}
+
+	for i := 0; i < 3000; i++ {
+		p := mrand.Intn(length + 1)
+		switch mrand.Intn(6) {
+		case 0, 1: // add
+			start := mrand.Intn(length + 1)
+			end := min(start+mrand.Intn(50), length)
+			if mrand.Intn(5) == 0 {
+				end = start
+			}
+			var iv Interval
+			s, iv = s.Add(Interval{
+				Start:      start,
+				End:        end,
+				Stickiness: Stickiness(mrand.Intn(4)),
+			})
+			model = append(model, iv)
+		case 2: // remove
+			if len(model) == 0 {
+				continue
+			}
+			j := mrand.Intn(len(model))
+			s = s.Remove(model[j])
+			model = append(model[:j:j], model[j+1:]...)
+		case 3: // insert
+			l := mrand.Intn(20) + 1
+			s = s.Insert(p, l)
+			next := make([]Interval, len(model))
+			for j, iv := range model {
+				growsAfter := iv.Stickiness == AlwaysGrows || iv.Stickiness == GrowsAfter
+				growsBefore := iv.Stickiness == AlwaysGrows || iv.Stickiness == GrowsBefore
+				if iv.End > p || iv.End == p && growsAfter {
+					iv.End += l
+				}
+				if iv.Start > p || iv.Start == p && !growsBefore {
+					iv.Start += l
+				}
+				iv.End = max(iv.End, iv.Start)
+				next[j] = iv
+			}
+			model = next
+			length += l
+		case 4: // delete
+			l := min(mrand.Intn(20), length-p)
+			s = s.Delete(p, l)
+			next := make([]Interval, len(model))
+			move := func(x int) int {
+				if x <= p {
+					return x
+				}
+				if x < p+l {
+					return p
+				}
+				return x - l
+			}
+			for j, iv := range model {
+				iv.Start = move(iv.Start)
+				iv.End = move(iv.End)
+				next[j] = iv
+			}
+			model = next
+			length -= l
+		case 5: // keep version
+			versions = append(versions, s)
+			models = append(models, append([]Interval(nil), model...))
+		}
+		sort.SliceStable(model, func(i, j int) bool {
+			return model[i].Start < model[j].Start
+		})
+		if s.Len() != len(model) || !equal(s.All(), model) {
+			t.Fatalf("%d", i)
+		}
+
+		from := mrand.Intn(length + 1)
+		to := from + mrand.Intn(100)
+		var expected []Interval
+		for _, iv := range model {
+			if iv.Start == iv.End && from <= iv.Start && iv.Start < to ||
+				iv.Start < to && from < iv.End {
+				expected = append(expected, iv)
+			}
+		}
+		if !equal(s.Query(from, to), expected) {
+			t.Fatal()
+		}
+		expected = expected[:0]
+		for _, iv := range model {
+			if iv.Start <= from && from < iv.End {
+				expected = append(expected, iv)
+			}
+		}
+		if !equal(s.Stab(from), expected) {
+			t.Fatal()
+		}
+	}
+
+	// persistent
+	for i, v := range versions {
+		if !equal(v.All(), models[i]) {
+			t.Fatal()
+		}
+	}
+}
+
+func TestIntervalsStickiness(t *testing.T) {
+	var s Intervals
+	for _, st := range []Stickiness{NeverGrows, AlwaysGrows, GrowsBefore, GrowsAfter} {
+		s, _ = s.Add(Interval{
+			Start:      10,
+			End:        20,
+			Stickiness: st,
+		})
+	}
+	s = s.Insert(10, 5).Insert(25, 5)
+	expected := map[Stickiness][2]int{
+		NeverGrows:  {15, 25},
+		AlwaysGrows: {10, 30},
+		GrowsBefore: {10, 25},
+		GrowsAfter:  {15, 30},
+	}
+	for _, iv := range s.All() {
+		if e := expected[iv.Stickiness]; iv.Start != e[0] || iv.End != e[1] {
+			t.Fatalf("%v %v", iv, e)
+		}
+	}
+}
+
+func TestDecorated(t *testing.T) {
+	d := Decorated{
+		Text: NewFromString("foo bar baz"),
+	}
+	var bar Interval
+	d.Intervals, bar = d.Intervals.Add(Interval{
+		Start: 4,
+		End:   7,
+		Value: "bar",
+	})
+	d2 := d.Insert(0, []byte("qux ")).Replace(0, 3, []byte("quux")).Delete(100, 1)
+	if string(d2.Text.Bytes()) != "quux foo bar baz" {
+		t.Fatal()
+	}
+	ivs := d2.Intervals.Stab(10)
+	if len(ivs) != 1 || ivs[0].ID != bar.ID || string(d2.Text.Sub(ivs[0].Start, ivs[0].End-ivs[0].Start)) != "bar" {
+		t.Fatal()
+	}
+	if d.Intervals.All()[0].Start != 4 {
+		t.Fatal()
+	}
+}
@@ -0,0 +1,30 @@
+package rope
+
+import "sync"
+
+// lazyNode loads content or children of a node on first access.
+// load is dropped after loading, releasing what it references
+type lazyNode struct {
+	once sync.Once
+	load func(*Rope) error
+	err  error
+}
+
+// ensure loads the node on first access. Loading errors panic
+func (r *Rope) ensure() {
+	if err := r.load(); err != nil {
+		panic(err)
+	}
+}
+
+// load loads the node on first access and returns the loading error
+func (r *Rope) load() error {
+	if r == nil || r.lazy == nil {
+		return nil
+	}
+	r.lazy.once.Do(func() {
+		r.lazy.err = r.lazy.load(r)
+		r.lazy.load = nil
+	})
+	return r.lazy.err
+}
@@ -0,0 +1,497 @@
+package rope
+
+import (
+	"bytes"
+	"strings"
+)
+
+// UnicodeLineSeparators makes line APIs treat U+2028 LINE SEPARATOR and U+2029 PARAGRAPH SEPARATOR as line terminators.
+// Should be set before use, width metrics memoized by CacheWidths depend on it
+var UnicodeLineSeparators = false
+
+// iterTerminators calls fn with offsets and lengths of line terminators from offset.
+// "\r\n", "\n" and "\r" are terminators, "\r\n" is one even if it is split between leaves
+func (r *Rope) iterTerminators(offset int, fn func(pos, l int) bool) bool {
+	pendingCR := -1
+	var partial []byte // prefix of a separator at the end of the last leaf
+	partialPos := 0
+	stopped := false
+	emit := func(pos, l int) bool {
+		stopped = !fn(pos, l)
+		return !stopped
+	}
+	pos := offset
+	r.Iter(offset, func(bs []byte) bool {
+		base := pos
+		pos += len(bs)
+		i := 0
+		if len(partial) > 0 {
+			take := min(3-len(partial), len(bs))
+			partial = append(partial, bs[:take]...)
+			if len(partial) < 3 {
+				return true
+			}
+			if isUnicodeLineSeparator(partial) {
+				if !emit(partialPos, 3) {
+					return false
+				}
+				i = take
+			}
+			partial = nil
+		}
+		for i < len(bs) {
+			if pendingCR >= 0 {
+				p := pendingCR
+				pendingCR = -1
+				if bs[i] == '\n' {
+					if !emit(p, 2) {
+						return false
+					}
+					i++
+					continue
+				}
+				if !emit(p, 1) {
+					return false
+				}
+			}
+			j := indexTerminatorByte(bs[i:])
+			if j < 0 {
+				break
+			}
+			j += i
+			i = j + 1
+			switch bs[j] {
+			case '\n':
+				if !emit(base+j, 1) {
+					return false
+				}
+			case '\r':
+				pendingCR = base + j
+			default: // 0xe2
+				rest := bs[j:min(j+3, len(bs))]
+				if len(rest) == 3 {
+					if isUnicodeLineSeparator(rest) {
+						if !emit(base+j, 3) {
+							return false
+						}
+						i = j + 3
+					}
+				} else if len(rest) == 1 || rest[1] == 0x80 { // may continue in the next leaf
+					partial = append(partial[:0], rest...)
+					partialPos = base + j
+					i = len(bs)
+				}
+			}
+		}
+		return true
+	})
+	if !stopped && pendingCR >= 0 {
+		emit(pendingCR, 1)
+	}
+	return !stopped
+}
+
+// indexTerminatorByte returns the index of the first CR, LF, or the first byte of Unicode line separators if enabled
+func indexTerminatorByte(bs []byte) int {
+	i := bytes.IndexAny(bs, "\r\n")
+	if !UnicodeLineSeparators {
+		return i
+	}
+	if i >= 0 {
+		bs = bs[:i]
+	}
+	if j := bytes.IndexByte(bs, 0xe2); j >= 0 {
+		return j
+	}
+	return i
+}
+
+func isUnicodeLineSeparator(bs []byte) bool {
+	return bs[0] == 0xe2 && bs[1] == 0x80 && (bs[2] == 0xa8 || bs[2] == 0xa9)
+}
+
+// iterLeafTerminators calls fn with terminators starting in bs, as if nothing is before or after it.
+// Unicode line separators continuing after bs are not included
+func iterLeafTerminators(bs []byte, fn func(pos, l int) bool) {
+	for i := 0; ; {
+		j := indexTerminatorByte(bs[i:])
+		if j < 0 {
+			return
+		}
+		j += i
+		l := 1
+		switch {
+		case bs[j] == '\r' && j+1 < len(bs) && bs[j+1] == '\n':
+			l = 2
+		case bs[j] == 0xe2:
+			if j+3 > len(bs) || !isUnicodeLineSeparator(bs[j:j+3]) {
+				i = j + 1
+				continue
+			}
+			l = 3
+		}
+		if !fn(j, l) {
+			return
+		}
+		i = j + l
+	}
+}
+
+// lineMetrics counts line terminators starting in a span, as if nothing is before or after it,
+// with bytes at the ends to correct counts when spans are combined
+type lineMetrics struct {
+	separators bool // UnicodeLineSeparators when counted
+	size       int
+	count      int
+	// whether the span starts with LF, which is not a terminator after a CR, and ends with CR
+	firstLF bool
+	lastCR  bool
+	// first and last two bytes, the last ones right aligned
+	head [2]byte
+	tail [2]byte
+}
+
+func newLineMetrics(bs []byte) *lineMetrics {
+	m := &lineMetrics{
+		separators: UnicodeLineSeparators,
+		size:       len(bs),
+		firstLF:    bs[0] == '\n',
+		lastCR:     bs[len(bs)-1] == '\r',
+	}
+	copy(m.head[:], bs)
+	copy(m.tail[max(2-len(bs), 0):], bs[max(len(bs)-2, 0):])
+	iterLeafTerminators(bs, func(_, _ int) bool {
+		m.count++
+		return true
+	})
+	return m
+}
+
+// crossing returns the number of bytes in m of a Unicode line separator continuing in m2, or 0
+func (m *lineMetrics) crossing(m2 *lineMetrics) int {
+	if !m.separators {
+		return 0
+	}
+	for k := 1; k <= 2; k++ {
+		if m.size >= k && m2.size >= 3-k {
+			var bs [3]byte
+			copy(bs[:], m.tail[2-k:])
+			copy(bs[k:], m2.head[:3-k])
+			if isUnicodeLineSeparator(bs[:]) {
+				return k
+			}
+		}
+	}
+	return 0
+}
+
+// then returns metrics of m followed by m2
+func (m *lineMetrics) then(m2 *lineMetrics) *lineMetrics {
+	ret := &lineMetrics{
+		separators: m.separators,
+		size:       m.size + m2.size,
+		count:      m.count + m2.count,
+		firstLF:    m.firstLF,
+		lastCR:     m2.lastCR,
+		head:       m.head,
+		tail:       m2.tail,
+	}
+	if m.lastCR && m2.firstLF { // CRLF
+		ret.count--
+	}
+	if m.crossing(m2) > 0 {
+		ret.count++
+	}
+	if m.size == 1 {
+		ret.head[1] = m2.head[0]
+	}
+	if m2.size == 1 {
+		ret.tail[0] = m.tail[1]
+	}
+	return ret
+}
+
+// lineMetrics returns line metrics of the subtree, memoized
+func (r *Rope) lineMetrics() *lineMetrics {
+	if m := r.lines.Load(); m != nil && m.separators == UnicodeLineSeparators {
+		return m
+	}
+	r.ensure()
+	var m *lineMetrics
+	if len(r.content) > 0 { // leaf
+		m = newLineMetrics(r.content)
+	} else {
+		m = r.left.lineMetrics().then(r.right.lineMetrics())
+	}
+	r.lines.Store(m)
+	return m
+}
+
+func (r *Rope) terminatorCount() int {
+	if r == nil {
+		return 0
+	}
+	return r.lineMetrics().count
+}
+
+// terminatorStart returns the offset of the k-th line terminator, counting from 0, which must exist
+func (r *Rope) terminatorStart(k int) (offset int) {
+	for {
+		r.ensure()
+		if len(r.content) > 0 { // leaf
+			iterLeafTerminators(r.content, func(pos, _ int) bool {
+				if k == 0 {
+					offset += pos
+					return false
+				}
+				k--
+				return true
+			})
+			return
+		}
+		lm, rm := r.left.lineMetrics(), r.right.lineMetrics()
+		if k < lm.count {
+			r = r.left
+			continue
+		}
+		k -= lm.count
+		if inLeft := lm.crossing(rm); inLeft > 0 {
+			if k == 0 {
+				return offset + r.weight - inLeft
+			}
+			k--
+		}
+		if lm.lastCR && rm.firstLF { // the LF is counted in the right subtree
+			k++
+		}
+		offset += r.weight
+		r = r.right
+	}
+}
+
+// terminatorLen returns the length of the line terminator at offset
+func (r *Rope) terminatorLen(offset int) int {
+	switch r.Index(offset) {
+	case '\r':
+		if offset+1 < r.Len() && r.Index(offset+1) == '\n' {
+			return 2
+		}
+		return 1
+	case '\n':
+		return 1
+	}
+	return 3
+}
+
+// terminatorsBefore returns the number of line terminators ending at or before offset
+func (r *Rope) terminatorsBefore(offset int) (n int) {
+	for r != nil && offset > 0 {
+		r.ensure()
+		if len(r.content) > 0 { // leaf
+			iterLeafTerminators(r.content, func(pos, l int) bool {
+				if pos+l > offset {
+					return false
+				}
+				n++
+				return true
+			})
+			return
+		}
+		lm, rm := r.left.lineMetrics(), r.right.lineMetrics()
+		crlf := lm.lastCR && rm.firstLF
+		if offset < r.weight {
+			r = r.left
+			continue
+		}
+		n += lm.count
+		if crlf { // counted in both subtrees, or ending after offset
+			n--
+		}
+		if offset == r.weight {
+			return
+		}
+		if inLeft := lm.crossing(rm); inLeft > 0 && offset-r.weight >= 3-inLeft {
+			n++
+		}
+		offset -= r.weight
+		r = r.right
+	}
+	return
+}
+
+// LineCount returns the number of lines, which is one more than the number of terminators.
+// Terminators are counted in nodes and memoized, so line lookups take O(log n) after the first one
+func (r *Rope) LineCount() int {
+	return r.terminatorCount() + 1
+}
+
+// IterLines calls fn with lines from line, counting from 0.
+// Content of a line is [start, end), and the next line starts at next, after the terminator
+func (r *Rope) IterLines(line int, fn func(start, end, next int) bool) bool {
+	start, ok := r.seekLine(line)
+	if !ok {
+		return true
+	}
+	if !r.iterTerminators(start, func(pos, l int) bool {
+		if !fn(start, pos, pos+l) {
+			return false
+		}
+		start = pos + l
+		return true
+	}) {
+		return false
+	}
+	l := r.Len()
+	return fn(start, l, l)
+}
+
+// LineStart returns the offset of the start of line, counting from 0, or the length if there are less lines
+func (r *Rope) LineStart(line int) int {
+	ret, _ := r.seekLine(line)
+	return ret
+}
+
+// seekLine returns the start of line, and whether the line exists
+func (r *Rope) seekLine(line int) (int, bool) {
+	if line <= 0 {
+		return 0, true
+	}
+	if line > r.terminatorCount() {
+		return r.Len(), false
+	}
+	pos := r.terminatorStart(line - 1)
+	return pos + r.terminatorLen(pos), true
+}
+
+// LineEnd returns the offset of the terminator of line, or the length if it is the last line
+func (r *Rope) LineEnd(line int) int {
+	if line < 0 {
+		return 0
+	}
+	if line >= r.terminatorCount() {
+		return r.Len()
+	}
+	return r.terminatorStart(line)
+}
+
+// LineAt returns the line containing offset, counting from 0. Terminators belong to the lines they end
+func (r *Rope) LineAt(offset int) int {
+	return r.terminatorsBefore(offset)
+}
+
+// isLineTerminator returns whether ru ends a line, the LF of a CRLF is part of the terminator started by the CR
+func isLineTerminator(ru rune) bool {
+	return ru == '\n' || ru == '\r' || UnicodeLineSeparators && (ru == '\u2028' || ru == '\u2029')
+}
+
+// terminatorBefore returns the length of the line terminator ending at end, or 0
+func (r *Rope) terminatorBefore(end int) int {
+	if end <= 0 {
+		return 0
+	}
+	switch r.Index(end - 1) {
+	case '\n':
+		if end > 1 && r.Index(end-2) == '\r' {
+			return 2
+		}
+		return 1
+	case '\r':
+		return 1
+	}
+	if UnicodeLineSeparators && end >= 3 && isUnicodeLineSeparator(r.Sub(end-3, 3)) {
+		return 3
+	}
+	return 0
+}
+
+// DetectLineEnding returns the most frequent line ending, preferring LF, CRLF then CR on ties.
+// KeepLineEnding is returned if there is no line ending
+func (r *Rope) DetectLineEnding() LineEnding {
+	var counts [CR + 1]int
+	r.iterTerminators(0, func(pos, l int) bool {
+		switch {
+		case l == 2:
+			counts[CRLF]++
+		case l == 1 && r.Index(pos) == '\r':
+			counts[CR]++
+		case l == 1:
+			counts[LF]++
+		}
+		return true
+	})
+	ret := KeepLineEnding
+	for _, e := range []LineEnding{LF, CRLF, CR} {
+		if counts[e] > counts[ret] {
+			ret = e
+		}
+	}
+	return ret
+}
+
+// NormalizeLineEndings returns the rope with "\r\n", "\n" and "\r" converted to the line ending.
+// Subtrees without line endings to convert are shared. Unicode line separators are kept
+func (r *Rope) NormalizeLineEndings(to LineEnding) *Rope {
+	if to == KeepLineEnding {
+		return r
+	}
+	return r.normalizeLineEndings(r, 0, r.Len(), to).check()
+}
+
+func (r *Rope) normalizeLineEndings(root *Rope, offset int, length int, to LineEnding) *Rope {
+	if r == nil {
+		return nil
+	}
+	r.ensure()
+	if len(r.content) > 0 { // leaf
+		content := r.content
+		if bytes.IndexByte(content, '\r') < 0 && (to == LF || bytes.IndexByte(content, '\n') < 0) {
+			return r
+		}
+		// "\r\n" split between leaves is converted at the CR, and the LF is kept if the line ending ends with LF
+		prevCR := content[0] == '\n' && offset > 0 && root.Index(offset-1) == '\r'
+		end := offset + len(content)
+		nextLF := content[len(content)-1] == '\r' && end < length && root.Index(end) == '\n'
+		converted := convertLineEndings(content, to, prevCR, nextLF)
+		if bytes.Equal(converted, content) {
+			return r
+		}
+		return NewFromBytes(converted)
+	}
+	left := r.left.normalizeLineEndings(root, offset, length, to)
+	right := r.right.normalizeLineEndings(root, offset+r.weight, length, to)
+	if left == r.left && right == r.right {
+		return r
+	}
+	return left.join(right)
+}
+
+func convertLineEndings(bs []byte, to LineEnding, prevCR, nextLF bool) []byte {
+	ending := to.String()
+	endsWithLF := strings.HasSuffix(ending, "\n")
+	ret := make([]byte, 0, len(bs))
+	if prevCR {
+		if endsWithLF {
+			ret = append(ret, '\n')
+		}
+		bs = bs[1:]
+	}
+	for len(bs) > 0 {
+		i := bytes.IndexAny(bs, "\r\n")
+		if i < 0 {
+			ret = append(ret, bs...)
+			break
+		}
+		ret = append(ret, bs[:i]...)
+		switch {
+		case bs[i] == '\r' && i+1 < len(bs) && bs[i+1] == '\n':
+			ret = append(ret, ending...)
+			i++
+		case bs[i] == '\r' && i+1 == len(bs) && nextLF:
+			ret = append(ret, strings.TrimSuffix(ending, "\n")...)
+		default:
+			ret = append(ret, ending...)
+		}
+		bs = bs[i+1:]
+	}
+	return ret
+}
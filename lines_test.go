@@ -0,0 +1,221 @@
+package rope
+
+import (
+	mrand "math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// lineRanges returns starts and content ends of lines by scanning bytes
+func lineRanges(text string) (ret [][2]int) {
+	start := 0
+	for i := 0; i < len(text); i++ {
+		l := 0
+		switch {
+		case strings.HasPrefix(text[i:], "\r\n"):
+			l = 2
+		case text[i] == '\n', text[i] == '\r':
+			l = 1
+		case UnicodeLineSeparators && (strings.HasPrefix(text[i:], "\u2028") || strings.HasPrefix(text[i:], "\u2029")):
+			l = 3
+		default:
+			continue
+		}
+		ret = append(ret, [2]int{start, i})
+		i += l - 1
+		start = i + 1
+	}
+	return append(ret, [2]int{start, len(text)})
+}
+
+func leafSet(r *Rope, set map[*Rope]bool) map[*Rope]bool {
+	if r == nil {
+		return set
+	}
+	r.ensure()
+	if len(r.content) > 0 {
+		set[r] = true
+		return set
+	}
+	return leafSet(r.right, leafSet(r.left, set))
+}
+
+func TestLines(t *testing.T) {
+	defer func() {
+		UnicodeLineSeparators = false
+	}()
+	pieces := []string{"a", "bc", "中", "\n", "\r", "\r\n", "\u2028", "\u2029", "\xe2\x80"}
+	for _, seps := range []bool{false, true} {
+		UnicodeLineSeparators = seps
+		for i := 0; i < 50; i++ {
+			// random inserts, so terminators are split between leaves
+			var text string
+			var r *Rope
+			for j, n := 0, mrand.Intn(30); j < n; j++ {
+				piece := pieces[mrand.Intn(len(pieces))]
+				pos := mrand.Intn(len(text) + 1)
+				for pos < len(text) && !utf8.RuneStart(text[pos]) {
+					pos++
+				}
+				text = text[:pos] + piece + text[pos:]
+				r = r.Insert(pos, []byte(piece))
+			}
+
+			lines := lineRanges(text)
+			if r.LineCount() != len(lines) {
+				t.Fatalf("%q: got %d lines", text, r.LineCount())
+			}
+			for line, l := range lines {
+				if r.LineStart(line) != l[0] || r.LineEnd(line) != l[1] {
+					t.Fatalf("%q: line %d", text, line)
+				}
+			}
+			if r.LineStart(len(lines)) != len(text) || r.LineEnd(len(lines)) != len(text) {
+				t.Fatal()
+			}
+			line := 0
+			for offset := 0; offset <= len(text); offset++ {
+				if line+1 < len(lines) && offset >= lines[line+1][0] {
+					line++
+				}
+				if got := r.LineAt(offset); got != line {
+					t.Fatalf("%q: offset %d at line %d, got %d", text, offset, line, got)
+				}
+			}
+
+			if !r.IterLines(len(lines), func(_, _, _ int) bool {
+				t.Fatal()
+				return false
+			}) {
+				t.Fatal()
+			}
+
+			from := mrand.Intn(len(lines))
+			n := 0
+			r.IterLines(from, func(start, end, next int) bool {
+				l := lines[from+n]
+				expectedNext := len(text)
+				if from+n+1 < len(lines) {
+					expectedNext = lines[from+n+1][0]
+				}
+				if start != l[0] || end != l[1] || next != expectedNext {
+					t.Fatalf("%q: line %d", text, from+n)
+				}
+				n++
+				return true
+			})
+			if n != len(lines)-from {
+				t.Fatal()
+			}
+		}
+	}
+}
+
+func TestLinesOneByteLeaves(t *testing.T) {
+	defer func() {
+		UnicodeLineSeparators = false
+		MergeLeaves = true
+	}()
+	MergeLeaves = false
+	pieces := []string{"a", "\n", "\r", "\r\n", "\u2028", "\xe2\x80", "\xe2"}
+	for _, seps := range []bool{false, true} {
+		UnicodeLineSeparators = seps
+		for i := 0; i < 100; i++ {
+			var text string
+			for j, n := 0, mrand.Intn(20); j < n; j++ {
+				text += pieces[mrand.Intn(len(pieces))]
+			}
+			// terminators split at every byte, in random tree shapes
+			var r *Rope
+			for j := 0; j < len(text); j++ {
+				r = r.Concat(NewFromBytes([]byte{text[j]}))
+			}
+			for j := 0; j < 5 && r != nil; j++ {
+				r1, r2 := r.Split(mrand.Intn(r.Len()))
+				r = r1.Concat(r2)
+			}
+			lines := lineRanges(text)
+			if r.LineCount() != len(lines) {
+				t.Fatalf("%q: got %d lines", text, r.LineCount())
+			}
+			for line, l := range lines {
+				if r.LineStart(line) != l[0] || r.LineEnd(line) != l[1] {
+					t.Fatalf("%q: line %d", text, line)
+				}
+				next := len(text) + 1
+				if line+1 < len(lines) {
+					next = lines[line+1][0]
+				}
+				for offset := l[0]; offset < next; offset++ {
+					if r.LineAt(offset) != line {
+						t.Fatalf("%q: offset %d", text, offset)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDetectLineEnding(t *testing.T) {
+	for text, e := range map[string]LineEnding{
+		"":                     KeepLineEnding,
+		"foo":                  KeepLineEnding,
+		"a\nb\nc\r\n":          LF,
+		"a\r\nb\r\nc\n":        CRLF,
+		"a\rb\rc\r\n":          CR,
+		"a\r\nb\n":             LF,
+		"a\r\nb\r":             CRLF,
+		"aaaaaaa\r\nbbbbbbb\r": CRLF,
+	} {
+		if got := NewFromBytes([]byte(text)).DetectLineEnding(); got != e {
+			t.Fatalf("%q: got %v", text, got)
+		}
+	}
+	// CRLF split between leaves
+	r := NewFromBytes([]byte("aaaaaaa\r")).Concat(NewFromBytes([]byte("\nb\nc\r\n")))
+	if r.DetectLineEnding() != CRLF || r.LineCount() != 4 {
+		t.Fatal()
+	}
+}
+
+func TestNormalizeLineEndings(t *testing.T) {
+	terminator := regexp.MustCompile("\r\n|\r|\n")
+	pieces := []string{"a", "bcdefg", "\n", "\r", "\r\n", "\n\r"}
+	for i := 0; i < 100; i++ {
+		var text string
+		var r *Rope
+		for j, n := 0, mrand.Intn(30); j < n; j++ {
+			piece := pieces[mrand.Intn(len(pieces))]
+			pos := mrand.Intn(len(text) + 1)
+			text = text[:pos] + piece + text[pos:]
+			r = r.Insert(pos, []byte(piece))
+		}
+		for _, to := range []LineEnding{LF, CRLF, CR} {
+			got := r.NormalizeLineEndings(to)
+			if expected := terminator.ReplaceAllString(text, to.String()); string(got.Bytes()) != expected {
+				t.Fatalf("%q to %v: got %q", text, to, got.Bytes())
+			}
+		}
+		if r.NormalizeLineEndings(KeepLineEnding) != r {
+			t.Fatal()
+		}
+	}
+
+	// unchanged subtrees are shared
+	r := NewFromBytes([]byte(strings.Repeat("abcdefgh", 32) + "x\r\ny"))
+	if r.NormalizeLineEndings(CRLF) != r {
+		t.Fatal()
+	}
+	leaves := leafSet(r, map[*Rope]bool{})
+	shared := 0
+	for leaf := range leafSet(r.NormalizeLineEndings(LF), map[*Rope]bool{}) {
+		if leaves[leaf] {
+			shared++
+		}
+	}
+	if shared < len(leaves)-2 {
+		t.Fatalf("%d of %d leaves shared", shared, len(leaves))
+	}
+}
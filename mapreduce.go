@@ -0,0 +1,236 @@
+package rope
+
+import (
+	"bytes"
+	"regexp"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// parts per worker, for load balancing
+const partsPerWorker = 4
+
+type part struct {
+	offset int
+	node   *Rope
+}
+
+// parts returns at least n subtrees covering r in order, or all leaves if there are less
+func (r *Rope) parts(n int) []part {
+	if r == nil {
+		return nil
+	}
+	ret := []part{{0, r}}
+	for len(ret) < n {
+		var next []part
+		for _, p := range ret {
+			p.node.ensure()
+			if len(p.node.content) > 0 { // leaf
+				next = append(next, p)
+				continue
+			}
+			next = append(next,
+				part{p.offset, p.node.left},
+				part{p.offset + p.node.weight, p.node.right},
+			)
+		}
+		if len(next) == len(ret) { // all leaves
+			break
+		}
+		ret = next
+	}
+	return ret
+}
+
+// runParts calls fn with indexes of parts by workers goroutines
+func runParts(workers int, parts int, fn func(i int)) {
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, parts); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= parts {
+					return
+				}
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// ParallelIter calls fn with leaves and their offsets by workers goroutines.
+// The tree is divided at subtree boundaries, leaves of one subtree are passed in order, but subtrees are not.
+// fn is called concurrently. Iteration stops if fn returns false, and ParallelIter returns false.
+// workers <= 0 means GOMAXPROCS
+func (r *Rope) ParallelIter(workers int, fn func(offset int, bs []byte) bool) bool {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	parts := r.parts(workers * partsPerWorker)
+	var stop atomic.Bool
+	runParts(workers, len(parts), func(i int) {
+		offset := parts[i].offset
+		parts[i].node.Iter(0, func(bs []byte) bool {
+			if stop.Load() || !fn(offset, bs) {
+				stop.Store(true)
+				return false
+			}
+			offset += len(bs)
+			return true
+		})
+	})
+	return !stop.Load()
+}
+
+// Chunk is a part of the rope passed to the map function of MapReduce
+type Chunk struct {
+	Offset int
+	// Bytes is a copy of the chunk, and overlapping bytes of following chunks
+	Bytes []byte
+	// Len is the length of the chunk, Bytes[Len:] is the overlap
+	Len int
+}
+
+// MapReduce divides r into chunks at subtree boundaries, calls mapFn with chunks by workers goroutines,
+// then folds results with reduceFn in order of chunks. Chunks include overlap bytes following them,
+// so that matches crossing chunk boundaries can be found by the chunk of their start.
+// The zero T is returned for empty ropes. workers <= 0 means GOMAXPROCS
+func MapReduce[T any](
+	r *Rope,
+	workers int,
+	overlap int,
+	mapFn func(Chunk) T,
+	reduceFn func(T, T) T,
+) (ret T) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	parts := r.parts(workers * partsPerWorker)
+	results := make([]T, len(parts))
+	runParts(workers, len(parts), func(i int) {
+		node := parts[i].node
+		l := node.Len()
+		bs := make([]byte, 0, l+overlap)
+		node.Iter(0, func(leaf []byte) bool {
+			bs = append(bs, leaf...)
+			return true
+		})
+		if overlap > 0 {
+			bs = append(bs, r.Sub(parts[i].offset+l, overlap)...)
+		}
+		results[i] = mapFn(Chunk{
+			Offset: parts[i].offset,
+			Bytes:  bs,
+			Len:    l,
+		})
+	})
+	for i, res := range results {
+		if i == 0 {
+			ret = res
+		} else {
+			ret = reduceFn(ret, res)
+		}
+	}
+	return
+}
+
+// CountParallel returns the number of c in r, counted by workers goroutines.
+// Lines are CountParallel('\n', workers) + 1
+func (r *Rope) CountParallel(c byte, workers int) int {
+	return MapReduce(r, workers, 0, func(chunk Chunk) int {
+		return bytes.Count(chunk.Bytes, []byte{c})
+	}, func(a, b int) int {
+		return a + b
+	})
+}
+
+// IndexAllParallel returns offsets of all occurrences of sep in r, including overlapping ones, searched by workers goroutines
+func (r *Rope) IndexAllParallel(sep []byte, workers int) []int {
+	if len(sep) == 0 {
+		return nil
+	}
+	return MapReduce(r, workers, len(sep)-1, func(chunk Chunk) (ret []int) {
+		for i := 0; i < chunk.Len; {
+			n := bytes.Index(chunk.Bytes[i:], sep)
+			if n < 0 || i+n >= chunk.Len {
+				break
+			}
+			ret = append(ret, chunk.Offset+i+n)
+			i += n + 1
+		}
+		return
+	}, func(a, b []int) []int {
+		return append(a, b...)
+	})
+}
+
+// FindAllRegexpParallel returns offset pairs of matches of re in r, searched by workers goroutines.
+// Chunks are matched independently with maxLen-1 bytes of overlap, and matches starting in a chunk are reported.
+// Where the last match of a chunk extends into the next one, the next chunk is rescanned from its end
+// until the scans agree, so the result is the same as matching the whole rope.
+// Matches longer than maxLen may be truncated, and anchors and word boundaries match at chunk boundaries
+func (r *Rope) FindAllRegexpParallel(re *regexp.Regexp, maxLen int, workers int) [][2]int {
+	if r.Len() == 0 {
+		if re.Match(nil) {
+			return [][2]int{{0, 0}}
+		}
+		return nil
+	}
+	overlap := max(maxLen-1, 0)
+	// join appends b to a, without an empty match of b right after the last match of a, like FindAllIndex
+	join := func(a, b [][2]int) [][2]int {
+		if len(a) > 0 && len(b) > 0 && b[0][0] == b[0][1] && b[0][0] == a[len(a)-1][1] {
+			b = b[1:]
+		}
+		return append(a, b...)
+	}
+	return MapReduce(r, workers, overlap, func(chunk Chunk) (ret [][2]int) {
+		atEnd := chunk.Offset+chunk.Len == r.Len()
+		for _, loc := range re.FindAllIndex(chunk.Bytes, -1) {
+			if loc[0] > chunk.Len || loc[0] == chunk.Len && !atEnd { // in the next chunk
+				break
+			}
+			ret = append(ret, [2]int{chunk.Offset + loc[0], chunk.Offset + loc[1]})
+		}
+		return
+	}, func(a, b [][2]int) [][2]int {
+		if len(a) == 0 || len(b) == 0 || b[0][0] >= a[len(a)-1][1] {
+			return join(a, b)
+		}
+		// matches of b are dropped while they start before pos. The scan of b searched from the end of
+		// the last dropped one, so once pos reaches it, or the next match starts after it, the rest of b follows
+		pos := a[len(a)-1][1]
+		resumed := pos
+		for {
+			for len(b) > 0 && b[0][0] < pos {
+				resumed = b[0][1]
+				b = b[1:]
+			}
+			if resumed <= pos {
+				return join(a, b)
+			}
+			text := r.Sub(pos, resumed-pos+overlap)
+			loc := re.FindIndex(text)
+			if loc == nil || pos+loc[0] >= resumed {
+				return join(a, b)
+			}
+			m := [2]int{pos + loc[0], pos + loc[1]}
+			if m[0] == m[1] { // empty match, not allowed right after a match, and the scan moves by a rune
+				_, l := utf8.DecodeRune(text[loc[1]:])
+				pos = m[1] + max(l, 1)
+				if m[0] == a[len(a)-1][1] {
+					continue
+				}
+			} else {
+				pos = m[1]
+			}
+			a = append(a, m)
+		}
+	})
+}
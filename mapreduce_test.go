@@ -0,0 +1,205 @@
+package rope
+
+import (
+	"bytes"
+	mrand "math/rand"
+	"regexp"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestParts(t *testing.T) {
+	r := NewFromBytes(getRandomBytes(1000))
+	for _, n := range []int{1, 2, 3, 16, 1000} {
+		parts := r.parts(n)
+		if len(parts) < min(n, r.Stats().Leaves) {
+			t.Fatal()
+		}
+		offset := 0
+		for _, p := range parts {
+			if p.offset != offset {
+				t.Fatal()
+			}
+			offset += p.node.Len()
+		}
+		if offset != 1000 {
+			t.Fatal()
+		}
+	}
+	if len((*Rope)(nil).parts(4)) != 0 {
+		t.Fatal()
+	}
+}
+
+func TestParallelIter(t *testing.T) {
+	bs := getRandomBytes(10000)
+	r := NewFromBytes(bs)
+	for _, workers := range []int{0, 1, 4} {
+		got := make([]byte, len(bs))
+		var mu sync.Mutex
+		n := 0
+		if !r.ParallelIter(workers, func(offset int, leaf []byte) bool {
+			copy(got[offset:], leaf)
+			mu.Lock()
+			n += len(leaf)
+			mu.Unlock()
+			return true
+		}) {
+			t.Fatal()
+		}
+		if n != len(bs) || !bytes.Equal(got, bs) {
+			t.Fatal()
+		}
+	}
+	if r.ParallelIter(4, func(int, []byte) bool {
+		return false
+	}) {
+		t.Fatal()
+	}
+}
+
+func TestMapReduce(t *testing.T) {
+	if MapReduce((*Rope)(nil), 4, 0, func(Chunk) int {
+		return 1
+	}, func(a, b int) int {
+		return a + b
+	}) != 0 {
+		t.Fatal()
+	}
+
+	bs := getRandomBytes(10000)
+	r := NewFromBytes(bs)
+	// reduce in order
+	got := MapReduce(r, 4, 3, func(c Chunk) []byte {
+		if !bytes.Equal(c.Bytes, bs[c.Offset:min(c.Offset+c.Len+3, len(bs))]) {
+			t.Error()
+		}
+		return c.Bytes[:c.Len]
+	}, func(a, b []byte) []byte {
+		return append(a, b...)
+	})
+	if !bytes.Equal(got, bs) {
+		t.Fatal()
+	}
+}
+
+func TestCountParallel(t *testing.T) {
+	bs := bytes.Repeat([]byte("foo\nbar\r\nbaz"), 1000)
+	r := NewFromBytes(bs)
+	if r.CountParallel('\n', 4) != 2000 {
+		t.Fatal()
+	}
+	if (*Rope)(nil).CountParallel('\n', 4) != 0 {
+		t.Fatal()
+	}
+}
+
+func TestIndexAllParallel(t *testing.T) {
+	bs := bytes.Repeat([]byte("aaabaabcaaaa"), 1000)
+	r := NewFromBytes(bs)
+	for _, sep := range []string{"a", "aa", "aab", "baab", "caaaaaaab", "x"} {
+		var expected []int
+		for i := 0; i+len(sep) <= len(bs); i++ {
+			if bytes.HasPrefix(bs[i:], []byte(sep)) {
+				expected = append(expected, i)
+			}
+		}
+		got := r.IndexAllParallel([]byte(sep), 3)
+		if len(got) != len(expected) || !sort.IntsAreSorted(got) {
+			t.Fatalf("%s %d %d", sep, len(got), len(expected))
+		}
+		for i := range got {
+			if got[i] != expected[i] {
+				t.Fatal()
+			}
+		}
+	}
+	if r.IndexAllParallel(nil, 3) != nil {
+		t.Fatal()
+	}
+}
+
+func TestFindAllRegexpParallel(t *testing.T) {
+	bs := bytes.Repeat([]byte("foo123bar4567baz89"), 1000)
+	r := NewFromBytes(bs)
+	re := regexp.MustCompile(`[0-9]+`)
+	expected := re.FindAllIndex(bs, -1)
+	got := r.FindAllRegexpParallel(re, 4, 3)
+	if len(got) != len(expected) {
+		t.Fatalf("%d %d", len(got), len(expected))
+	}
+	for i := range got {
+		if got[i][0] != expected[i][0] || got[i][1] != expected[i][1] {
+			t.Fatal()
+		}
+	}
+}
+
+func TestFindAllRegexpParallelAcrossChunks(t *testing.T) {
+	r := NewFromBytes([]byte("xxxxxxxaaaaxxxxx"))
+	if got := r.FindAllRegexpParallel(regexp.MustCompile(`aa`), 2, 2); len(got) != 2 || got[0] != [2]int{7, 9} || got[1] != [2]int{9, 11} {
+		t.Fatalf("got %v", got)
+	}
+
+	for _, c := range []struct {
+		re     string
+		maxLen int
+	}{
+		{`aa`, 2},
+		{`aba|ab`, 3},
+		{`a.?a`, 3},
+		{`b[ab]{0,4}b`, 6},
+		{`(ab){1,3}`, 6},
+	} {
+		re := regexp.MustCompile(c.re)
+		for i := 0; i < 100; i++ {
+			text := make([]byte, mrand.Intn(200))
+			for j := range text {
+				text[j] = "aab x"[mrand.Intn(5)]
+			}
+			r := NewFromBytes(text)
+			var expected [][2]int
+			for _, loc := range re.FindAllIndex(r.Bytes(), -1) {
+				expected = append(expected, [2]int{loc[0], loc[1]})
+			}
+			got := r.FindAllRegexpParallel(re, c.maxLen, 1+mrand.Intn(8))
+			if len(got) != len(expected) {
+				t.Fatalf("%s in %q: got %v, expected %v", c.re, text, got, expected)
+			}
+			for j := range got {
+				if got[j] != expected[j] {
+					t.Fatalf("%s in %q: got %v, expected %v", c.re, text, got, expected)
+				}
+			}
+		}
+	}
+}
+
+func TestFindAllRegexpParallelEmptyMatches(t *testing.T) {
+	for _, pattern := range []string{`a*`, `x*`, `a*?`, `(ab)*`, `b?a*`} {
+		re := regexp.MustCompile(pattern)
+		for i := 0; i < 200; i++ {
+			// random leaf sizes give random chunk sizes
+			var r *Rope
+			for j, n := 0, mrand.Intn(20); j < n; j++ {
+				piece := make([]byte, 1+mrand.Intn(12))
+				for k := range piece {
+					piece[k] = "aabx"[mrand.Intn(4)]
+				}
+				r = r.Insert(mrand.Intn(r.Len()+1), piece)
+			}
+			var expected [][2]int
+			maxLen := 0
+			for _, loc := range re.FindAllIndex(r.Bytes(), -1) {
+				expected = append(expected, [2]int{loc[0], loc[1]})
+				maxLen = max(maxLen, loc[1]-loc[0])
+			}
+			got := r.FindAllRegexpParallel(re, maxLen+mrand.Intn(4), 1+mrand.Intn(8))
+			if !slices.Equal(got, expected) {
+				t.Fatalf("%s in %q: got %v, expected %v", pattern, r.Bytes(), got, expected)
+			}
+		}
+	}
+}
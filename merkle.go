@@ -0,0 +1,157 @@
+package rope
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// Merkle makes the cache key leaves by content hash instead of by content
+var Merkle = false
+
+type Hash [sha256.Size]byte
+
+const (
+	leafHashTag = 0
+	nodeHashTag = 1
+)
+
+func (h Hash) String() string {
+	return fmt.Sprintf("%x", h[:])
+}
+
+func leafHash(content []byte) (ret Hash) {
+	d := sha256.New()
+	d.Write([]byte{leafHashTag})
+	d.Write(content)
+	d.Sum(ret[:0])
+	return
+}
+
+func nodeHash(left, right Hash) (ret Hash) {
+	d := sha256.New()
+	d.Write([]byte{nodeHashTag})
+	d.Write(left[:])
+	d.Write(right[:])
+	d.Sum(ret[:0])
+	return
+}
+
+func leafKey(content string) Key {
+	if Merkle {
+		return Key{
+			hash: leafHash(unsafe.Slice(unsafe.StringData(content), len(content))),
+		}
+	}
+	return Key{
+		content: content,
+	}
+}
+
+// Hash returns the merkle hash of the tree, memoized in nodes
+func (r *Rope) Hash() Hash {
+	if r == nil {
+		return Hash{}
+	}
+	if h := r.hash.Load(); h != nil {
+		return *h
+	}
+	r.ensure()
+	var h Hash
+	if len(r.content) > 0 { // leaf
+		h = leafHash(r.content)
+	} else { // non leaf
+		h = nodeHash(r.left.Hash(), r.right.Hash())
+	}
+	r.hash.Store(&h)
+	return h
+}
+
+// Equal reports whether two ropes have the same content.
+// Ropes sharing structure are compared by hash in O(1)
+func (r *Rope) Equal(r2 *Rope) bool {
+	if r == r2 {
+		return true
+	}
+	if r.Len() != r2.Len() {
+		return false
+	}
+	if r.Hash() == r2.Hash() {
+		return true
+	}
+	var leaves [][]byte
+	r2.Iter(0, func(bs []byte) bool {
+		leaves = append(leaves, bs)
+		return true
+	})
+	return r.Iter(0, func(bs []byte) bool {
+		for len(bs) > 0 {
+			n := len(bs)
+			if n > len(leaves[0]) {
+				n = len(leaves[0])
+			}
+			if !bytes.Equal(bs[:n], leaves[0][:n]) {
+				return false
+			}
+			bs = bs[n:]
+			leaves[0] = leaves[0][n:]
+			if len(leaves[0]) == 0 {
+				leaves = leaves[1:]
+			}
+		}
+		return true
+	})
+}
+
+// Missing calls fn for every node of r that the peer lacks, parents before children.
+// Subtrees whose hash the peer has are skipped
+func (r *Rope) Missing(has func(Hash) bool, fn func(*Rope) bool) {
+	r.iterNodes(func(node *Rope) bool {
+		if has(node.Hash()) {
+			return false
+		}
+		return fn(node)
+	})
+}
+
+var ErrHashMismatch = errors.New("hash mismatch")
+
+// Verify recomputes all hashes and checks them against memoized ones and the expected root hash.
+// Errors of loading lazy nodes are returned
+func (r *Rope) Verify(expected Hash) error {
+	h, err := r.verify()
+	if err != nil {
+		return err
+	}
+	if h != expected {
+		return fmt.Errorf("%w: root %v, expected %v", ErrHashMismatch, h, expected)
+	}
+	return nil
+}
+
+func (r *Rope) verify() (h Hash, err error) {
+	if r == nil {
+		return
+	}
+	if err = r.load(); err != nil {
+		return
+	}
+	if len(r.content) > 0 { // leaf
+		h = leafHash(r.content)
+	} else { // non leaf
+		var left, right Hash
+		if left, err = r.left.verify(); err != nil {
+			return
+		}
+		if right, err = r.right.verify(); err != nil {
+			return
+		}
+		h = nodeHash(left, right)
+	}
+	if memo := r.hash.Load(); memo != nil && *memo != h {
+		err = fmt.Errorf("%w: node %v, memoized %v", ErrHashMismatch, h, *memo)
+	}
+	return
+}
@@ -0,0 +1,160 @@
+package rope
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	var r *Rope
+	if r.Hash() != (Hash{}) {
+		t.Fatal()
+	}
+
+	bs := getRandomBytes(1024)
+	r1 := NewFromBytes(bs)
+	r2 := NewFromBytes(bs)
+	if r1.Hash() != r2.Hash() {
+		t.Fatal()
+	}
+	r3 := r1.Insert(512, []byte("x"))
+	if r1.Hash() == r3.Hash() {
+		t.Fatal()
+	}
+	if r3.Delete(512, 1).Hash() == r3.Hash() {
+		t.Fatal()
+	}
+
+	leaf := NewFromBytes([]byte("foo"))
+	if leaf.Hash() != leafHash([]byte("foo")) {
+		t.Fatal()
+	}
+	node := NewFromBytes([]byte("foobarbaz"))
+	if node.Hash() != nodeHash(leafHash([]byte("foobarba")), leafHash([]byte("z"))) {
+		t.Fatal()
+	}
+}
+
+func TestMerkleKey(t *testing.T) {
+	Merkle = true
+	defer func() {
+		Merkle = false
+	}()
+	bs := getRandomBytes(1024)
+	r1 := NewFromBytes(bs)
+	r2 := NewFromBytes(bs)
+	if r1 != r2 {
+		t.Fatal()
+	}
+	if !bytes.Equal(r1.Bytes(), bs) {
+		t.Fatal()
+	}
+	if NewFromBytes([]byte("foo")) == NewFromBytes([]byte("bar")) {
+		t.Fatal()
+	}
+}
+
+func TestEqual(t *testing.T) {
+	var r *Rope
+	if !r.Equal(nil) {
+		t.Fatal()
+	}
+	if !r.Equal(NewFromBytes(nil)) {
+		t.Fatal()
+	}
+
+	bs := getRandomBytes(1024)
+	r1 := NewFromBytes(bs)
+	// different structure, same content
+	r2 := NewFromBytes(nil)
+	for i := 0; i < len(bs); i += 3 {
+		end := i + 3
+		if end > len(bs) {
+			end = len(bs)
+		}
+		r2 = r2.Concat(NewFromBytes(bs[i:end]))
+	}
+	if r1.Hash() == r2.Hash() {
+		t.Fatal()
+	}
+	if !r1.Equal(r2) || !r2.Equal(r1) {
+		t.Fatal()
+	}
+
+	r3 := r1.Delete(100, 1).Insert(100, []byte{bs[100] + 1})
+	if r1.Equal(r3) {
+		t.Fatal()
+	}
+	if r1.Equal(r1.Delete(0, 1)) {
+		t.Fatal()
+	}
+}
+
+func TestMissing(t *testing.T) {
+	bs := getRandomBytes(4096)
+	r1 := NewFromBytes(bs)
+	r2 := r1.Insert(2048, []byte("foobar"))
+
+	// peer holds every node of r1
+	peer := make(map[Hash]bool)
+	r1.iterNodes(func(node *Rope) bool {
+		peer[node.Hash()] = true
+		return true
+	})
+	has := func(h Hash) bool {
+		return peer[h]
+	}
+
+	n := 0
+	buf := new(bytes.Buffer)
+	r2.Missing(has, func(node *Rope) bool {
+		n++
+		buf.Write(node.content)
+		return true
+	})
+	if n == 0 {
+		t.Fatal()
+	}
+	total := 0
+	r2.iterNodes(func(*Rope) bool {
+		total++
+		return true
+	})
+	if n >= total/2 {
+		t.Fatal()
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("foobar")) {
+		t.Fatal()
+	}
+
+	n = 0
+	r1.Missing(has, func(*Rope) bool {
+		n++
+		return true
+	})
+	if n != 0 {
+		t.Fatal()
+	}
+}
+
+func TestVerify(t *testing.T) {
+	r := NewFromBytes(getRandomBytes(1024))
+	if err := r.Verify(r.Hash()); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Verify(Hash{}); !errors.Is(err, ErrHashMismatch) {
+		t.Fatal()
+	}
+
+	// tampered leaf
+	leaf := &Rope{
+		weight:  3,
+		content: []byte("foo"),
+	}
+	h := leaf.Hash()
+	leaf.content = []byte("bar")
+	if err := leaf.Verify(h); !errors.Is(err, ErrHashMismatch) {
+		t.Fatal()
+	}
+}
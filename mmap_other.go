@@ -0,0 +1,10 @@
+//go:build !unix
+
+package rope
+
+import "os"
+
+// mapFile reads leaves with ReadAt where mmap is not supported. The file is closed by its finalizer
+func mapFile(f *os.File, size int64) (viewReader, error) {
+	return readerAtViewReader(f), nil
+}
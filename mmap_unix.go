@@ -0,0 +1,37 @@
+//go:build unix
+
+package rope
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+	"syscall"
+)
+
+type mapping struct {
+	data []byte
+}
+
+// mapFile maps the file read only. Leaves are copied out of the mapping when loaded,
+// and the mapping is released when every view node is loaded or unreachable
+func mapFile(f *os.File, size int64) (viewReader, error) {
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	// mapping stays valid after closing the file
+	if err := f.Close(); err != nil {
+		syscall.Munmap(data)
+		return nil, err
+	}
+	m := &mapping{
+		data: data,
+	}
+	runtime.SetFinalizer(m, func(m *mapping) {
+		syscall.Munmap(m.data)
+	})
+	return func(off int64, n int) ([]byte, error) {
+		return bytes.Clone(m.data[off : off+int64(n)]), nil
+	}, nil
+}
@@ -0,0 +1,5 @@
+//go:build !ropedebug
+
+package rope
+
+const debug = false
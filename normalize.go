@@ -0,0 +1,335 @@
+package rope
+
+import (
+	"bytes"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:generate go run gen_normalize.go
+
+type NormalForm int
+
+const (
+	NFC NormalForm = iota
+	NFD
+)
+
+// textTransform maps text region by region.
+// Regions are split at offsets where safe returns true, and runes in different regions do not affect each other
+type textTransform struct {
+	// whether the rune begins a region
+	safe func(ru rune) bool
+	// whether ASCII bytes are mapped to themselves
+	keepsASCII func(bs []byte) bool
+	apply      func(dst, src []byte) []byte
+}
+
+// Normalize returns the rope in the normalization form, sharing subtrees already normalized.
+// Tables are generated by gen_normalize.go from the Unicode version in normalize_tables.go
+func (r *Rope) Normalize(form NormalForm) *Rope {
+	return r.transform(textTransform{
+		safe: isNormalBoundary,
+		keepsASCII: func([]byte) bool {
+			return true
+		},
+		apply: func(dst, src []byte) []byte {
+			return normalizeBytes(dst, src, form)
+		},
+	})
+}
+
+// ToUpper returns the rope with runes mapped by unicode.ToUpper, sharing unchanged subtrees
+func (r *Rope) ToUpper() *Rope {
+	return r.mapRunes(func(bs []byte) bool {
+		return bytes.IndexFunc(bs, func(ru rune) bool { return 'a' <= ru && ru <= 'z' }) < 0
+	}, func(dst []byte, ru rune) []byte {
+		return utf8.AppendRune(dst, unicode.ToUpper(ru))
+	})
+}
+
+// ToLower returns the rope with runes mapped by unicode.ToLower, sharing unchanged subtrees
+func (r *Rope) ToLower() *Rope {
+	return r.mapRunes(noUpperASCII, func(dst []byte, ru rune) []byte {
+		return utf8.AppendRune(dst, unicode.ToLower(ru))
+	})
+}
+
+// CaseFold returns the rope with runes case folded for caseless matching, sharing unchanged subtrees.
+// It is the simple case folding of CaseFolding.txt, except that ß and ẞ fold to "ss".
+// Turkic mappings are not applied, so ı and İ do not fold to i
+func (r *Rope) CaseFold() *Rope {
+	return r.mapRunes(noUpperASCII, appendFolded)
+}
+
+func noUpperASCII(bs []byte) bool {
+	return bytes.IndexFunc(bs, func(ru rune) bool { return 'A' <= ru && ru <= 'Z' }) < 0
+}
+
+func appendFolded(dst []byte, ru rune) []byte {
+	if ru == 'ß' || ru == 'ẞ' {
+		return append(dst, "ss"...)
+	}
+	if 'A' <= ru && ru <= 'Z' { // fast path for ASCII
+		return append(dst, byte(ru)+'a'-'A')
+	}
+	if f, ok := caseFolds[ru]; ok {
+		ru = f
+	}
+	return utf8.AppendRune(dst, ru)
+}
+
+// mapRunes transforms runes one by one. Invalid bytes are kept
+func (r *Rope) mapRunes(keepsASCII func([]byte) bool, fn func(dst []byte, ru rune) []byte) *Rope {
+	return r.transform(textTransform{
+		safe: func(rune) bool {
+			return true
+		},
+		keepsASCII: keepsASCII,
+		apply: func(dst, src []byte) []byte {
+			for len(src) > 0 {
+				ru, l := utf8.DecodeRune(src)
+				if ru == utf8.RuneError && l == 1 {
+					dst = append(dst, src[0])
+				} else {
+					dst = fn(dst, ru)
+				}
+				src = src[l:]
+			}
+			return dst
+		},
+	})
+}
+
+func (r *Rope) transform(t textTransform) *Rope {
+	return r.transformNode(r, 0, t).check()
+}
+
+// transformNode maps the region owned by each leaf, which starts at the first safe offset in it
+func (r *Rope) transformNode(root *Rope, offset int, t textTransform) *Rope {
+	if r == nil {
+		return nil
+	}
+	r.ensure()
+	if len(r.content) > 0 { // leaf
+		end := offset + len(r.content)
+		if isASCII(r.content) && t.keepsASCII(r.content) && root.isSafe(end, t) {
+			return r
+		}
+		start := root.nextSafe(offset, t)
+		if start >= end { // owned by the previous leaf
+			return nil
+		}
+		end2 := root.nextSafe(end, t)
+		out := t.apply(nil, root.Sub(start, end2-start))
+		if start == offset && end2 == end && bytes.Equal(out, r.content) {
+			return r
+		}
+		return NewFromBytes(out)
+	}
+	left := r.left.transformNode(root, offset, t)
+	right := r.right.transformNode(root, offset+r.weight, t)
+	if left == r.left && right == r.right {
+		return r
+	}
+	return left.join(right)
+}
+
+func isASCII(bs []byte) bool {
+	for _, b := range bs {
+		if b >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// isSafe returns whether a region may begin at offset
+func (r *Rope) isSafe(offset int, t textTransform) bool {
+	if offset <= 0 || offset >= r.Len() {
+		return true
+	}
+	if b := r.Index(offset); b < utf8.RuneSelf {
+		return t.safe(rune(b))
+	} else if !isRuneStart(b) {
+		return false
+	}
+	ret := true
+	r.iterRunes(offset, func(ru rune, _ int, _ int) bool {
+		ret = t.safe(ru)
+		return false
+	})
+	return ret
+}
+
+// nextSafe returns the first offset from offset where a region may begin, or the length
+func (r *Rope) nextSafe(offset int, t textTransform) int {
+	if offset <= 0 || offset >= r.Len() {
+		return offset
+	}
+	for offset < r.Len() && !isRuneStart(r.Index(offset)) {
+		offset++
+	}
+	ret := r.Len()
+	r.iterRunes(offset, func(ru rune, pos int, size int) bool {
+		if t.safe(ru) && (ru != utf8.RuneError || size > 1 || isRuneStart(r.Index(pos))) {
+			ret = pos
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// normalizeBytes appends src in the normalization form to dst. Invalid bytes are kept
+func normalizeBytes(dst, src []byte, form NormalForm) []byte {
+	var runes []rune
+	flush := func() {
+		canonicalOrder(runes)
+		if form == NFC {
+			runes = compose(runes)
+		}
+		for _, ru := range runes {
+			dst = utf8.AppendRune(dst, ru)
+		}
+		runes = runes[:0]
+	}
+	for len(src) > 0 {
+		ru, l := utf8.DecodeRune(src)
+		if ru == utf8.RuneError && l == 1 {
+			flush()
+			dst = append(dst, src[0])
+		} else {
+			runes = decompose(runes, ru)
+		}
+		src = src[l:]
+	}
+	flush()
+	return dst
+}
+
+const (
+	hangulS     = 0xac00
+	hangulL     = 0x1100
+	hangulV     = 0x1161
+	hangulT     = 0x11a7
+	hangulLs    = 19
+	hangulVs    = 21
+	hangulTs    = 28
+	hangulCount = hangulLs * hangulVs * hangulTs
+)
+
+func isHangulV(ru rune) bool {
+	return hangulV <= ru && ru < hangulV+hangulVs
+}
+
+func isHangulT(ru rune) bool {
+	return hangulT < ru && ru < hangulT+hangulTs
+}
+
+// isNormalBoundary returns whether normalization of runes before ru does not depend on ru
+func isNormalBoundary(ru rune) bool {
+	if d, ok := decompositions[ru]; ok {
+		ru, _ = utf8.DecodeRuneInString(d)
+	}
+	return combiningClass(ru) == 0 && !composesWithPrevious[ru] && !isHangulV(ru) && !isHangulT(ru)
+}
+
+// decompose appends the full canonical decomposition of ru
+func decompose(dst []rune, ru rune) []rune {
+	if s := ru - hangulS; 0 <= s && s < hangulCount {
+		dst = append(dst, hangulL+s/(hangulVs*hangulTs), hangulV+s%(hangulVs*hangulTs)/hangulTs)
+		if t := s % hangulTs; t > 0 {
+			dst = append(dst, hangulT+t)
+		}
+		return dst
+	}
+	d, ok := decompositions[ru]
+	if !ok {
+		return append(dst, ru)
+	}
+	for _, ru := range d {
+		dst = append(dst, ru)
+	}
+	return dst
+}
+
+// canonicalOrder sorts each sequence of non-starters by combining classes
+func canonicalOrder(runes []rune) {
+	for i := 0; i < len(runes); {
+		if combiningClass(runes[i]) == 0 {
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(runes) && combiningClass(runes[j]) != 0 {
+			j++
+		}
+		marks := runes[i:j]
+		sort.SliceStable(marks, func(a, b int) bool {
+			return combiningClass(marks[a]) < combiningClass(marks[b])
+		})
+		i = j
+	}
+}
+
+// compose composes canonically ordered runes in place
+func compose(runes []rune) []rune {
+	out := runes[:0]
+	starter := -1
+	lastClass := -1 // of the last rune after the starter, -1 if none
+	for _, ru := range runes {
+		class := int(combiningClass(ru))
+		if starter >= 0 && (lastClass < 0 || lastClass != 0 && lastClass < class) {
+			if c, ok := composePair(out[starter], ru); ok {
+				out[starter] = c
+				continue
+			}
+		}
+		if class == 0 {
+			starter = len(out)
+			lastClass = -1
+		} else {
+			lastClass = class
+		}
+		out = append(out, ru)
+	}
+	return out
+}
+
+func composePair(a, b rune) (rune, bool) {
+	if l := a - hangulL; 0 <= l && l < hangulLs && isHangulV(b) {
+		return hangulS + (l*hangulVs+b-hangulV)*hangulTs, true
+	}
+	if s := a - hangulS; 0 <= s && s < hangulCount && s%hangulTs == 0 && isHangulT(b) {
+		return a + b - hangulT, true
+	}
+	c, ok := compositions[[2]rune{a, b}]
+	return c, ok
+}
+
+// combiningClass returns the canonical combining class of the rune
+func combiningClass(ru rune) uint8 {
+	if ru < 0x300 {
+		return 0
+	}
+	i := sort.Search(len(combiningClasses), func(i int) bool {
+		return ru <= combiningClasses[i].last
+	})
+	if i < len(combiningClasses) && combiningClasses[i].first <= ru {
+		return combiningClasses[i].class
+	}
+	return 0
+}
+
+// composesWithPrevious are starters composing with the previous rune, except Hangul vowels and trailing consonants
+var composesWithPrevious = func() map[rune]bool {
+	ret := make(map[rune]bool)
+	for pair := range compositions {
+		if combiningClass(pair[1]) == 0 {
+			ret[pair[1]] = true
+		}
+	}
+	return ret
+}()
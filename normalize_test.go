@@ -0,0 +1,216 @@
+package rope
+
+import (
+	mrand "math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNormalize(t *testing.T) {
+	for _, c := range []struct {
+		text, nfc, nfd string
+	}{
+		{"", "", ""},
+		{"abc", "abc", "abc"},
+		{"\u00e9", "\u00e9", "e\u0301"},
+		{"e\u0301", "\u00e9", "e\u0301"},
+		{"\u0178\u0130", "\u0178\u0130", "Y\u0308I\u0307"},
+		{"a\u0301\u0327", "\u00e1\u0327", "a\u0327\u0301"}, // reordered
+		{"c\u0327", "\u00e7", "c\u0327"},
+		{"o\u0331\u0308", "\u00f6\u0331", "o\u0331\u0308"},  // not blocked by a lower class
+		{"e\u034f\u0301", "e\u034f\u0301", "e\u034f\u0301"}, // blocked
+		{"\ud55c\uae00", "\ud55c\uae00", "\u1112\u1161\u11ab\u1100\u1173\u11af"},
+		{"\u1112\u1161\u11ab", "\ud55c", "\u1112\u1161\u11ab"},
+		{"a\xffe\u0301", "a\xff\u00e9", "a\xffe\u0301"},
+	} {
+		r := NewFromBytes([]byte(c.text))
+		if got := string(r.Normalize(NFC).Bytes()); got != c.nfc {
+			t.Fatalf("%q: got %q", c.text, got)
+		}
+		if got := string(r.Normalize(NFD).Bytes()); got != c.nfd {
+			t.Fatalf("%q: got %q", c.text, got)
+		}
+	}
+}
+
+// TestNormalizationVectors checks the invariants of NormalizationTest.txt for NFC and NFD
+func TestNormalizationVectors(t *testing.T) {
+	content, err := os.ReadFile("testdata/normalization.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	normalize := func(s string, form NormalForm) string {
+		return string(NewFromBytes([]byte(s)).Normalize(form).Bytes())
+	}
+	listed := make(map[rune]bool)
+	part := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "@") {
+			part = line
+			continue
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) < 5 {
+			t.Fatalf("bad line: %q", line)
+		}
+		var c [5]string
+		for i := range c {
+			for _, hex := range strings.Fields(fields[i]) {
+				ru, err := strconv.ParseUint(hex, 16, 32)
+				if err != nil {
+					t.Fatal(err)
+				}
+				c[i] += string(rune(ru))
+			}
+		}
+		if part == "@Part1" {
+			ru, _ := utf8.DecodeRuneInString(c[0])
+			listed[ru] = true
+		}
+		for i, s := range c {
+			nfc, nfd := c[1], c[2]
+			if i >= 3 {
+				nfc, nfd = c[3], c[4]
+			}
+			if got := normalize(s, NFC); got != nfc {
+				t.Fatalf("NFC %+q: got %+q, expected %+q", s, got, nfc)
+			}
+			if got := normalize(s, NFD); got != nfd {
+				t.Fatalf("NFD %+q: got %+q, expected %+q", s, got, nfd)
+			}
+		}
+	}
+	if len(listed) == 0 {
+		t.Fatal("no Part1")
+	}
+	for ru := rune(0); ru <= utf8.MaxRune; ru++ {
+		if !utf8.ValidRune(ru) || listed[ru] {
+			continue
+		}
+		bs := utf8.AppendRune(nil, ru)
+		nfd := normalizeBytes(nil, bs, NFD)
+		if hangulS <= ru && ru < hangulS+hangulCount {
+			if n := utf8.RuneCount(nfd); n < 2 || n > 3 || string(normalizeBytes(nil, nfd, NFC)) != string(bs) {
+				t.Fatalf("%U", ru)
+			}
+			continue
+		}
+		if string(nfd) != string(bs) || string(normalizeBytes(nil, bs, NFC)) != string(bs) {
+			t.Fatalf("%U not listed but changed", ru)
+		}
+	}
+}
+
+func TestCaseFoldingVectors(t *testing.T) {
+	content, err := os.ReadFile("testdata/CaseFolding.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listed := make(map[rune]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Split(line, ";")
+		if strings.HasPrefix(line, "#") || len(fields) < 3 {
+			continue
+		}
+		if status := strings.TrimSpace(fields[1]); status != "C" && status != "S" {
+			continue
+		}
+		var runes [2]rune
+		for i := range runes {
+			ru, err := strconv.ParseUint(strings.TrimSpace(fields[i*2]), 16, 32)
+			if err != nil {
+				t.Fatal(err)
+			}
+			runes[i] = rune(ru)
+		}
+		listed[runes[0]] = true
+		expected := string(runes[1])
+		if runes[0] == '\u1e9e' {
+			expected = "ss"
+		}
+		if got := string(NewFromBytes([]byte(string(runes[0]))).CaseFold().Bytes()); got != expected {
+			t.Fatalf("%U: got %+q, expected %+q", runes[0], got, expected)
+		}
+	}
+	if len(listed) == 0 {
+		t.Fatal("no mappings")
+	}
+	for ru := rune(0); ru <= utf8.MaxRune; ru++ {
+		if !utf8.ValidRune(ru) || listed[ru] || ru == '\u00df' {
+			continue
+		}
+		if got := appendFolded(nil, ru); string(got) != string(ru) {
+			t.Fatalf("%U not listed but folded to %+q", ru, got)
+		}
+	}
+}
+
+func TestTransformAcrossLeaves(t *testing.T) {
+	pieces := []string{"a", "B", "e", "\u00e9", "\u00c9", "\u0301", "\u0327", "\u00df", "\u1e9e", "\ud55c", "\u1100", "\u1161", "\u11ab", "\xff", "\u03a3\u0391\u03c2"}
+	fold := func(s string) string {
+		var ret []byte
+		for _, ru := range s {
+			ret = appendFolded(ret, ru)
+		}
+		return string(ret)
+	}
+	for i := 0; i < 100; i++ {
+		var text string
+		var r *Rope
+		for j, n := 0, mrand.Intn(40); j < n; j++ {
+			piece := pieces[mrand.Intn(len(pieces))]
+			pos := mrand.Intn(len(text) + 1)
+			for pos < len(text) && !utf8.RuneStart(text[pos]) {
+				pos++
+			}
+			text = text[:pos] + piece + text[pos:]
+			r = r.Insert(pos, []byte(piece))
+		}
+		for _, form := range []NormalForm{NFC, NFD} {
+			if got, expected := string(r.Normalize(form).Bytes()), string(normalizeBytes(nil, []byte(text), form)); got != expected {
+				t.Fatalf("%q %v: got %q, expected %q", text, form, got, expected)
+			}
+		}
+		if string(r.Normalize(NFD).Normalize(NFC).Bytes()) != string(r.Normalize(NFC).Bytes()) {
+			t.Fatalf("%q", text)
+		}
+		if utf8.ValidString(text) {
+			if string(r.ToUpper().Bytes()) != strings.ToUpper(text) ||
+				string(r.ToLower().Bytes()) != strings.ToLower(text) ||
+				string(r.CaseFold().Bytes()) != fold(text) {
+				t.Fatalf("%q", text)
+			}
+		}
+	}
+}
+
+func TestTransformSharing(t *testing.T) {
+	r := NewFromBytes([]byte(strings.Repeat("ABCDEFGH", 32) + "\u00c9"))
+	if r.Normalize(NFC) != r || r.ToUpper() != r {
+		t.Fatal()
+	}
+	leaves := leafSet(r, map[*Rope]bool{})
+	shared := 0
+	lower := r.ToLower()
+	for leaf := range leafSet(r.Normalize(NFD), map[*Rope]bool{}) {
+		if leaves[leaf] {
+			shared++
+		}
+	}
+	if shared < len(leaves)-2 {
+		t.Fatalf("%d of %d leaves shared", shared, len(leaves))
+	}
+	if lower.CaseFold() != lower || string(lower.Bytes()) != strings.ToLower(string(r.Bytes())) {
+		t.Fatal()
+	}
+}
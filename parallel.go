@@ -0,0 +1,133 @@
+package rope
+
+import (
+	"io"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// NewFromBytesParallel returns a rope of copied bs, built by workers goroutines.
+// The structure is the same as NewFromBytes. workers <= 0 means GOMAXPROCS.
+// Leaf boundaries of content defined chunkers are decided sequentially
+func NewFromBytesParallel(bs []byte, workers int) *Rope {
+	return newParallel(bs, workers, false)
+}
+
+// NewFromReaderAtParallel reads size bytes of ra by workers goroutines, and returns a rope built in parallel.
+// The structure is the same as NewFromReader. workers <= 0 means GOMAXPROCS.
+// Unlike NewFromReaderAt, all bytes are read before returning, and leaves share one buffer not exposed to callers
+func NewFromReaderAtParallel(ra io.ReaderAt, size int64, workers int) (*Rope, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	buf := make([]byte, size)
+	part := (len(buf) + workers - 1) / workers
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	for i := 0; i < workers; i++ {
+		start := i * part
+		end := min(start+part, len(buf))
+		if start >= end {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			n, err := ra.ReadAt(buf[start:end], int64(start))
+			if n == end-start {
+				return
+			}
+			if err == nil || err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newParallel(buf, workers, true), nil
+}
+
+type parallelBuilder struct {
+	bs     []byte
+	bounds []int // leaf i is bs[bounds[i]:bounds[i+1]]
+	owned  bool  // bs is not written or exposed after building
+	sem    chan struct{}
+}
+
+// leaves of subtrees built in new goroutines
+const parallelGrain = 64
+
+func newParallel(bs []byte, workers int, owned bool) (ret *Rope) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	b := &parallelBuilder{
+		bs:    bs,
+		owned: owned,
+		sem:   make(chan struct{}, workers-1),
+	}
+	// same boundaries as NewFromReader
+	for off := 0; off < len(bs); {
+		b.bounds = append(b.bounds, off)
+		l := LeafChunker.Cut(bs[off:])
+		if l == 0 { // last leaf
+			l = len(bs) - off
+		}
+		off += l
+	}
+	b.bounds = append(b.bounds, len(bs))
+
+	// perfect trees of 2^i leaves, by bits of the leaf count, as the slots of NewFromReader
+	var trees []*Rope
+	n := len(b.bounds) - 1
+	start := 0
+	for bit := 63; bit >= 0; bit-- {
+		if size := 1 << bit; n&size != 0 {
+			trees = append(trees, b.perfect(start, size))
+			start += size
+		}
+	}
+	for i := len(trees) - 1; i >= 0; i-- {
+		ret = trees[i].concat(ret)
+	}
+	return
+}
+
+func (b *parallelBuilder) leaf(i int) *Rope {
+	content := b.bs[b.bounds[i]:b.bounds[i+1]]
+	if b.owned {
+		return newLeafString(unsafe.String(unsafe.SliceData(content), len(content)))
+	}
+	return newLeaf(content)
+}
+
+// perfect returns the perfect tree of size leaves from leaf i
+func (b *parallelBuilder) perfect(i, size int) *Rope {
+	if size == 1 {
+		return b.leaf(i)
+	}
+	half := size / 2
+	if size >= parallelGrain {
+		select {
+		case b.sem <- struct{}{}:
+			var left *Rope
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				left = b.perfect(i, half)
+				<-b.sem
+			}()
+			right := b.perfect(i+half, half)
+			<-done
+			return newNode(left, right)
+		default:
+		}
+	}
+	return newNode(b.perfect(i, half), b.perfect(i+half, half))
+}
@@ -0,0 +1,49 @@
+package rope
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestNewFromBytesParallel(t *testing.T) {
+	for _, chunker := range []Chunker{FixedChunker, GearChunker(2, 4, 8)} {
+		LeafChunker = chunker
+		for _, n := range []int{0, 1, 7, 8, 9, 100, 1000, 8 * 64 * 5, 10000} {
+			bs := getRandomBytes(n)
+			expected := NewFromBytes(bs)
+			for _, workers := range []int{0, 1, 2, 3, 8} {
+				r := NewFromBytesParallel(bs, workers)
+				if !r.StructEqual(expected) {
+					t.Fatalf("%d %d", n, workers)
+				}
+				if err := r.Validate(); err != nil {
+					t.Fatal(err)
+				}
+				r2, err := NewFromReaderAtParallel(bytes.NewReader(bs), int64(n), workers)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !r2.StructEqual(expected) {
+					t.Fatalf("%d %d", n, workers)
+				}
+			}
+			// copied
+			if n > 0 {
+				r := NewFromBytesParallel(bs, 4)
+				bs[0]++
+				if r.Index(0) == bs[0] {
+					t.Fatal()
+				}
+			}
+		}
+	}
+	LeafChunker = FixedChunker
+}
+
+func TestNewFromReaderAtParallelError(t *testing.T) {
+	if _, err := NewFromReaderAtParallel(bytes.NewReader([]byte("foo")), 100, 4); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatal()
+	}
+}
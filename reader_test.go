@@ -43,8 +43,7 @@ func TestRuneReader(t *testing.T) {
 		{'体', 3},
 	}
 	if len(res) != len(expected) {
-		p("%v\n%v\n", res, expected)
-		t.Fatal()
+		t.Fatalf("%v\n%v", res, expected)
 	}
 	for i, o := range res {
 		if o.r != expected[i].r || o.n != expected[i].n {
@@ -3,11 +3,13 @@ package rope
 import (
 	"bytes"
 	"io"
-	"math"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"unicode/utf8"
+	"unsafe"
+
+	"github.com/reusee/rope/internal/avl"
 )
 
 // Key -> *Rope
@@ -18,96 +20,59 @@ type Key struct {
 	left    *Rope
 	right   *Rope
 	content string
+	hash    Hash
 }
 
+// Rope is a persistent rope in AVL balanced binary tree layout.
+// Leaves hold bytes, internal nodes concatenate their children.
+// For every internal node, heights of its children differ by at most one, so the height is O(log n).
+// Concat joins trees of different heights at the spine of the higher one, rotating on the way up,
+// in O(|h1 - h2|) time, and Split is a sequence of joins in O(log n) time
 type Rope struct {
-	left     *Rope
-	right    *Rope
-	content  []byte
-	serial   int64
-	height   int
-	weight   int
-	balanced bool
+	left    *Rope
+	right   *Rope
+	content []byte
+	serial  int64
+	height  int
+	weight  int
+	hash    atomic.Pointer[Hash]
+	lazy    *lazyNode
+	widths  atomic.Pointer[widthMetrics] // memoized if CacheWidths
+	lines   atomic.Pointer[lineMetrics]
 }
 
 var nextSerial int64
 
-var MaxLengthPerNode = 128
-
-func NewFromReader(r io.Reader) (ret *Rope, err error) {
-	slots := make([]*Rope, 32)
+var MaxLengthPerNode = avl.MaxLeafLength
 
+func NewFromReader(r io.Reader) (*Rope, error) {
+	var b builder
+	buf := make([]byte, MaxLengthPerNode)
+	var pending []byte
+	eof := false
 	for {
-		buf := make([]byte, MaxLengthPerNode)
-		l, err := r.Read(buf)
+		l := LeafChunker.Cut(pending)
 		if l == 0 {
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		key := Key{
-			content: string(buf[:l]),
-		}
-		var rope *Rope
-		if v, ok := cache.Load(key); ok {
-			rope = v.(*Rope)
-		} else {
-			rope = &Rope{
-				content:  buf[:l],
-				serial:   atomic.AddInt64(&nextSerial, 1),
-				height:   1,
-				weight:   l,
-				balanced: l == len(buf),
-			}
-			cache.Store(key, rope)
-		}
-
-		slotIndex := 0
-		for slots[slotIndex] != nil {
-			key := Key{
-				left:  slots[slotIndex],
-				right: rope,
-			}
-			if v, ok := cache.Load(key); ok {
-				rope = v.(*Rope)
-			} else {
-				rope = &Rope{
-					left:     slots[slotIndex],
-					right:    rope,
-					serial:   atomic.AddInt64(&nextSerial, 1),
-					height:   slotIndex + 2,
-					weight:   slots[slotIndex].Len(),
-					balanced: true,
+			if !eof { // read more
+				n, err := r.Read(buf)
+				pending = append(pending, buf[:n]...)
+				if err == io.EOF {
+					eof = true
+				} else if err != nil {
+					return nil, err
 				}
-				cache.Store(key, rope)
+				continue
 			}
-			slots[slotIndex] = nil
-			slotIndex++
-		}
-		slots[slotIndex] = rope
-
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	for _, c := range slots {
-		if c != nil {
-			if ret == nil {
-				ret = c
-			} else {
-				ret = c.Concat(ret)
+			// last leaf
+			l = len(pending)
+			if l == 0 {
+				break
 			}
 		}
+		b.add(newLeaf(pending[:l]))
+		pending = pending[l:]
 	}
-	return
+	return b.rope(), nil
 }
 
 func NewFromString(s string) *Rope {
@@ -126,7 +91,65 @@ func NewFromBytes(bs []byte) *Rope {
 	return r
 }
 
+// NewFromBytesNoCopy returns a rope with leaves aliasing bs, without copying.
+// bs is owned by the rope afterwards and must not be modified.
+// The leaves are not hash consed, so they are referenced only by the returned rope and its edits,
+// and leaves derived from them by edits are copied.
+// Ropes never write to leaves
+func NewFromBytesNoCopy(bs []byte) *Rope {
+	var b builder
+	for len(bs) > 0 {
+		l := LeafChunker.Cut(bs)
+		if l == 0 { // last leaf
+			l = len(bs)
+		}
+		b.add(&Rope{
+			content: bs[:l:l],
+			serial:  atomic.AddInt64(&nextSerial, 1),
+			height:  1,
+			weight:  l,
+		})
+		bs = bs[l:]
+	}
+	return b.rope()
+}
+
+// NewFromStringNoCopy returns a rope with leaves aliasing the bytes of s, without copying
+func NewFromStringNoCopy(s string) *Rope {
+	return NewFromBytesNoCopy(unsafe.Slice(unsafe.StringData(s), len(s)))
+}
+
+// builder builds a rope from leaves in order
+type builder struct {
+	// slots hold perfect trees of 2^i leaves
+	slots [64]*Rope
+}
+
+func (b *builder) add(rope *Rope) {
+	slotIndex := 0
+	for b.slots[slotIndex] != nil {
+		rope = newNode(b.slots[slotIndex], rope)
+		b.slots[slotIndex] = nil
+		slotIndex++
+	}
+	b.slots[slotIndex] = rope
+}
+
+func (b *builder) rope() (ret *Rope) {
+	for _, c := range b.slots {
+		if c != nil {
+			if ret == nil {
+				ret = c
+			} else {
+				ret = c.concat(ret)
+			}
+		}
+	}
+	return
+}
+
 func (r *Rope) Index(i int) byte {
+	r.ensure()
 	if i >= r.weight {
 		return r.right.Index(i - r.weight)
 	}
@@ -141,6 +164,7 @@ func (r *Rope) Len() int {
 	if r == nil {
 		return 0
 	}
+	r.ensure()
 	return r.weight + r.right.Len()
 }
 
@@ -155,150 +179,182 @@ func (r *Rope) Bytes() []byte {
 	return ret
 }
 
-func (r *Rope) Concat(r2 *Rope) (ret *Rope) {
-	key := Key{
-		left:  r,
-		right: r2,
+// newLeaf returns the hash consed leaf of content, copied if not cached
+func newLeaf(content []byte) *Rope {
+	// aliasing for the lookup only
+	key := leafKey(unsafe.String(unsafe.SliceData(content), len(content)))
+	if v, ok := cache.Load(key); ok {
+		return v.(*Rope)
+	}
+	s := string(content)
+	if !Merkle {
+		key.content = s
 	}
+	return storeLeaf(key, s)
+}
+
+// newLeafString returns the hash consed leaf of s, aliasing s if not cached
+func newLeafString(s string) *Rope {
+	key := leafKey(s)
 	if v, ok := cache.Load(key); ok {
 		return v.(*Rope)
 	}
-	ret = &Rope{
-		left:   r,
-		right:  r2,
-		serial: atomic.AddInt64(&nextSerial, 1),
-		weight: r.Len(),
+	return storeLeaf(key, s)
+}
+
+// storeLeaf caches the leaf of s. The content aliases s, so the key and the content share memory owned by the leaf
+func storeLeaf(key Key, s string) *Rope {
+	ret := &Rope{
+		content: unsafe.Slice(unsafe.StringData(s), len(s)),
+		serial:  atomic.AddInt64(&nextSerial, 1),
+		height:  1,
+		weight:  len(s),
 	}
-	if ret.left != nil {
-		ret.height = ret.left.height
+	v, _ := cache.LoadOrStore(key, ret)
+	return v.(*Rope)
+}
+
+// newNode returns the hash consed node of left and right, which must be non nil and balanced
+func newNode(left, right *Rope) *Rope {
+	key := Key{
+		left:  left,
+		right: right,
 	}
-	if ret.right != nil && ret.right.height > ret.height {
-		ret.height = ret.right.height
+	if v, ok := cache.Load(key); ok {
+		return v.(*Rope)
 	}
-	if ret.left != nil && ret.left.balanced &&
-		ret.right != nil && ret.right.balanced &&
-		ret.left.height == ret.right.height {
-		ret.balanced = true
+	ret := &Rope{
+		left:   left,
+		right:  right,
+		serial: atomic.AddInt64(&nextSerial, 1),
+		height: left.height + 1,
+		weight: left.Len(),
 	}
-	ret.height++
-	// check and rebalance
-	if !ret.balanced {
-		l := int((math.Ceil(math.Log2(float64((ret.Len()/MaxLengthPerNode)+1))) + 1) * 1.5)
-		if ret.height > l {
-			ret = ret.rebalance()
-		}
+	if right.height >= left.height {
+		ret.height = right.height + 1
 	}
 	cache.Store(key, ret)
-	return
+	return ret
 }
 
-func (r *Rope) rebalance() (ret *Rope) {
-	var currentBytes []byte
-	slots := make([]*Rope, 64)
-	r.iterNodes(func(node *Rope) bool {
-		var balancedNode *Rope
-		iterSubNodes := true
-		if len(currentBytes) == 0 && node.balanced { // balanced, insert to slots
-			balancedNode = node
-			iterSubNodes = false
-		} else { // collect bytes
-			currentBytes = append(currentBytes, node.content...)
-			if len(currentBytes) >= MaxLengthPerNode { // a full leaf
-				key := Key{
-					content: string(currentBytes[:MaxLengthPerNode]),
-				}
-				if v, ok := cache.Load(key); ok {
-					balancedNode = v.(*Rope)
-				} else {
-					balancedNode = &Rope{
-						content:  currentBytes[:MaxLengthPerNode],
-						serial:   atomic.AddInt64(&nextSerial, 1),
-						height:   1,
-						weight:   MaxLengthPerNode,
-						balanced: true,
-					}
-					cache.Store(key, balancedNode)
-				}
-				currentBytes = currentBytes[MaxLengthPerNode:]
-			}
-		}
-		if balancedNode != nil {
-			slotIndex := balancedNode.height - 1
-			for slots[slotIndex] != nil {
-				key := Key{
-					left:  slots[slotIndex],
-					right: balancedNode,
-				}
-				if v, ok := cache.Load(key); ok {
-					balancedNode = v.(*Rope)
-				} else {
-					balancedNode = &Rope{
-						left:     slots[slotIndex],
-						right:    balancedNode,
-						serial:   atomic.AddInt64(&nextSerial, 1),
-						height:   balancedNode.height + 1,
-						weight:   slots[slotIndex].Len(),
-						balanced: true,
-					}
-					cache.Store(key, balancedNode)
-				}
-				slots[slotIndex] = nil
-				slotIndex++
-			}
-			slots[slotIndex] = balancedNode
-		}
-		return iterSubNodes
-	})
-	if len(currentBytes) > 0 {
-		key := Key{
-			content: string(currentBytes),
-		}
-		if v, ok := cache.Load(key); ok {
-			ret = v.(*Rope)
-		} else {
-			ret = &Rope{
-				content:  currentBytes,
-				serial:   atomic.AddInt64(&nextSerial, 1),
-				height:   1,
-				weight:   len(currentBytes),
-				balanced: false,
-			}
-			cache.Store(key, ret)
-		}
+// MergeLeaves makes Concat and Split merge under-full leaves at the joint,
+// keeping leaves at least half of MaxLengthPerNode, except when the tree has only one leaf
+var MergeLeaves = true
+
+func (r *Rope) Concat(r2 *Rope) *Rope {
+	return r.join(r2).check()
+}
+
+// join concats r and r2, merging leaves at the joint if any of them is under-full
+func (r *Rope) join(r2 *Rope) *Rope {
+	if r == nil || r2 == nil || !MergeLeaves {
+		return r.concat(r2)
 	}
-	for _, c := range slots {
-		if c != nil {
-			if ret == nil {
-				ret = c
-			} else {
-				ret = c.Concat(ret)
-			}
-		}
+	last, first := r.lastLeaf(), r2.firstLeaf()
+	if !last.underFull() && !first.underFull() {
+		return r.concat(r2)
 	}
-	return
+	r, _ = r.split(r.Len() - last.weight)
+	_, r2 = r2.split(first.weight)
+	return r.concat(mergeLeaves(last.content, first.content)).concat(r2)
 }
 
-func (r *Rope) Split(n int) (out1, out2 *Rope) {
+func (r *Rope) underFull() bool {
+	return len(r.content) < MaxLengthPerNode/2
+}
+
+func (r *Rope) firstLeaf() *Rope {
 	if r == nil {
-		return
+		return nil
 	}
-	if len(r.content) > 0 { // leaf
-		if n > len(r.content) { // offset overflow
-			n = len(r.content)
+	r.ensure()
+	for r.left != nil {
+		r = r.left
+		r.ensure()
+	}
+	return r
+}
+
+func (r *Rope) lastLeaf() *Rope {
+	if r == nil {
+		return nil
+	}
+	r.ensure()
+	for r.right != nil {
+		r = r.right
+		r.ensure()
+	}
+	return r
+}
+
+// mergeLeaves returns a leaf of a and b, or two leaves of halves if too long
+func mergeLeaves(a, b []byte) *Rope {
+	s := string(a) + string(b)
+	if len(s) <= MaxLengthPerNode {
+		return newLeafString(s)
+	}
+	l := len(s) / 2
+	return newNode(newLeafString(s[:l]), newLeafString(s[l:]))
+}
+
+func (r *Rope) concat(r2 *Rope) *Rope {
+	return avl.Concat(ropeTree{}, r, r2)
+}
+
+// ropeTree balances ropes by package avl, loading lazy nodes on the way
+type ropeTree struct{}
+
+func (ropeTree) Height(r *Rope) int {
+	return r.height
+}
+
+func (ropeTree) Children(r *Rope) (*Rope, *Rope) {
+	r.ensure()
+	return r.left, r.right
+}
+
+func (ropeTree) Node(left, right *Rope) *Rope {
+	return newNode(left, right).checkNode()
+}
+
+func (ropeTree) Cut(r *Rope, n int) (*Rope, *Rope, bool) {
+	if n <= 0 {
+		return nil, r, true
+	}
+	if n >= r.Len() {
+		return r, nil, true
+	}
+	if len(r.content) > 0 { // leaf, halves are copied as the content may be externally backed
+		return newLeaf(r.content[:n]), newLeaf(r.content[n:]), true
+	}
+	return nil, nil, false
+}
+
+func (ropeTree) Descend(r, _, _ *Rope, n int) (bool, int) {
+	if n >= r.weight { // at right subtree
+		return true, n - r.weight
+	}
+	return false, n
+}
+
+func (r *Rope) Split(n int) (out1, out2 *Rope) {
+	out1, out2 = r.split(n)
+	if MergeLeaves {
+		// the split leaf may leave under-full pieces at the ends
+		if last := out1.lastLeaf(); out1 != last && last.underFull() {
+			r1, r2 := out1.split(out1.Len() - last.weight)
+			out1 = r1.join(r2)
 		}
-		out1 = NewFromBytes(r.content[:n])
-		out2 = NewFromBytes(r.content[n:])
-	} else { // non leaf
-		var r1 *Rope
-		if n >= r.weight { // at right subtree
-			r1, out2 = r.right.Split(n - r.weight)
-			out1 = r.left.Concat(r1)
-		} else { // at left subtree
-			out1, r1 = r.left.Split(n)
-			out2 = r1.Concat(r.right)
+		if first := out2.firstLeaf(); out2 != first && first.underFull() {
+			r1, r2 := out2.split(first.weight)
+			out2 = r1.join(r2)
 		}
 	}
-	return
+	return out1.check(), out2.check()
+}
+
+func (r *Rope) split(n int) (out1, out2 *Rope) {
+	return avl.Split(ropeTree{}, r, n)
 }
 
 func (r *Rope) Insert(n int, bs []byte) *Rope {
@@ -334,6 +390,7 @@ func (r *Rope) Iter(offset int, fn func([]byte) bool) bool {
 	if r == nil {
 		return true
 	}
+	r.ensure()
 	if len(r.content) > 0 { // leaf
 		if offset < len(r.content) {
 			if !fn(r.content[offset:]) {
@@ -361,6 +418,7 @@ func (r *Rope) IterBackward(offset int, fn func([]byte) bool) bool {
 	if r == nil {
 		return true
 	}
+	r.ensure()
 	if len(r.content) > 0 { // leaf
 		content := r.content[:offset]
 		if len(content) == 0 {
@@ -391,6 +449,7 @@ func (r *Rope) iterNodes(fn func(*Rope) bool) {
 	if r == nil {
 		return
 	}
+	r.ensure()
 	if fn(r) {
 		r.left.iterNodes(fn)
 		r.right.iterNodes(fn)
@@ -21,6 +21,8 @@ func getRandomBytes(l int) []byte {
 
 func TestMain(m *testing.M) {
 	MaxLengthPerNode = 8
+	BTreeMaxLeaf = 8
+	BTreeMaxChildren = 4
 	os.Exit(m.Run())
 }
 
@@ -183,8 +185,7 @@ func TestInsert(t *testing.T) {
 		bs1 := r.Insert(i, []byte("FOOBARBAZ")).Bytes()
 		bs2 := bytes.Join([][]byte{bs[:i], []byte("FOOBARBAZ"), bs[i:]}, nil)
 		if !bytes.Equal(bs1, bs2) {
-			p("%s %s\n", bs1, bs2)
-			t.Fatal()
+			t.Fatalf("%s %s", bs1, bs2)
 		}
 	}
 }
@@ -208,8 +209,7 @@ func TestDelete(t *testing.T) {
 	for _, c := range cases {
 		s := string(r.Delete(c.start, c.length).Bytes())
 		if s != c.str {
-			p("%s %s\n", s, c.str)
-			t.Fatal()
+			t.Fatalf("%s %s", s, c.str)
 		}
 	}
 
@@ -356,7 +356,7 @@ func TestIterBackward(t *testing.T) {
 		}
 		return true
 	})
-	if n != 520 {
+	if n != r.Len()-r.weight+MaxLengthPerNode {
 		t.Fatal()
 	}
 
@@ -439,3 +439,175 @@ func TestIterRune(t *testing.T) {
 		t.Fatal()
 	}
 }
+
+func TestBalanceInvariants(t *testing.T) {
+	bs := getRandomBytes(4096)
+	r := NewFromBytes(bs)
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2000; i++ {
+		switch mrand.Intn(4) {
+		case 0: // insert
+			n := mrand.Intn(len(bs) + 1)
+			ins := getRandomBytes(mrand.Intn(20) + 1)
+			r = r.Insert(n, ins)
+			bs = bytes.Join([][]byte{bs[:n], ins, bs[n:]}, nil)
+		case 1: // delete
+			n := mrand.Intn(len(bs) + 1)
+			end := min(n+mrand.Intn(20), len(bs))
+			r = r.Delete(n, end-n)
+			bs = bytes.Join([][]byte{bs[:n], bs[end:]}, nil)
+		case 2: // rotate
+			n := mrand.Intn(len(bs) + 1)
+			r1, r2 := r.Split(n)
+			r = r2.Concat(r1)
+			bs = bytes.Join([][]byte{bs[n:], bs[:n]}, nil)
+		case 3: // prepend
+			r = NewFromBytes([]byte{'x'}).Concat(r)
+			bs = append([]byte{'x'}, bs...)
+		}
+		if err := r.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		if r.Len() != len(bs) {
+			t.Fatal()
+		}
+	}
+	if !bytes.Equal(r.Bytes(), bs) {
+		t.Fatal()
+	}
+	// AVL height bound, 1.44 * log2(leaves + 2)
+	leaves := 0
+	r.iterNodes(func(node *Rope) bool {
+		if len(node.content) > 0 {
+			leaves++
+		}
+		return true
+	})
+	if float64(r.height) > 1.45*math.Log2(float64(leaves+2)) {
+		t.Fatal()
+	}
+}
+
+func TestMergeLeaves(t *testing.T) {
+	halfFull := func(r *Rope) bool {
+		ok := true
+		r.iterNodes(func(node *Rope) bool {
+			if node != r && len(node.content) > 0 && node.underFull() {
+				ok = false
+			}
+			return ok
+		})
+		return ok
+	}
+
+	var r *Rope
+	var bs []byte
+	for i := 0; i < 2000; i++ {
+		n := mrand.Intn(len(bs) + 1)
+		if mrand.Intn(3) == 0 && n < len(bs) { // delete
+			r = r.Delete(n, 1)
+			bs = bytes.Join([][]byte{bs[:n], bs[n+1:]}, nil)
+		} else {
+			r = r.Insert(n, []byte{byte(i)})
+			bs = bytes.Join([][]byte{bs[:n], {byte(i)}, bs[n:]}, nil)
+		}
+		if !halfFull(r) {
+			t.Fatal()
+		}
+		if err := r.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Equal(r.Bytes(), bs) {
+		t.Fatal()
+	}
+	for i := 0; i < 100; i++ {
+		r1, r2 := r.Split(mrand.Intn(len(bs) + 1))
+		if !halfFull(r1) || !halfFull(r2) {
+			t.Fatal()
+		}
+		if !bytes.Equal(r2.Concat(r1).Bytes(), append(r2.Bytes(), r1.Bytes()...)) {
+			t.Fatal()
+		}
+	}
+
+	// single byte leaves without merging
+	MergeLeaves = false
+	defer func() {
+		MergeLeaves = true
+	}()
+	r = nil
+	for i := 0; i < 100; i++ {
+		r = r.Insert(mrand.Intn(r.Len()+1), []byte{byte(i)})
+	}
+	if r.Stats().Leaves != 100 {
+		t.Fatal()
+	}
+}
+
+func TestNoCopy(t *testing.T) {
+	bs := getRandomBytes(1024)
+	orig := bytes.Clone(bs)
+	r := NewFromBytesNoCopy(bs)
+	if !bytes.Equal(r.Bytes(), bs) {
+		t.Fatal()
+	}
+	if r.Len() != 1024 || !r.StructEqual(NewFromBytes(bs)) {
+		t.Fatal()
+	}
+	// leaves alias bs
+	if &r.firstLeaf().content[0] != &bs[0] || &r.lastLeaf().content[0] != &bs[1024-MaxLengthPerNode] {
+		t.Fatal()
+	}
+
+	// leaves are not hash consed
+	if v, _ := cache.Load(leafKey(string(bs[:MaxLengthPerNode]))); v == r.firstLeaf() {
+		t.Fatal()
+	}
+
+	// split leaves do not alias bs
+	r1, r2 := r.split(3)
+	if &r1.content[0] == &bs[0] || &r2.firstLeaf().content[0] == &bs[3] {
+		t.Fatal()
+	}
+
+	// edits do not write to bs
+	r2 = r
+	for i := 0; i < 1000; i++ {
+		n := mrand.Intn(r2.Len() + 1)
+		switch mrand.Intn(3) {
+		case 0:
+			r2 = r2.Insert(n, getRandomBytes(mrand.Intn(20)+1))
+		case 1:
+			r2 = r2.Delete(n, mrand.Intn(20))
+		case 2:
+			r1, r3 := r2.Split(n)
+			r2 = r3.Concat(r1)
+		}
+	}
+	if !bytes.Equal(bs, orig) || !bytes.Equal(r.Bytes(), orig) {
+		t.Fatal()
+	}
+	// returned bytes are copies
+	r.Bytes()[0]++
+	r.Sub(0, 10)[0]++
+	if !bytes.Equal(bs, orig) {
+		t.Fatal()
+	}
+
+	// writing to read only memory of string literals would crash
+	s := "foobarbazquxquuxcorgegraultgarplywaldofredplughxyzzythud"
+	r = NewFromStringNoCopy(s)
+	if string(r.Bytes()) != s {
+		t.Fatal()
+	}
+	for i := 0; i < 100; i++ {
+		n := mrand.Intn(r.Len() + 1)
+		r = r.Insert(n, []byte("x")).Delete(n, 2)
+	}
+	if NewFromStringNoCopy("") != nil || NewFromBytesNoCopy(nil) != nil {
+		t.Fatal()
+	}
+}
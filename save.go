@@ -0,0 +1,176 @@
+package rope
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// WriteTo writes bytes of r to w leaf by leaf
+func (r *Rope) WriteTo(w io.Writer) (n int64, err error) {
+	r.Iter(0, func(bs []byte) bool {
+		var l int
+		l, err = w.Write(bs)
+		n += int64(l)
+		return err == nil
+	})
+	return
+}
+
+type LineEnding int
+
+const (
+	KeepLineEnding LineEnding = iota
+	LF
+	CRLF
+	CR
+)
+
+func (l LineEnding) String() string {
+	switch l {
+	case LF:
+		return "\n"
+	case CRLF:
+		return "\r\n"
+	case CR:
+		return "\r"
+	}
+	return ""
+}
+
+type SaveOptions struct {
+	// Perm is the permission of new files. Existing files keep theirs. Defaults to 0644
+	Perm os.FileMode
+	// BackupSuffix, if not empty, keeps the replaced file at path + BackupSuffix
+	BackupSuffix string
+	// LineEnding converts "\r\n", "\n" and "\r" to the specified one
+	LineEnding LineEnding
+	// Encoder wraps the output to convert encoding of the UTF-8 bytes. Close must flush but not close the underlying writer
+	Encoder func(io.Writer) io.WriteCloser
+}
+
+// SaveFile writes r to path atomically, streaming from leaves.
+// Bytes are written to a temporary file in the same directory, synced, then renamed to path.
+// Symbolic links are followed. opts may be nil
+func SaveFile(path string, r *Rope, opts *SaveOptions) error {
+	if opts == nil {
+		opts = new(SaveOptions)
+	}
+	perm := opts.Perm
+	if perm == 0 {
+		perm = 0644
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	info, err := os.Stat(path)
+	exists := err == nil
+	if exists {
+		perm = info.Mode().Perm()
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if exists && opts.BackupSuffix != "" {
+		if err := backupFile(path, path+opts.BackupSuffix, perm); err != nil {
+			return err
+		}
+	}
+
+	return writeFileAtomic(path, perm, func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+		var out io.Writer = bw
+		var closers []io.Closer
+		if opts.Encoder != nil {
+			enc := opts.Encoder(out)
+			out = enc
+			closers = append(closers, enc)
+		}
+		if opts.LineEnding != KeepLineEnding {
+			conv := newLineEndingWriter(out, opts.LineEnding)
+			out = conv
+			closers = append(closers, conv)
+		}
+		if _, err := r.WriteTo(out); err != nil {
+			return err
+		}
+		// from the outermost, so pending bytes flow down
+		for i := len(closers) - 1; i >= 0; i-- {
+			if err := closers[i].Close(); err != nil {
+				return err
+			}
+		}
+		return bw.Flush()
+	})
+}
+
+// backupFile hard links src to dst, or copies if linking is not possible
+func backupFile(src, dst string, perm os.FileMode) error {
+	if err := os.Remove(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeFileAtomic(dst, perm, func(w io.Writer) error {
+		_, err := io.Copy(w, f)
+		return err
+	})
+}
+
+type lineEndingWriter struct {
+	w         io.Writer
+	ending    []byte
+	pendingCR bool
+	buf       []byte
+}
+
+func newLineEndingWriter(w io.Writer, ending LineEnding) *lineEndingWriter {
+	return &lineEndingWriter{
+		w:      w,
+		ending: []byte(ending.String()),
+	}
+}
+
+func (l *lineEndingWriter) Write(p []byte) (int, error) {
+	buf := l.buf[:0]
+	for _, b := range p {
+		if l.pendingCR {
+			l.pendingCR = false
+			buf = append(buf, l.ending...)
+			if b == '\n' { // CRLF
+				continue
+			}
+		}
+		switch b {
+		case '\r':
+			l.pendingCR = true
+		case '\n':
+			buf = append(buf, l.ending...)
+		default:
+			buf = append(buf, b)
+		}
+	}
+	l.buf = buf
+	if _, err := l.w.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (l *lineEndingWriter) Close() error {
+	if l.pendingCR {
+		l.pendingCR = false
+		_, err := l.w.Write(l.ending)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package rope
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+	bs := getRandomBytes(4096)
+	r := NewFromBytes(bs)
+	buf := new(bytes.Buffer)
+	n, err := r.WriteTo(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(bs)) || !bytes.Equal(buf.Bytes(), bs) {
+		t.Fatal()
+	}
+
+	var nilRope *Rope
+	n, err = nilRope.WriteTo(buf)
+	if n != 0 || err != nil {
+		t.Fatal()
+	}
+
+	// stops at error
+	w := &limitedWriter{
+		n: 100,
+	}
+	n, err = r.WriteTo(w)
+	if err != io.ErrShortWrite || n > 100 {
+		t.Fatal()
+	}
+}
+
+type limitedWriter struct {
+	n int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > l.n {
+		n := l.n
+		l.n = 0
+		return n, io.ErrShortWrite
+	}
+	l.n -= len(p)
+	return len(p), nil
+}
+
+func TestSaveFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo")
+	r := NewFromBytes([]byte("foo\nbar\r\nbaz\rqux\r"))
+
+	// new file
+	if err := SaveFile(path, r, nil); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0644 {
+		t.Fatal()
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, r.Bytes()) {
+		t.Fatal()
+	}
+
+	// keep permissions, backup
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveFile(path, r, &SaveOptions{
+		BackupSuffix: "~",
+		LineEnding:   LF,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	info, err = os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatal()
+	}
+	content, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "foo\nbar\nbaz\nqux\n" {
+		t.Fatal()
+	}
+	backup, err := os.ReadFile(path + "~")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(backup, r.Bytes()) {
+		t.Fatal()
+	}
+
+	// symbolic link, encoder
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink(path, link); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveFile(link, r, &SaveOptions{
+		LineEnding: CRLF,
+		Encoder: func(w io.Writer) io.WriteCloser {
+			return &upperEncoder{
+				w: w,
+			}
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatal()
+	}
+	content, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "FOO\r\nBAR\r\nBAZ\r\nQUX\r\n" {
+		t.Fatal()
+	}
+}
+
+// upperEncoder buffers all bytes until Close
+type upperEncoder struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+func (u *upperEncoder) Write(p []byte) (int, error) {
+	return u.buf.Write(p)
+}
+
+func (u *upperEncoder) Close() error {
+	_, err := u.w.Write(bytes.ToUpper(u.buf.Bytes()))
+	return err
+}
+
+func TestLineEndingWriter(t *testing.T) {
+	cases := []struct {
+		writes   []string
+		ending   LineEnding
+		expected string
+	}{
+		{[]string{"foo\r\nbar"}, LF, "foo\nbar"},
+		{[]string{"foo\r", "\nbar"}, LF, "foo\nbar"},
+		{[]string{"foo\r", "bar\r"}, LF, "foo\nbar\n"},
+		{[]string{"\r\r\n\n"}, CR, "\r\r\r"},
+		{[]string{"foo\n", "\r", "\n", "\r"}, CRLF, "foo\r\n\r\n\r\n"},
+		{[]string{"", "foo"}, CRLF, "foo"},
+	}
+	for _, c := range cases {
+		buf := new(bytes.Buffer)
+		w := newLineEndingWriter(buf, c.ending)
+		for _, s := range c.writes {
+			n, err := w.Write([]byte(s))
+			if err != nil || n != len(s) {
+				t.Fatal()
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != c.expected {
+			t.Fatalf("%q %q", buf.String(), c.expected)
+		}
+	}
+}
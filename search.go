@@ -0,0 +1,197 @@
+package rope
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+type SearchOptions struct {
+	// IgnoreCase matches runes case folded like CaseFold
+	IgnoreCase bool
+	// IgnoreDiacritics matches runes without combining marks, after decomposition like Normalize(NFD).
+	// Marks following a match are included in it
+	IgnoreDiacritics bool
+	// WholeWord matches only if word runes do not continue before or after the match
+	WholeWord bool
+	// IsWord returns whether the rune is part of words for WholeWord. Defaults to IsIdentifierChar
+	IsWord func(rune) bool
+}
+
+// fold appends the runes ru is matched as
+func (o SearchOptions) fold(dst []rune, ru rune) []rune {
+	if ru < 0 { // invalid byte
+		return append(dst, ru)
+	}
+	var decomposed [4]rune // the longest canonical decomposition
+	runes := append(decomposed[:0], ru)
+	if o.IgnoreDiacritics {
+		runes = decompose(decomposed[:0], ru)
+	}
+	for _, ru := range runes {
+		switch {
+		case o.IgnoreDiacritics && unicode.In(ru, unicode.Mn, unicode.Me):
+		case o.IgnoreCase:
+			var buf [2 * utf8.UTFMax]byte
+			for _, f := range string(appendFolded(buf[:0], ru)) {
+				dst = append(dst, f)
+			}
+		default:
+			dst = append(dst, ru)
+		}
+	}
+	return dst
+}
+
+// invalidRune returns a negative rune for an invalid byte, so invalid bytes match only themselves
+func invalidRune(b byte) rune {
+	return -1 - rune(b)
+}
+
+// matchUnit is a folded rune with the range of the original rune
+type matchUnit struct {
+	ru          rune
+	start, end  int
+	first, last bool // of the folded runes of the original rune
+}
+
+// IterMatches calls fn with byte ranges of non-overlapping matches of pattern from offset.
+// Runes are folded by opts and compared, and matches cover whole original runes, streaming across leaves
+func (r *Rope) IterMatches(offset int, pattern []byte, opts SearchOptions, fn func(start, end int) bool) bool {
+	var pat []rune
+	for bs := pattern; len(bs) > 0; {
+		ru, l := utf8.DecodeRune(bs)
+		if ru == utf8.RuneError && l == 1 {
+			ru = invalidRune(bs[0])
+		}
+		pat = opts.fold(pat, ru)
+		bs = bs[l:]
+	}
+	m := len(pat)
+	if m == 0 {
+		return true
+	}
+	// KMP failure function
+	fail := make([]int, m)
+	for i, k := 1, 0; i < m; i++ {
+		for k > 0 && pat[i] != pat[k] {
+			k = fail[k-1]
+		}
+		if pat[i] == pat[k] {
+			k++
+		}
+		fail[i] = k
+	}
+
+	units := make([]matchUnit, m) // the last m ones
+	n, k := 0, 0
+	lastEnd := offset
+	pending := false // a match not reported yet, which may be extended by following marks
+	var matchStart, matchEnd, lastStart int
+	report := func() bool {
+		pending = false
+		if matchStart < lastEnd || opts.WholeWord && !r.isWholeWord(matchStart, lastStart, matchEnd, opts.IsWord) {
+			return true
+		}
+		lastEnd = matchEnd
+		return fn(matchStart, matchEnd)
+	}
+	var folded []rune
+	stopped := false
+	r.iterRunes(offset, func(ru rune, pos int, size int) bool {
+		if ru == utf8.RuneError && size == 1 {
+			ru = invalidRune(r.Index(pos))
+		}
+		folded = opts.fold(folded[:0], ru)
+		if len(folded) == 0 { // removed mark
+			if n > 0 {
+				units[(n-1)%m].end = pos + size
+			}
+			if pending {
+				matchEnd = pos + size
+			}
+			return true
+		}
+		if pending && !report() {
+			stopped = true
+			return false
+		}
+		for i, f := range folded {
+			units[n%m] = matchUnit{
+				ru:    f,
+				start: pos,
+				end:   pos + size,
+				first: i == 0,
+				last:  i == len(folded)-1,
+			}
+			n++
+			for k > 0 && pat[k] != f {
+				k = fail[k-1]
+			}
+			if pat[k] == f {
+				k++
+			}
+			if k == m {
+				first, last := units[(n-m)%m], units[(n-1)%m]
+				if first.first && last.last {
+					pending = true
+					matchStart, matchEnd, lastStart = first.start, last.end, last.start
+				}
+				k = fail[k-1]
+			}
+		}
+		return true
+	})
+	if stopped {
+		return false
+	}
+	if pending {
+		return report()
+	}
+	return true
+}
+
+// isWholeWord returns whether word runes do not continue the match [start, end) whose last rune starts at lastStart
+func (r *Rope) isWholeWord(start, lastStart, end int, isWord func(rune) bool) bool {
+	if isWord == nil {
+		isWord = IsIdentifierChar
+	}
+	runeAt := func(offset int) (ret rune) {
+		r.iterRunes(offset, func(ru rune, _ int, _ int) bool {
+			ret = ru
+			return false
+		})
+		return ret
+	}
+	if start > 0 {
+		before, _ := r.runeBefore(start)
+		if isWord(before) && isWord(runeAt(start)) {
+			return false
+		}
+	}
+	if end < r.Len() {
+		after := runeAt(end)
+		if isWord(runeAt(lastStart)) && (isWord(after) || unicode.In(after, unicode.Mn, unicode.Me)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find returns the range of the first match of pattern from offset, or -1, -1
+func (r *Rope) Find(offset int, pattern []byte, opts SearchOptions) (start, end int) {
+	start, end = -1, -1
+	r.IterMatches(offset, pattern, opts, func(s, e int) bool {
+		start, end = s, e
+		return false
+	})
+	return
+}
+
+// FindAll returns ranges of all non-overlapping matches of pattern
+func (r *Rope) FindAll(pattern []byte, opts SearchOptions) (ret [][2]int) {
+	r.IterMatches(0, pattern, opts, func(start, end int) bool {
+		ret = append(ret, [2]int{start, end})
+		return true
+	})
+	return
+}
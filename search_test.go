@@ -0,0 +1,90 @@
+package rope
+
+import (
+	"bytes"
+	mrand "math/rand"
+	"slices"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	ignoreCase := SearchOptions{IgnoreCase: true}
+	ignoreDiacritics := SearchOptions{IgnoreDiacritics: true}
+	wholeWord := SearchOptions{WholeWord: true}
+	all := SearchOptions{IgnoreCase: true, IgnoreDiacritics: true, WholeWord: true}
+	for _, c := range []struct {
+		text, pattern string
+		opts          SearchOptions
+		expected      [][2]int
+	}{
+		{"foo bar foo", "foo", SearchOptions{}, [][2]int{{0, 3}, {8, 11}}},
+		{"Foo FOO foo", "foo", SearchOptions{}, [][2]int{{8, 11}}},
+		{"Foo FOO foo", "fOo", ignoreCase, [][2]int{{0, 3}, {4, 7}, {8, 11}}},
+		{"aaaa", "aa", SearchOptions{}, [][2]int{{0, 2}, {2, 4}}},
+		{"Stra\u00dfe strasse", "STRASSE", ignoreCase, [][2]int{{0, 7}, {8, 15}}},
+		{"\u00dfs", "s", ignoreCase, [][2]int{{2, 3}}}, // not a part of the folded rune
+		{"\u03a3\u03c3\u03c2", "\u03c3", ignoreCase, [][2]int{{0, 2}, {2, 4}, {4, 6}}},
+		{"I i \u0131 \u0130 \u212a", "ik", ignoreCase, nil},
+		{"I i \u0131 \u0130", "i", ignoreCase, [][2]int{{0, 1}, {2, 3}}}, // no Turkic mappings
+		{"\u212a\u017f \u1e9e \u13a0\uab70", "ks ss \uab70\u13a0", ignoreCase, [][2]int{{0, 16}}},
+		{"caf\u00e9 cafe\u0301 cafe", "cafe", ignoreDiacritics, [][2]int{{0, 5}, {6, 12}, {13, 17}}},
+		{"caf\u00e9 cafe\u0301 cafe", "caf\u00e9", ignoreDiacritics, [][2]int{{0, 5}, {6, 12}, {13, 17}}},
+		{"caf\u00e9 cafe\u0301 cafe", "cafe", SearchOptions{}, [][2]int{{6, 10}, {13, 17}}},
+		{"CAF\u00c9", "caf\u00e9", SearchOptions{IgnoreCase: true, IgnoreDiacritics: true}, [][2]int{{0, 5}}},
+		{"foo foobar barfoo foo_ foo.", "foo", wholeWord, [][2]int{{0, 3}, {23, 26}}},
+		{"foo.bar", "foo.", wholeWord, [][2]int{{0, 4}}},
+		{"cafe\u0301 cafe", "cafe", wholeWord, [][2]int{{7, 11}}},
+		{"Caf\u00e9s CAFE\u0301", "cafe", all, [][2]int{{7, 13}}},
+		{"a\xffb\xfe", "\xff", SearchOptions{}, [][2]int{{1, 2}}},
+		{"foo", "", SearchOptions{}, nil},
+		{"foo", "\u0301", ignoreDiacritics, nil},
+		{"Vi\u1ec7t \u01d5 \u1fa2", "Viet U \u03c9", ignoreDiacritics, [][2]int{{0, 13}}}, // recursive decompositions
+	} {
+		r := NewFromBytes([]byte(c.text))
+		if got := r.FindAll([]byte(c.pattern), c.opts); !slices.Equal(got, c.expected) {
+			t.Fatalf("%q in %q: got %v", c.pattern, c.text, got)
+		}
+	}
+
+	r := NewFromBytes([]byte("Foo foo FOO"))
+	if start, end := r.Find(1, []byte("foo"), ignoreCase); start != 4 || end != 7 {
+		t.Fatal()
+	}
+	if start, end := r.Find(9, []byte("foo"), ignoreCase); start != -1 || end != -1 {
+		t.Fatal()
+	}
+}
+
+func TestFindAcrossLeaves(t *testing.T) {
+	pieces := [][]byte{[]byte("ab"), []byte("a"), []byte("b"), []byte("\u00e9"), []byte(" ")}
+	for i := 0; i < 100; i++ {
+		var text []byte
+		var r *Rope
+		for j, n := 0, mrand.Intn(50); j < n; j++ {
+			piece := pieces[mrand.Intn(len(pieces))]
+			pos := len(text)
+			text = append(text, piece...)
+			r = r.Insert(pos, piece)
+		}
+		pattern := []byte("aba")
+		if mrand.Intn(2) == 0 {
+			pattern = []byte("éa")
+		}
+		// same as bytes.Index without options
+		var expected [][2]int
+		for offset := 0; ; {
+			i := bytes.Index(text[offset:], pattern)
+			if i < 0 {
+				break
+			}
+			expected = append(expected, [2]int{offset + i, offset + i + len(pattern)})
+			offset += i + len(pattern)
+		}
+		if got := r.FindAll(pattern, SearchOptions{}); !slices.Equal(got, expected) {
+			t.Fatalf("%q in %q: got %v, expected %v", pattern, text, got, expected)
+		}
+		if got := r.ToUpper().FindAll(pattern, SearchOptions{IgnoreCase: true}); len(got) != len(expected) {
+			t.Fatalf("%q in %q: got %v", pattern, text, got)
+		}
+	}
+}
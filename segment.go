@@ -0,0 +1,454 @@
+package rope
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Word and sentence segmentation by the rules of UAX #29, with properties from segment_tables.go, generated by gen_ucd.go.
+// Segments are found by running the rules forward from a position where a boundary is certain,
+// like after a line feed, so iterating backward does not scan from the start of the text
+
+type segmenter interface {
+	// reset resets the state, and returns a position at or before offset where there is a boundary
+	reset(r *Rope, offset int) int
+	// next reports whether there is a boundary before ru at pos, and updates the state
+	next(r *Rope, ru rune, pos int, size int) bool
+}
+
+// iterRunesBackward calls fn with runes before offset and their offsets, in reverse order.
+// Invalid bytes are RuneError of size 1
+func (r *Rope) iterRunesBackward(offset int, fn func(ru rune, offset int, size int) bool) bool {
+	var buf []byte
+	return r.IterBackward(offset, func(rev []byte) bool {
+		buf = append(reversedBytes(rev), buf...)
+		start := offset - len(buf)
+		// runes are decodable if there are UTFMax bytes, or all bytes before
+		for len(buf) > 0 && (len(buf) >= utf8.UTFMax || start == 0) {
+			ru, l := utf8.DecodeLastRune(buf)
+			offset -= l
+			if !fn(ru, offset, l) {
+				return false
+			}
+			buf = buf[:len(buf)-l]
+		}
+		return true
+	})
+}
+
+// safePoint returns the last position at or before offset where safe returns true for runes around it, or 0.
+// after is -1 at the end
+func (r *Rope) safePoint(offset int, safe func(before, after rune) bool) (ret int) {
+	offset = r.runeStart(offset)
+	after := rune(-1)
+	if offset < r.Len() {
+		after, _ = utf8.DecodeRune(r.Sub(offset, utf8.UTFMax))
+	}
+	r.iterRunesBackward(offset, func(ru rune, pos int, size int) bool {
+		if safe(ru, after) {
+			ret = pos + size
+			return false
+		}
+		after = ru
+		return true
+	})
+	return
+}
+
+// segmentsFrom calls fn with segments from the reset position start, that end after offset
+func (r *Rope) segmentsFrom(seg segmenter, start int, offset int, fn func(start, end int) bool) bool {
+	if !r.iterRunes(start, func(ru rune, pos int, size int) bool {
+		if !seg.next(r, ru, pos, size) || pos == start {
+			return true
+		}
+		if pos > offset && !fn(start, pos) {
+			return false
+		}
+		start = pos
+		return true
+	}) {
+		return false
+	}
+	if l := r.Len(); start < l && l > offset {
+		return fn(start, l)
+	}
+	return true
+}
+
+func (r *Rope) iterSegments(seg segmenter, offset int, fn func(start, end int) bool) bool {
+	offset = max(min(offset, r.Len()), 0)
+	return r.segmentsFrom(seg, seg.reset(r, offset), offset, fn)
+}
+
+func (r *Rope) iterSegmentsBackward(seg segmenter, offset int, fn func(start, end int) bool) bool {
+	end := max(min(offset, r.Len()), 0)
+	for end > 0 {
+		start := seg.reset(r, end-1)
+		var segs [][2]int
+		r.segmentsFrom(seg, start, start, func(s, e int) bool {
+			if s >= end {
+				return false
+			}
+			segs = append(segs, [2]int{s, e})
+			return true
+		})
+		for i := len(segs) - 1; i >= 0; i-- {
+			if !fn(segs[i][0], segs[i][1]) {
+				return false
+			}
+		}
+		end = start
+	}
+	return true
+}
+
+// IsIdentifierChar reports whether ru is a letter, a digit or an underscore, for words of identifiers
+func IsIdentifierChar(ru rune) bool {
+	return ru == '_' || unicode.IsLetter(ru) || unicode.IsDigit(ru)
+}
+
+// IterWords calls fn with word segments from the one containing offset.
+// Segments are words, spaces and punctuations, broken by UAX #29 rules.
+// If isWord is not nil, runs of runes it returns true for are single words, like identifiers with IsIdentifierChar
+func (r *Rope) IterWords(offset int, isWord func(rune) bool, fn func(start, end int) bool) bool {
+	return r.iterSegments(&wordSegmenter{isWord: isWord}, offset, fn)
+}
+
+// IterWordsBackward calls fn with word segments from the one containing the byte before offset, in reverse order
+func (r *Rope) IterWordsBackward(offset int, isWord func(rune) bool, fn func(start, end int) bool) bool {
+	return r.iterSegmentsBackward(&wordSegmenter{isWord: isWord}, offset, fn)
+}
+
+// NextWord returns the first word boundary after offset, or the length if none
+func (r *Rope) NextWord(offset int, isWord func(rune) bool) int {
+	ret := r.Len()
+	r.IterWords(offset, isWord, func(_, end int) bool {
+		ret = end
+		return false
+	})
+	return ret
+}
+
+// PrevWord returns the last word boundary before offset, or 0 if none
+func (r *Rope) PrevWord(offset int, isWord func(rune) bool) (ret int) {
+	r.IterWordsBackward(offset, isWord, func(start, _ int) bool {
+		ret = start
+		return false
+	})
+	return
+}
+
+// WordAt returns the word segment containing offset, or an empty range at the end
+func (r *Rope) WordAt(offset int, isWord func(rune) bool) (start, end int) {
+	start, end = offset, offset
+	r.IterWords(offset, isWord, func(s, e int) bool {
+		start, end = s, e
+		return false
+	})
+	return
+}
+
+// IterSentences calls fn with sentences from the one containing offset
+func (r *Rope) IterSentences(offset int, fn func(start, end int) bool) bool {
+	return r.iterSegments(new(sentenceSegmenter), offset, fn)
+}
+
+// IterSentencesBackward calls fn with sentences from the one containing the byte before offset, in reverse order
+func (r *Rope) IterSentencesBackward(offset int, fn func(start, end int) bool) bool {
+	return r.iterSegmentsBackward(new(sentenceSegmenter), offset, fn)
+}
+
+// NextSentence returns the first sentence boundary after offset, or the length if none
+func (r *Rope) NextSentence(offset int) int {
+	ret := r.Len()
+	r.IterSentences(offset, func(_, end int) bool {
+		ret = end
+		return false
+	})
+	return ret
+}
+
+// PrevSentence returns the last sentence boundary before offset, or 0 if none
+func (r *Rope) PrevSentence(offset int) (ret int) {
+	r.IterSentencesBackward(offset, func(start, _ int) bool {
+		ret = start
+		return false
+	})
+	return
+}
+
+// SentenceAt returns the sentence containing offset, or an empty range at the end
+func (r *Rope) SentenceAt(offset int) (start, end int) {
+	start, end = offset, offset
+	r.IterSentences(offset, func(s, e int) bool {
+		start, end = s, e
+		return false
+	})
+	return
+}
+
+type wbProp int
+
+const (
+	wbOther wbProp = iota
+	wbSOT
+	wbCR
+	wbLF
+	wbNewline
+	wbExtend
+	wbZWJ
+	wbRI
+	wbFormat
+	wbKatakana
+	wbHebrewLetter
+	wbALetter
+	wbSingleQuote
+	wbDoubleQuote
+	wbMidNumLet
+	wbMidLetter
+	wbMidNum
+	wbNumeric
+	wbExtendNumLet
+	wbWSegSpace
+	wbWord // runes of the custom word class
+)
+
+func wordProp(ru rune, isWord func(rune) bool) wbProp {
+	if isWord != nil && isWord(ru) {
+		return wbWord
+	}
+	return lookupProp(wordProps, ru)
+}
+
+func (p wbProp) ahLetter() bool {
+	return p == wbALetter || p == wbHebrewLetter
+}
+
+func (p wbProp) midNumLetQ() bool {
+	return p == wbMidNumLet || p == wbSingleQuote
+}
+
+func (p wbProp) ignored() bool {
+	return p == wbExtend || p == wbFormat || p == wbZWJ
+}
+
+func (p wbProp) newline() bool {
+	return p == wbCR || p == wbLF || p == wbNewline
+}
+
+type wordSegmenter struct {
+	isWord func(rune) bool
+	raw    wbProp // of the previous rune
+	// previous two, skipping extends and formats
+	p1, p2 wbProp
+	// number of consecutive regional indicators ending at p1
+	ri int
+}
+
+func (s *wordSegmenter) reset(r *Rope, offset int) int {
+	s.raw, s.p1, s.p2, s.ri = wbSOT, wbSOT, wbSOT, 0
+	return r.safePoint(offset, func(before, after rune) bool {
+		switch before {
+		case '\n', 0x0b, 0x0c, 0x85, 0x2028, 0x2029: // WB3a
+			return true
+		}
+		// nothing joins a space to the previous rune other than a space
+		return after >= 0 &&
+			wordProp(after, s.isWord) == wbWSegSpace &&
+			wordProp(before, s.isWord) != wbWSegSpace
+	})
+}
+
+// peek returns the property of the first rune not ignored from offset
+func (s *wordSegmenter) peek(r *Rope, offset int) (ret wbProp) {
+	ret = wbOther
+	r.iterRunes(offset, func(ru rune, _ int, _ int) bool {
+		p := wordProp(ru, s.isWord)
+		if p.ignored() {
+			return true
+		}
+		ret = p
+		return false
+	})
+	return
+}
+
+func (s *wordSegmenter) next(r *Rope, ru rune, pos int, size int) bool {
+	cur := wordProp(ru, s.isWord)
+	ret := s.breaks(r, cur, graphemeProp(ru) == gbExtPict, pos+size)
+	if !cur.ignored() || s.raw == wbSOT || s.raw.newline() {
+		s.p2, s.p1 = s.p1, cur
+		if cur == wbRI {
+			s.ri++
+		} else {
+			s.ri = 0
+		}
+	}
+	s.raw = cur
+	return ret
+}
+
+// breaks reports whether there is a boundary before a rune of cur, pict reports whether it is extended pictographic
+func (s *wordSegmenter) breaks(r *Rope, cur wbProp, pict bool, next int) bool {
+	raw, p1, p2 := s.raw, s.p1, s.p2
+	switch {
+	case raw == wbSOT: // WB1
+		return true
+	case raw == wbCR && cur == wbLF: // WB3
+		return false
+	case raw.newline(), cur.newline(): // WB3a, WB3b
+		return true
+	case raw == wbZWJ && pict: // WB3c
+		return false
+	case raw == wbWSegSpace && cur == wbWSegSpace: // WB3d
+		return false
+	case cur.ignored(): // WB4
+		return false
+	case p1 == wbWord || cur == wbWord:
+		return p1 != cur
+	case p1.ahLetter() && cur.ahLetter(): // WB5
+		return false
+	case p1.ahLetter() && (cur == wbMidLetter || cur.midNumLetQ()) && s.peek(r, next).ahLetter(): // WB6
+		return false
+	case p2.ahLetter() && (p1 == wbMidLetter || p1.midNumLetQ()) && cur.ahLetter(): // WB7
+		return false
+	case p1 == wbHebrewLetter && cur == wbSingleQuote: // WB7a
+		return false
+	case p1 == wbHebrewLetter && cur == wbDoubleQuote && s.peek(r, next) == wbHebrewLetter: // WB7b
+		return false
+	case p2 == wbHebrewLetter && p1 == wbDoubleQuote && cur == wbHebrewLetter: // WB7c
+		return false
+	case (p1 == wbNumeric || p1.ahLetter()) && (cur == wbNumeric || cur.ahLetter()): // WB8, WB9, WB10
+		return false
+	case p2 == wbNumeric && (p1 == wbMidNum || p1.midNumLetQ()) && cur == wbNumeric: // WB11
+		return false
+	case p1 == wbNumeric && (cur == wbMidNum || cur.midNumLetQ()) && s.peek(r, next) == wbNumeric: // WB12
+		return false
+	case p1 == wbKatakana && cur == wbKatakana: // WB13
+		return false
+	case (p1.ahLetter() || p1 == wbNumeric || p1 == wbKatakana || p1 == wbExtendNumLet) && cur == wbExtendNumLet: // WB13a
+		return false
+	case p1 == wbExtendNumLet && (cur.ahLetter() || cur == wbNumeric || cur == wbKatakana): // WB13b
+		return false
+	case p1 == wbRI && cur == wbRI && s.ri%2 == 1: // WB15, WB16
+		return false
+	}
+	return true // WB999
+}
+
+type sbProp int
+
+const (
+	sbOther sbProp = iota
+	sbSOT
+	sbCR
+	sbLF
+	sbSep
+	sbExtend
+	sbFormat
+	sbSp
+	sbLower
+	sbUpper
+	sbOLetter
+	sbNumeric
+	sbATerm
+	sbSTerm
+	sbClose
+	sbSContinue
+)
+
+func sentenceProp(ru rune) sbProp {
+	return lookupProp(sentenceProps, ru)
+}
+
+func (p sbProp) paraSep() bool {
+	return p == sbCR || p == sbLF || p == sbSep
+}
+
+func (p sbProp) saTerm() bool {
+	return p == sbATerm || p == sbSTerm
+}
+
+type sentenceSegmenter struct {
+	raw sbProp // of the previous rune
+	// previous two, skipping extends and formats
+	p1, p2 sbProp
+	// 1 after SATerm Close*, 2 after SATerm Close* Sp+
+	term  int
+	aTerm bool // term is ATerm
+}
+
+func (s *sentenceSegmenter) reset(r *Rope, offset int) int {
+	*s = sentenceSegmenter{raw: sbSOT, p1: sbSOT, p2: sbSOT}
+	return r.safePoint(offset, func(before, after rune) bool {
+		switch before {
+		case '\n', 0x85, 0x2028, 0x2029: // SB4
+			return true
+		case '\r':
+			return after != '\n'
+		}
+		return false
+	})
+}
+
+// lowerFollows reports whether a lower case letter follows offset, skipping runes other than letters, separators and terms
+func (s *sentenceSegmenter) lowerFollows(r *Rope, offset int) (ret bool) {
+	r.iterRunes(offset, func(ru rune, _ int, _ int) bool {
+		switch p := sentenceProp(ru); {
+		case p == sbLower:
+			ret = true
+			return false
+		case p == sbOLetter, p == sbUpper, p.paraSep(), p.saTerm():
+			return false
+		}
+		return true
+	})
+	return
+}
+
+func (s *sentenceSegmenter) next(r *Rope, ru rune, pos int, _ int) bool {
+	cur := sentenceProp(ru)
+	ret := s.breaks(r, cur, pos)
+	if cur != sbExtend && cur != sbFormat || s.raw == sbSOT || s.raw.paraSep() {
+		s.p2, s.p1 = s.p1, cur
+		switch {
+		case cur.saTerm():
+			s.term, s.aTerm = 1, cur == sbATerm
+		case s.term == 1 && cur == sbClose:
+		case s.term > 0 && cur == sbSp:
+			s.term = 2
+		default:
+			s.term = 0
+		}
+	}
+	s.raw = cur
+	return ret
+}
+
+func (s *sentenceSegmenter) breaks(r *Rope, cur sbProp, pos int) bool {
+	switch {
+	case s.raw == sbSOT: // SB1
+		return true
+	case s.raw == sbCR && cur == sbLF: // SB3
+		return false
+	case s.raw.paraSep(): // SB4
+		return true
+	case cur == sbExtend || cur == sbFormat: // SB5
+		return false
+	case s.p1 == sbATerm && cur == sbNumeric: // SB6
+		return false
+	case (s.p2 == sbUpper || s.p2 == sbLower) && s.p1 == sbATerm && cur == sbUpper: // SB7
+		return false
+	case s.term > 0 && s.aTerm && s.lowerFollows(r, pos): // SB8
+		return false
+	case s.term > 0 && (cur == sbSContinue || cur.saTerm()): // SB8a
+		return false
+	case s.term == 1 && (cur == sbClose || cur == sbSp || cur.paraSep()): // SB9
+		return false
+	case s.term > 0 && (cur == sbSp || cur.paraSep()): // SB10
+		return false
+	case s.term > 0: // SB11
+		return true
+	}
+	return false // SB998
+}
@@ -0,0 +1,910 @@
+// Code generated by gen_ucd.go from Unicode 17.0.0. DO NOT EDIT.
+
+package rope
+
+// wordProps are Word_Break of runes other than Other
+var wordProps = []propRange[wbProp]{
+	{0xa, 0xa, wbLF}, {0xb, 0xc, wbNewline}, {0xd, 0xd, wbCR}, {0x20, 0x20, wbWSegSpace},
+	{0x22, 0x22, wbDoubleQuote}, {0x27, 0x27, wbSingleQuote}, {0x2c, 0x2c, wbMidNum}, {0x2e, 0x2e, wbMidNumLet},
+	{0x30, 0x39, wbNumeric}, {0x3a, 0x3a, wbMidLetter}, {0x3b, 0x3b, wbMidNum}, {0x41, 0x5a, wbALetter},
+	{0x5f, 0x5f, wbExtendNumLet}, {0x61, 0x7a, wbALetter}, {0x85, 0x85, wbNewline}, {0xaa, 0xaa, wbALetter},
+	{0xad, 0xad, wbFormat}, {0xb5, 0xb5, wbALetter}, {0xb7, 0xb7, wbMidLetter}, {0xb8, 0xb8, wbALetter},
+	{0xba, 0xba, wbALetter}, {0xc0, 0xd6, wbALetter}, {0xd8, 0xf6, wbALetter}, {0xf8, 0x2d7, wbALetter},
+	{0x2de, 0x2ff, wbALetter}, {0x300, 0x36f, wbExtend}, {0x370, 0x374, wbALetter}, {0x376, 0x377, wbALetter},
+	{0x37a, 0x37d, wbALetter}, {0x37e, 0x37e, wbMidNum}, {0x37f, 0x37f, wbALetter}, {0x386, 0x386, wbALetter},
+	{0x387, 0x387, wbMidLetter}, {0x388, 0x38a, wbALetter}, {0x38c, 0x38c, wbALetter}, {0x38e, 0x3a1, wbALetter},
+	{0x3a3, 0x3f5, wbALetter}, {0x3f7, 0x481, wbALetter}, {0x483, 0x489, wbExtend}, {0x48a, 0x52f, wbALetter},
+	{0x531, 0x556, wbALetter}, {0x559, 0x55c, wbALetter}, {0x55e, 0x55e, wbALetter}, {0x55f, 0x55f, wbMidLetter},
+	{0x560, 0x588, wbALetter}, {0x589, 0x589, wbMidNum}, {0x58a, 0x58a, wbALetter}, {0x591, 0x5bd, wbExtend},
+	{0x5bf, 0x5bf, wbExtend}, {0x5c1, 0x5c2, wbExtend}, {0x5c4, 0x5c5, wbExtend}, {0x5c7, 0x5c7, wbExtend},
+	{0x5d0, 0x5ea, wbHebrewLetter}, {0x5ef, 0x5f2, wbHebrewLetter}, {0x5f3, 0x5f3, wbALetter}, {0x5f4, 0x5f4, wbMidLetter},
+	{0x600, 0x605, wbNumeric}, {0x60c, 0x60d, wbMidNum}, {0x610, 0x61a, wbExtend}, {0x61c, 0x61c, wbFormat},
+	{0x620, 0x64a, wbALetter}, {0x64b, 0x65f, wbExtend}, {0x660, 0x669, wbNumeric}, {0x66b, 0x66b, wbNumeric},
+	{0x66c, 0x66c, wbMidNum}, {0x66e, 0x66f, wbALetter}, {0x670, 0x670, wbExtend}, {0x671, 0x6d3, wbALetter},
+	{0x6d5, 0x6d5, wbALetter}, {0x6d6, 0x6dc, wbExtend}, {0x6dd, 0x6dd, wbNumeric}, {0x6df, 0x6e4, wbExtend},
+	{0x6e5, 0x6e6, wbALetter}, {0x6e7, 0x6e8, wbExtend}, {0x6ea, 0x6ed, wbExtend}, {0x6ee, 0x6ef, wbALetter},
+	{0x6f0, 0x6f9, wbNumeric}, {0x6fa, 0x6fc, wbALetter}, {0x6ff, 0x6ff, wbALetter}, {0x70f, 0x710, wbALetter},
+	{0x711, 0x711, wbExtend}, {0x712, 0x72f, wbALetter}, {0x730, 0x74a, wbExtend}, {0x74d, 0x7a5, wbALetter},
+	{0x7a6, 0x7b0, wbExtend}, {0x7b1, 0x7b1, wbALetter}, {0x7c0, 0x7c9, wbNumeric}, {0x7ca, 0x7ea, wbALetter},
+	{0x7eb, 0x7f3, wbExtend}, {0x7f4, 0x7f5, wbALetter}, {0x7f8, 0x7f8, wbMidNum}, {0x7fa, 0x7fa, wbALetter},
+	{0x7fd, 0x7fd, wbExtend}, {0x800, 0x815, wbALetter}, {0x816, 0x819, wbExtend}, {0x81a, 0x81a, wbALetter},
+	{0x81b, 0x823, wbExtend}, {0x824, 0x824, wbALetter}, {0x825, 0x827, wbExtend}, {0x828, 0x828, wbALetter},
+	{0x829, 0x82d, wbExtend}, {0x840, 0x858, wbALetter}, {0x859, 0x85b, wbExtend}, {0x860, 0x86a, wbALetter},
+	{0x870, 0x887, wbALetter}, {0x889, 0x88f, wbALetter}, {0x890, 0x891, wbNumeric}, {0x897, 0x89f, wbExtend},
+	{0x8a0, 0x8c9, wbALetter}, {0x8ca, 0x8e1, wbExtend}, {0x8e2, 0x8e2, wbNumeric}, {0x8e3, 0x903, wbExtend},
+	{0x904, 0x939, wbALetter}, {0x93a, 0x93c, wbExtend}, {0x93d, 0x93d, wbALetter}, {0x93e, 0x94f, wbExtend},
+	{0x950, 0x950, wbALetter}, {0x951, 0x957, wbExtend}, {0x958, 0x961, wbALetter}, {0x962, 0x963, wbExtend},
+	{0x966, 0x96f, wbNumeric}, {0x971, 0x980, wbALetter}, {0x981, 0x983, wbExtend}, {0x985, 0x98c, wbALetter},
+	{0x98f, 0x990, wbALetter}, {0x993, 0x9a8, wbALetter}, {0x9aa, 0x9b0, wbALetter}, {0x9b2, 0x9b2, wbALetter},
+	{0x9b6, 0x9b9, wbALetter}, {0x9bc, 0x9bc, wbExtend}, {0x9bd, 0x9bd, wbALetter}, {0x9be, 0x9c4, wbExtend},
+	{0x9c7, 0x9c8, wbExtend}, {0x9cb, 0x9cd, wbExtend}, {0x9ce, 0x9ce, wbALetter}, {0x9d7, 0x9d7, wbExtend},
+	{0x9dc, 0x9dd, wbALetter}, {0x9df, 0x9e1, wbALetter}, {0x9e2, 0x9e3, wbExtend}, {0x9e6, 0x9ef, wbNumeric},
+	{0x9f0, 0x9f1, wbALetter}, {0x9fc, 0x9fc, wbALetter}, {0x9fe, 0x9fe, wbExtend}, {0xa01, 0xa03, wbExtend},
+	{0xa05, 0xa0a, wbALetter}, {0xa0f, 0xa10, wbALetter}, {0xa13, 0xa28, wbALetter}, {0xa2a, 0xa30, wbALetter},
+	{0xa32, 0xa33, wbALetter}, {0xa35, 0xa36, wbALetter}, {0xa38, 0xa39, wbALetter}, {0xa3c, 0xa3c, wbExtend},
+	{0xa3e, 0xa42, wbExtend}, {0xa47, 0xa48, wbExtend}, {0xa4b, 0xa4d, wbExtend}, {0xa51, 0xa51, wbExtend},
+	{0xa59, 0xa5c, wbALetter}, {0xa5e, 0xa5e, wbALetter}, {0xa66, 0xa6f, wbNumeric}, {0xa70, 0xa71, wbExtend},
+	{0xa72, 0xa74, wbALetter}, {0xa75, 0xa75, wbExtend}, {0xa81, 0xa83, wbExtend}, {0xa85, 0xa8d, wbALetter},
+	{0xa8f, 0xa91, wbALetter}, {0xa93, 0xaa8, wbALetter}, {0xaaa, 0xab0, wbALetter}, {0xab2, 0xab3, wbALetter},
+	{0xab5, 0xab9, wbALetter}, {0xabc, 0xabc, wbExtend}, {0xabd, 0xabd, wbALetter}, {0xabe, 0xac5, wbExtend},
+	{0xac7, 0xac9, wbExtend}, {0xacb, 0xacd, wbExtend}, {0xad0, 0xad0, wbALetter}, {0xae0, 0xae1, wbALetter},
+	{0xae2, 0xae3, wbExtend}, {0xae6, 0xaef, wbNumeric}, {0xaf9, 0xaf9, wbALetter}, {0xafa, 0xaff, wbExtend},
+	{0xb01, 0xb03, wbExtend}, {0xb05, 0xb0c, wbALetter}, {0xb0f, 0xb10, wbALetter}, {0xb13, 0xb28, wbALetter},
+	{0xb2a, 0xb30, wbALetter}, {0xb32, 0xb33, wbALetter}, {0xb35, 0xb39, wbALetter}, {0xb3c, 0xb3c, wbExtend},
+	{0xb3d, 0xb3d, wbALetter}, {0xb3e, 0xb44, wbExtend}, {0xb47, 0xb48, wbExtend}, {0xb4b, 0xb4d, wbExtend},
+	{0xb55, 0xb57, wbExtend}, {0xb5c, 0xb5d, wbALetter}, {0xb5f, 0xb61, wbALetter}, {0xb62, 0xb63, wbExtend},
+	{0xb66, 0xb6f, wbNumeric}, {0xb71, 0xb71, wbALetter}, {0xb82, 0xb82, wbExtend}, {0xb83, 0xb83, wbALetter},
+	{0xb85, 0xb8a, wbALetter}, {0xb8e, 0xb90, wbALetter}, {0xb92, 0xb95, wbALetter}, {0xb99, 0xb9a, wbALetter},
+	{0xb9c, 0xb9c, wbALetter}, {0xb9e, 0xb9f, wbALetter}, {0xba3, 0xba4, wbALetter}, {0xba8, 0xbaa, wbALetter},
+	{0xbae, 0xbb9, wbALetter}, {0xbbe, 0xbc2, wbExtend}, {0xbc6, 0xbc8, wbExtend}, {0xbca, 0xbcd, wbExtend},
+	{0xbd0, 0xbd0, wbALetter}, {0xbd7, 0xbd7, wbExtend}, {0xbe6, 0xbef, wbNumeric}, {0xc00, 0xc04, wbExtend},
+	{0xc05, 0xc0c, wbALetter}, {0xc0e, 0xc10, wbALetter}, {0xc12, 0xc28, wbALetter}, {0xc2a, 0xc39, wbALetter},
+	{0xc3c, 0xc3c, wbExtend}, {0xc3d, 0xc3d, wbALetter}, {0xc3e, 0xc44, wbExtend}, {0xc46, 0xc48, wbExtend},
+	{0xc4a, 0xc4d, wbExtend}, {0xc55, 0xc56, wbExtend}, {0xc58, 0xc5a, wbALetter}, {0xc5c, 0xc5d, wbALetter},
+	{0xc60, 0xc61, wbALetter}, {0xc62, 0xc63, wbExtend}, {0xc66, 0xc6f, wbNumeric}, {0xc80, 0xc80, wbALetter},
+	{0xc81, 0xc83, wbExtend}, {0xc85, 0xc8c, wbALetter}, {0xc8e, 0xc90, wbALetter}, {0xc92, 0xca8, wbALetter},
+	{0xcaa, 0xcb3, wbALetter}, {0xcb5, 0xcb9, wbALetter}, {0xcbc, 0xcbc, wbExtend}, {0xcbd, 0xcbd, wbALetter},
+	{0xcbe, 0xcc4, wbExtend}, {0xcc6, 0xcc8, wbExtend}, {0xcca, 0xccd, wbExtend}, {0xcd5, 0xcd6, wbExtend},
+	{0xcdc, 0xcde, wbALetter}, {0xce0, 0xce1, wbALetter}, {0xce2, 0xce3, wbExtend}, {0xce6, 0xcef, wbNumeric},
+	{0xcf1, 0xcf2, wbALetter}, {0xcf3, 0xcf3, wbExtend}, {0xd00, 0xd03, wbExtend}, {0xd04, 0xd0c, wbALetter},
+	{0xd0e, 0xd10, wbALetter}, {0xd12, 0xd3a, wbALetter}, {0xd3b, 0xd3c, wbExtend}, {0xd3d, 0xd3d, wbALetter},
+	{0xd3e, 0xd44, wbExtend}, {0xd46, 0xd48, wbExtend}, {0xd4a, 0xd4d, wbExtend}, {0xd4e, 0xd4e, wbALetter},
+	{0xd54, 0xd56, wbALetter}, {0xd57, 0xd57, wbExtend}, {0xd5f, 0xd61, wbALetter}, {0xd62, 0xd63, wbExtend},
+	{0xd66, 0xd6f, wbNumeric}, {0xd7a, 0xd7f, wbALetter}, {0xd81, 0xd83, wbExtend}, {0xd85, 0xd96, wbALetter},
+	{0xd9a, 0xdb1, wbALetter}, {0xdb3, 0xdbb, wbALetter}, {0xdbd, 0xdbd, wbALetter}, {0xdc0, 0xdc6, wbALetter},
+	{0xdca, 0xdca, wbExtend}, {0xdcf, 0xdd4, wbExtend}, {0xdd6, 0xdd6, wbExtend}, {0xdd8, 0xddf, wbExtend},
+	{0xde6, 0xdef, wbNumeric}, {0xdf2, 0xdf3, wbExtend}, {0xe31, 0xe31, wbExtend}, {0xe34, 0xe3a, wbExtend},
+	{0xe47, 0xe4e, wbExtend}, {0xe50, 0xe59, wbNumeric}, {0xeb1, 0xeb1, wbExtend}, {0xeb4, 0xebc, wbExtend},
+	{0xec8, 0xece, wbExtend}, {0xed0, 0xed9, wbNumeric}, {0xf00, 0xf00, wbALetter}, {0xf18, 0xf19, wbExtend},
+	{0xf20, 0xf29, wbNumeric}, {0xf35, 0xf35, wbExtend}, {0xf37, 0xf37, wbExtend}, {0xf39, 0xf39, wbExtend},
+	{0xf3e, 0xf3f, wbExtend}, {0xf40, 0xf47, wbALetter}, {0xf49, 0xf6c, wbALetter}, {0xf71, 0xf84, wbExtend},
+	{0xf86, 0xf87, wbExtend}, {0xf88, 0xf8c, wbALetter}, {0xf8d, 0xf97, wbExtend}, {0xf99, 0xfbc, wbExtend},
+	{0xfc6, 0xfc6, wbExtend}, {0x102b, 0x103e, wbExtend}, {0x1040, 0x1049, wbNumeric}, {0x1056, 0x1059, wbExtend},
+	{0x105e, 0x1060, wbExtend}, {0x1062, 0x1064, wbExtend}, {0x1067, 0x106d, wbExtend}, {0x1071, 0x1074, wbExtend},
+	{0x1082, 0x108d, wbExtend}, {0x108f, 0x108f, wbExtend}, {0x1090, 0x1099, wbNumeric}, {0x109a, 0x109d, wbExtend},
+	{0x10a0, 0x10c5, wbALetter}, {0x10c7, 0x10c7, wbALetter}, {0x10cd, 0x10cd, wbALetter}, {0x10d0, 0x10fa, wbALetter},
+	{0x10fc, 0x1248, wbALetter}, {0x124a, 0x124d, wbALetter}, {0x1250, 0x1256, wbALetter}, {0x1258, 0x1258, wbALetter},
+	{0x125a, 0x125d, wbALetter}, {0x1260, 0x1288, wbALetter}, {0x128a, 0x128d, wbALetter}, {0x1290, 0x12b0, wbALetter},
+	{0x12b2, 0x12b5, wbALetter}, {0x12b8, 0x12be, wbALetter}, {0x12c0, 0x12c0, wbALetter}, {0x12c2, 0x12c5, wbALetter},
+	{0x12c8, 0x12d6, wbALetter}, {0x12d8, 0x1310, wbALetter}, {0x1312, 0x1315, wbALetter}, {0x1318, 0x135a, wbALetter},
+	{0x135d, 0x135f, wbExtend}, {0x1380, 0x138f, wbALetter}, {0x13a0, 0x13f5, wbALetter}, {0x13f8, 0x13fd, wbALetter},
+	{0x1401, 0x166c, wbALetter}, {0x166f, 0x167f, wbALetter}, {0x1680, 0x1680, wbWSegSpace}, {0x1681, 0x169a, wbALetter},
+	{0x16a0, 0x16ea, wbALetter}, {0x16ee, 0x16f8, wbALetter}, {0x1700, 0x1711, wbALetter}, {0x1712, 0x1715, wbExtend},
+	{0x171f, 0x1731, wbALetter}, {0x1732, 0x1734, wbExtend}, {0x1740, 0x1751, wbALetter}, {0x1752, 0x1753, wbExtend},
+	{0x1760, 0x176c, wbALetter}, {0x176e, 0x1770, wbALetter}, {0x1772, 0x1773, wbExtend}, {0x17b4, 0x17d3, wbExtend},
+	{0x17dd, 0x17dd, wbExtend}, {0x17e0, 0x17e9, wbNumeric}, {0x180b, 0x180d, wbExtend}, {0x180e, 0x180e, wbFormat},
+	{0x180f, 0x180f, wbExtend}, {0x1810, 0x1819, wbNumeric}, {0x1820, 0x1878, wbALetter}, {0x1880, 0x1884, wbALetter},
+	{0x1885, 0x1886, wbExtend}, {0x1887, 0x18a8, wbALetter}, {0x18a9, 0x18a9, wbExtend}, {0x18aa, 0x18aa, wbALetter},
+	{0x18b0, 0x18f5, wbALetter}, {0x1900, 0x191e, wbALetter}, {0x1920, 0x192b, wbExtend}, {0x1930, 0x193b, wbExtend},
+	{0x1946, 0x194f, wbNumeric}, {0x19d0, 0x19da, wbNumeric}, {0x1a00, 0x1a16, wbALetter}, {0x1a17, 0x1a1b, wbExtend},
+	{0x1a55, 0x1a5e, wbExtend}, {0x1a60, 0x1a7c, wbExtend}, {0x1a7f, 0x1a7f, wbExtend}, {0x1a80, 0x1a89, wbNumeric},
+	{0x1a90, 0x1a99, wbNumeric}, {0x1ab0, 0x1add, wbExtend}, {0x1ae0, 0x1aeb, wbExtend}, {0x1b00, 0x1b04, wbExtend},
+	{0x1b05, 0x1b33, wbALetter}, {0x1b34, 0x1b44, wbExtend}, {0x1b45, 0x1b4c, wbALetter}, {0x1b50, 0x1b59, wbNumeric},
+	{0x1b6b, 0x1b73, wbExtend}, {0x1b80, 0x1b82, wbExtend}, {0x1b83, 0x1ba0, wbALetter}, {0x1ba1, 0x1bad, wbExtend},
+	{0x1bae, 0x1baf, wbALetter}, {0x1bb0, 0x1bb9, wbNumeric}, {0x1bba, 0x1be5, wbALetter}, {0x1be6, 0x1bf3, wbExtend},
+	{0x1c00, 0x1c23, wbALetter}, {0x1c24, 0x1c37, wbExtend}, {0x1c40, 0x1c49, wbNumeric}, {0x1c4d, 0x1c4f, wbALetter},
+	{0x1c50, 0x1c59, wbNumeric}, {0x1c5a, 0x1c7d, wbALetter}, {0x1c80, 0x1c8a, wbALetter}, {0x1c90, 0x1cba, wbALetter},
+	{0x1cbd, 0x1cbf, wbALetter}, {0x1cd0, 0x1cd2, wbExtend}, {0x1cd4, 0x1ce8, wbExtend}, {0x1ce9, 0x1cec, wbALetter},
+	{0x1ced, 0x1ced, wbExtend}, {0x1cee, 0x1cf3, wbALetter}, {0x1cf4, 0x1cf4, wbExtend}, {0x1cf5, 0x1cf6, wbALetter},
+	{0x1cf7, 0x1cf9, wbExtend}, {0x1cfa, 0x1cfa, wbALetter}, {0x1d00, 0x1dbf, wbALetter}, {0x1dc0, 0x1dff, wbExtend},
+	{0x1e00, 0x1f15, wbALetter}, {0x1f18, 0x1f1d, wbALetter}, {0x1f20, 0x1f45, wbALetter}, {0x1f48, 0x1f4d, wbALetter},
+	{0x1f50, 0x1f57, wbALetter}, {0x1f59, 0x1f59, wbALetter}, {0x1f5b, 0x1f5b, wbALetter}, {0x1f5d, 0x1f5d, wbALetter},
+	{0x1f5f, 0x1f7d, wbALetter}, {0x1f80, 0x1fb4, wbALetter}, {0x1fb6, 0x1fbc, wbALetter}, {0x1fbe, 0x1fbe, wbALetter},
+	{0x1fc2, 0x1fc4, wbALetter}, {0x1fc6, 0x1fcc, wbALetter}, {0x1fd0, 0x1fd3, wbALetter}, {0x1fd6, 0x1fdb, wbALetter},
+	{0x1fe0, 0x1fec, wbALetter}, {0x1ff2, 0x1ff4, wbALetter}, {0x1ff6, 0x1ffc, wbALetter}, {0x2000, 0x2006, wbWSegSpace},
+	{0x2008, 0x200a, wbWSegSpace}, {0x200c, 0x200c, wbExtend}, {0x200d, 0x200d, wbZWJ}, {0x200e, 0x200f, wbFormat},
+	{0x2018, 0x2019, wbMidNumLet}, {0x2024, 0x2024, wbMidNumLet}, {0x2027, 0x2027, wbMidLetter}, {0x2028, 0x2029, wbNewline},
+	{0x202a, 0x202e, wbFormat}, {0x202f, 0x202f, wbExtendNumLet}, {0x203f, 0x2040, wbExtendNumLet}, {0x2044, 0x2044, wbMidNum},
+	{0x2054, 0x2054, wbExtendNumLet}, {0x205f, 0x205f, wbWSegSpace}, {0x2060, 0x2064, wbFormat}, {0x2066, 0x206f, wbFormat},
+	{0x2071, 0x2071, wbALetter}, {0x207f, 0x207f, wbALetter}, {0x2090, 0x209c, wbALetter}, {0x20d0, 0x20f0, wbExtend},
+	{0x2102, 0x2102, wbALetter}, {0x2107, 0x2107, wbALetter}, {0x210a, 0x2113, wbALetter}, {0x2115, 0x2115, wbALetter},
+	{0x2119, 0x211d, wbALetter}, {0x2124, 0x2124, wbALetter}, {0x2126, 0x2126, wbALetter}, {0x2128, 0x2128, wbALetter},
+	{0x212a, 0x212d, wbALetter}, {0x212f, 0x2139, wbALetter}, {0x213c, 0x213f, wbALetter}, {0x2145, 0x2149, wbALetter},
+	{0x214e, 0x214e, wbALetter}, {0x2160, 0x2188, wbALetter}, {0x24b6, 0x24e9, wbALetter}, {0x2c00, 0x2ce4, wbALetter},
+	{0x2ceb, 0x2cee, wbALetter}, {0x2cef, 0x2cf1, wbExtend}, {0x2cf2, 0x2cf3, wbALetter}, {0x2d00, 0x2d25, wbALetter},
+	{0x2d27, 0x2d27, wbALetter}, {0x2d2d, 0x2d2d, wbALetter}, {0x2d30, 0x2d67, wbALetter}, {0x2d6f, 0x2d6f, wbALetter},
+	{0x2d7f, 0x2d7f, wbExtend}, {0x2d80, 0x2d96, wbALetter}, {0x2da0, 0x2da6, wbALetter}, {0x2da8, 0x2dae, wbALetter},
+	{0x2db0, 0x2db6, wbALetter}, {0x2db8, 0x2dbe, wbALetter}, {0x2dc0, 0x2dc6, wbALetter}, {0x2dc8, 0x2dce, wbALetter},
+	{0x2dd0, 0x2dd6, wbALetter}, {0x2dd8, 0x2dde, wbALetter}, {0x2de0, 0x2dff, wbExtend}, {0x2e2f, 0x2e2f, wbALetter},
+	{0x3000, 0x3000, wbWSegSpace}, {0x3005, 0x3005, wbALetter}, {0x302a, 0x302f, wbExtend}, {0x3031, 0x3035, wbKatakana},
+	{0x303b, 0x303c, wbALetter}, {0x3099, 0x309a, wbExtend}, {0x309b, 0x309c, wbKatakana}, {0x30a0, 0x30fa, wbKatakana},
+	{0x30fc, 0x30ff, wbKatakana}, {0x3105, 0x312f, wbALetter}, {0x3131, 0x318e, wbALetter}, {0x31a0, 0x31bf, wbALetter},
+	{0x31f0, 0x31ff, wbKatakana}, {0x32d0, 0x32fe, wbKatakana}, {0x3300, 0x3357, wbKatakana}, {0xa000, 0xa48c, wbALetter},
+	{0xa4d0, 0xa4fd, wbALetter}, {0xa500, 0xa60c, wbALetter}, {0xa610, 0xa61f, wbALetter}, {0xa620, 0xa629, wbNumeric},
+	{0xa62a, 0xa62b, wbALetter}, {0xa640, 0xa66e, wbALetter}, {0xa66f, 0xa672, wbExtend}, {0xa674, 0xa67d, wbExtend},
+	{0xa67f, 0xa69d, wbALetter}, {0xa69e, 0xa69f, wbExtend}, {0xa6a0, 0xa6ef, wbALetter}, {0xa6f0, 0xa6f1, wbExtend},
+	{0xa708, 0xa7dc, wbALetter}, {0xa7f1, 0xa801, wbALetter}, {0xa802, 0xa802, wbExtend}, {0xa803, 0xa805, wbALetter},
+	{0xa806, 0xa806, wbExtend}, {0xa807, 0xa80a, wbALetter}, {0xa80b, 0xa80b, wbExtend}, {0xa80c, 0xa822, wbALetter},
+	{0xa823, 0xa827, wbExtend}, {0xa82c, 0xa82c, wbExtend}, {0xa840, 0xa873, wbALetter}, {0xa880, 0xa881, wbExtend},
+	{0xa882, 0xa8b3, wbALetter}, {0xa8b4, 0xa8c5, wbExtend}, {0xa8d0, 0xa8d9, wbNumeric}, {0xa8e0, 0xa8f1, wbExtend},
+	{0xa8f2, 0xa8f7, wbALetter}, {0xa8fb, 0xa8fb, wbALetter}, {0xa8fd, 0xa8fe, wbALetter}, {0xa8ff, 0xa8ff, wbExtend},
+	{0xa900, 0xa909, wbNumeric}, {0xa90a, 0xa925, wbALetter}, {0xa926, 0xa92d, wbExtend}, {0xa930, 0xa946, wbALetter},
+	{0xa947, 0xa953, wbExtend}, {0xa960, 0xa97c, wbALetter}, {0xa980, 0xa983, wbExtend}, {0xa984, 0xa9b2, wbALetter},
+	{0xa9b3, 0xa9c0, wbExtend}, {0xa9cf, 0xa9cf, wbALetter}, {0xa9d0, 0xa9d9, wbNumeric}, {0xa9e5, 0xa9e5, wbExtend},
+	{0xa9f0, 0xa9f9, wbNumeric}, {0xaa00, 0xaa28, wbALetter}, {0xaa29, 0xaa36, wbExtend}, {0xaa40, 0xaa42, wbALetter},
+	{0xaa43, 0xaa43, wbExtend}, {0xaa44, 0xaa4b, wbALetter}, {0xaa4c, 0xaa4d, wbExtend}, {0xaa50, 0xaa59, wbNumeric},
+	{0xaa7b, 0xaa7d, wbExtend}, {0xaab0, 0xaab0, wbExtend}, {0xaab2, 0xaab4, wbExtend}, {0xaab7, 0xaab8, wbExtend},
+	{0xaabe, 0xaabf, wbExtend}, {0xaac1, 0xaac1, wbExtend}, {0xaae0, 0xaaea, wbALetter}, {0xaaeb, 0xaaef, wbExtend},
+	{0xaaf2, 0xaaf4, wbALetter}, {0xaaf5, 0xaaf6, wbExtend}, {0xab01, 0xab06, wbALetter}, {0xab09, 0xab0e, wbALetter},
+	{0xab11, 0xab16, wbALetter}, {0xab20, 0xab26, wbALetter}, {0xab28, 0xab2e, wbALetter}, {0xab30, 0xab69, wbALetter},
+	{0xab70, 0xabe2, wbALetter}, {0xabe3, 0xabea, wbExtend}, {0xabec, 0xabed, wbExtend}, {0xabf0, 0xabf9, wbNumeric},
+	{0xac00, 0xd7a3, wbALetter}, {0xd7b0, 0xd7c6, wbALetter}, {0xd7cb, 0xd7fb, wbALetter}, {0xfb00, 0xfb06, wbALetter},
+	{0xfb13, 0xfb17, wbALetter}, {0xfb1d, 0xfb1d, wbHebrewLetter}, {0xfb1e, 0xfb1e, wbExtend}, {0xfb1f, 0xfb28, wbHebrewLetter},
+	{0xfb2a, 0xfb36, wbHebrewLetter}, {0xfb38, 0xfb3c, wbHebrewLetter}, {0xfb3e, 0xfb3e, wbHebrewLetter}, {0xfb40, 0xfb41, wbHebrewLetter},
+	{0xfb43, 0xfb44, wbHebrewLetter}, {0xfb46, 0xfb4f, wbHebrewLetter}, {0xfb50, 0xfbb1, wbALetter}, {0xfbd3, 0xfd3d, wbALetter},
+	{0xfd50, 0xfd8f, wbALetter}, {0xfd92, 0xfdc7, wbALetter}, {0xfdf0, 0xfdfb, wbALetter}, {0xfe00, 0xfe0f, wbExtend},
+	{0xfe13, 0xfe13, wbMidLetter}, {0xfe20, 0xfe2f, wbExtend}, {0xfe33, 0xfe34, wbExtendNumLet}, {0xfe4d, 0xfe4f, wbExtendNumLet},
+	{0xfe50, 0xfe50, wbMidNum}, {0xfe52, 0xfe52, wbMidNumLet}, {0xfe54, 0xfe54, wbMidNum}, {0xfe55, 0xfe55, wbMidLetter},
+	{0xfe70, 0xfe74, wbALetter}, {0xfe76, 0xfefc, wbALetter}, {0xfeff, 0xfeff, wbFormat}, {0xff07, 0xff07, wbMidNumLet},
+	{0xff0c, 0xff0c, wbMidNum}, {0xff0e, 0xff0e, wbMidNumLet}, {0xff10, 0xff19, wbNumeric}, {0xff1a, 0xff1a, wbMidLetter},
+	{0xff1b, 0xff1b, wbMidNum}, {0xff21, 0xff3a, wbALetter}, {0xff3f, 0xff3f, wbExtendNumLet}, {0xff41, 0xff5a, wbALetter},
+	{0xff66, 0xff9d, wbKatakana}, {0xff9e, 0xff9f, wbExtend}, {0xffa0, 0xffbe, wbALetter}, {0xffc2, 0xffc7, wbALetter},
+	{0xffca, 0xffcf, wbALetter}, {0xffd2, 0xffd7, wbALetter}, {0xffda, 0xffdc, wbALetter}, {0xfff9, 0xfffb, wbFormat},
+	{0x10000, 0x1000b, wbALetter}, {0x1000d, 0x10026, wbALetter}, {0x10028, 0x1003a, wbALetter}, {0x1003c, 0x1003d, wbALetter},
+	{0x1003f, 0x1004d, wbALetter}, {0x10050, 0x1005d, wbALetter}, {0x10080, 0x100fa, wbALetter}, {0x10140, 0x10174, wbALetter},
+	{0x101fd, 0x101fd, wbExtend}, {0x10280, 0x1029c, wbALetter}, {0x102a0, 0x102d0, wbALetter}, {0x102e0, 0x102e0, wbExtend},
+	{0x10300, 0x1031f, wbALetter}, {0x1032d, 0x1034a, wbALetter}, {0x10350, 0x10375, wbALetter}, {0x10376, 0x1037a, wbExtend},
+	{0x10380, 0x1039d, wbALetter}, {0x103a0, 0x103c3, wbALetter}, {0x103c8, 0x103cf, wbALetter}, {0x103d1, 0x103d5, wbALetter},
+	{0x10400, 0x1049d, wbALetter}, {0x104a0, 0x104a9, wbNumeric}, {0x104b0, 0x104d3, wbALetter}, {0x104d8, 0x104fb, wbALetter},
+	{0x10500, 0x10527, wbALetter}, {0x10530, 0x10563, wbALetter}, {0x10570, 0x1057a, wbALetter}, {0x1057c, 0x1058a, wbALetter},
+	{0x1058c, 0x10592, wbALetter}, {0x10594, 0x10595, wbALetter}, {0x10597, 0x105a1, wbALetter}, {0x105a3, 0x105b1, wbALetter},
+	{0x105b3, 0x105b9, wbALetter}, {0x105bb, 0x105bc, wbALetter}, {0x105c0, 0x105f3, wbALetter}, {0x10600, 0x10736, wbALetter},
+	{0x10740, 0x10755, wbALetter}, {0x10760, 0x10767, wbALetter}, {0x10780, 0x10785, wbALetter}, {0x10787, 0x107b0, wbALetter},
+	{0x107b2, 0x107ba, wbALetter}, {0x10800, 0x10805, wbALetter}, {0x10808, 0x10808, wbALetter}, {0x1080a, 0x10835, wbALetter},
+	{0x10837, 0x10838, wbALetter}, {0x1083c, 0x1083c, wbALetter}, {0x1083f, 0x10855, wbALetter}, {0x10860, 0x10876, wbALetter},
+	{0x10880, 0x1089e, wbALetter}, {0x108e0, 0x108f2, wbALetter}, {0x108f4, 0x108f5, wbALetter}, {0x10900, 0x10915, wbALetter},
+	{0x10920, 0x10939, wbALetter}, {0x10940, 0x10959, wbALetter}, {0x10980, 0x109b7, wbALetter}, {0x109be, 0x109bf, wbALetter},
+	{0x10a00, 0x10a00, wbALetter}, {0x10a01, 0x10a03, wbExtend}, {0x10a05, 0x10a06, wbExtend}, {0x10a0c, 0x10a0f, wbExtend},
+	{0x10a10, 0x10a13, wbALetter}, {0x10a15, 0x10a17, wbALetter}, {0x10a19, 0x10a35, wbALetter}, {0x10a38, 0x10a3a, wbExtend},
+	{0x10a3f, 0x10a3f, wbExtend}, {0x10a60, 0x10a7c, wbALetter}, {0x10a80, 0x10a9c, wbALetter}, {0x10ac0, 0x10ac7, wbALetter},
+	{0x10ac9, 0x10ae4, wbALetter}, {0x10ae5, 0x10ae6, wbExtend}, {0x10b00, 0x10b35, wbALetter}, {0x10b40, 0x10b55, wbALetter},
+	{0x10b60, 0x10b72, wbALetter}, {0x10b80, 0x10b91, wbALetter}, {0x10c00, 0x10c48, wbALetter}, {0x10c80, 0x10cb2, wbALetter},
+	{0x10cc0, 0x10cf2, wbALetter}, {0x10d00, 0x10d23, wbALetter}, {0x10d24, 0x10d27, wbExtend}, {0x10d30, 0x10d39, wbNumeric},
+	{0x10d40, 0x10d49, wbNumeric}, {0x10d4a, 0x10d65, wbALetter}, {0x10d69, 0x10d6d, wbExtend}, {0x10d6f, 0x10d85, wbALetter},
+	{0x10e80, 0x10ea9, wbALetter}, {0x10eab, 0x10eac, wbExtend}, {0x10eb0, 0x10eb1, wbALetter}, {0x10ec2, 0x10ec7, wbALetter},
+	{0x10efa, 0x10eff, wbExtend}, {0x10f00, 0x10f1c, wbALetter}, {0x10f27, 0x10f27, wbALetter}, {0x10f30, 0x10f45, wbALetter},
+	{0x10f46, 0x10f50, wbExtend}, {0x10f70, 0x10f81, wbALetter}, {0x10f82, 0x10f85, wbExtend}, {0x10fb0, 0x10fc4, wbALetter},
+	{0x10fe0, 0x10ff6, wbALetter}, {0x11000, 0x11002, wbExtend}, {0x11003, 0x11037, wbALetter}, {0x11038, 0x11046, wbExtend},
+	{0x11066, 0x1106f, wbNumeric}, {0x11070, 0x11070, wbExtend}, {0x11071, 0x11072, wbALetter}, {0x11073, 0x11074, wbExtend},
+	{0x11075, 0x11075, wbALetter}, {0x1107f, 0x11082, wbExtend}, {0x11083, 0x110af, wbALetter}, {0x110b0, 0x110ba, wbExtend},
+	{0x110bd, 0x110bd, wbNumeric}, {0x110c2, 0x110c2, wbExtend}, {0x110cd, 0x110cd, wbNumeric}, {0x110d0, 0x110e8, wbALetter},
+	{0x110f0, 0x110f9, wbNumeric}, {0x11100, 0x11102, wbExtend}, {0x11103, 0x11126, wbALetter}, {0x11127, 0x11134, wbExtend},
+	{0x11136, 0x1113f, wbNumeric}, {0x11144, 0x11144, wbALetter}, {0x11145, 0x11146, wbExtend}, {0x11147, 0x11147, wbALetter},
+	{0x11150, 0x11172, wbALetter}, {0x11173, 0x11173, wbExtend}, {0x11176, 0x11176, wbALetter}, {0x11180, 0x11182, wbExtend},
+	{0x11183, 0x111b2, wbALetter}, {0x111b3, 0x111c0, wbExtend}, {0x111c1, 0x111c4, wbALetter}, {0x111c9, 0x111cc, wbExtend},
+	{0x111ce, 0x111cf, wbExtend}, {0x111d0, 0x111d9, wbNumeric}, {0x111da, 0x111da, wbALetter}, {0x111dc, 0x111dc, wbALetter},
+	{0x11200, 0x11211, wbALetter}, {0x11213, 0x1122b, wbALetter}, {0x1122c, 0x11237, wbExtend}, {0x1123e, 0x1123e, wbExtend},
+	{0x1123f, 0x11240, wbALetter}, {0x11241, 0x11241, wbExtend}, {0x11280, 0x11286, wbALetter}, {0x11288, 0x11288, wbALetter},
+	{0x1128a, 0x1128d, wbALetter}, {0x1128f, 0x1129d, wbALetter}, {0x1129f, 0x112a8, wbALetter}, {0x112b0, 0x112de, wbALetter},
+	{0x112df, 0x112ea, wbExtend}, {0x112f0, 0x112f9, wbNumeric}, {0x11300, 0x11303, wbExtend}, {0x11305, 0x1130c, wbALetter},
+	{0x1130f, 0x11310, wbALetter}, {0x11313, 0x11328, wbALetter}, {0x1132a, 0x11330, wbALetter}, {0x11332, 0x11333, wbALetter},
+	{0x11335, 0x11339, wbALetter}, {0x1133b, 0x1133c, wbExtend}, {0x1133d, 0x1133d, wbALetter}, {0x1133e, 0x11344, wbExtend},
+	{0x11347, 0x11348, wbExtend}, {0x1134b, 0x1134d, wbExtend}, {0x11350, 0x11350, wbALetter}, {0x11357, 0x11357, wbExtend},
+	{0x1135d, 0x11361, wbALetter}, {0x11362, 0x11363, wbExtend}, {0x11366, 0x1136c, wbExtend}, {0x11370, 0x11374, wbExtend},
+	{0x11380, 0x11389, wbALetter}, {0x1138b, 0x1138b, wbALetter}, {0x1138e, 0x1138e, wbALetter}, {0x11390, 0x113b5, wbALetter},
+	{0x113b7, 0x113b7, wbALetter}, {0x113b8, 0x113c0, wbExtend}, {0x113c2, 0x113c2, wbExtend}, {0x113c5, 0x113c5, wbExtend},
+	{0x113c7, 0x113ca, wbExtend}, {0x113cc, 0x113d0, wbExtend}, {0x113d1, 0x113d1, wbALetter}, {0x113d2, 0x113d2, wbExtend},
+	{0x113d3, 0x113d3, wbALetter}, {0x113e1, 0x113e2, wbExtend}, {0x11400, 0x11434, wbALetter}, {0x11435, 0x11446, wbExtend},
+	{0x11447, 0x1144a, wbALetter}, {0x11450, 0x11459, wbNumeric}, {0x1145e, 0x1145e, wbExtend}, {0x1145f, 0x11461, wbALetter},
+	{0x11480, 0x114af, wbALetter}, {0x114b0, 0x114c3, wbExtend}, {0x114c4, 0x114c5, wbALetter}, {0x114c7, 0x114c7, wbALetter},
+	{0x114d0, 0x114d9, wbNumeric}, {0x11580, 0x115ae, wbALetter}, {0x115af, 0x115b5, wbExtend}, {0x115b8, 0x115c0, wbExtend},
+	{0x115d8, 0x115db, wbALetter}, {0x115dc, 0x115dd, wbExtend}, {0x11600, 0x1162f, wbALetter}, {0x11630, 0x11640, wbExtend},
+	{0x11644, 0x11644, wbALetter}, {0x11650, 0x11659, wbNumeric}, {0x11680, 0x116aa, wbALetter}, {0x116ab, 0x116b7, wbExtend},
+	{0x116b8, 0x116b8, wbALetter}, {0x116c0, 0x116c9, wbNumeric}, {0x116d0, 0x116e3, wbNumeric}, {0x1171d, 0x1172b, wbExtend},
+	{0x11730, 0x11739, wbNumeric}, {0x11800, 0x1182b, wbALetter}, {0x1182c, 0x1183a, wbExtend}, {0x118a0, 0x118df, wbALetter},
+	{0x118e0, 0x118e9, wbNumeric}, {0x118ff, 0x11906, wbALetter}, {0x11909, 0x11909, wbALetter}, {0x1190c, 0x11913, wbALetter},
+	{0x11915, 0x11916, wbALetter}, {0x11918, 0x1192f, wbALetter}, {0x11930, 0x11935, wbExtend}, {0x11937, 0x11938, wbExtend},
+	{0x1193b, 0x1193e, wbExtend}, {0x1193f, 0x1193f, wbALetter}, {0x11940, 0x11940, wbExtend}, {0x11941, 0x11941, wbALetter},
+	{0x11942, 0x11943, wbExtend}, {0x11950, 0x11959, wbNumeric}, {0x119a0, 0x119a7, wbALetter}, {0x119aa, 0x119d0, wbALetter},
+	{0x119d1, 0x119d7, wbExtend}, {0x119da, 0x119e0, wbExtend}, {0x119e1, 0x119e1, wbALetter}, {0x119e3, 0x119e3, wbALetter},
+	{0x119e4, 0x119e4, wbExtend}, {0x11a00, 0x11a00, wbALetter}, {0x11a01, 0x11a0a, wbExtend}, {0x11a0b, 0x11a32, wbALetter},
+	{0x11a33, 0x11a39, wbExtend}, {0x11a3a, 0x11a3a, wbALetter}, {0x11a3b, 0x11a3e, wbExtend}, {0x11a47, 0x11a47, wbExtend},
+	{0x11a50, 0x11a50, wbALetter}, {0x11a51, 0x11a5b, wbExtend}, {0x11a5c, 0x11a89, wbALetter}, {0x11a8a, 0x11a99, wbExtend},
+	{0x11a9d, 0x11a9d, wbALetter}, {0x11ab0, 0x11af8, wbALetter}, {0x11b60, 0x11b67, wbExtend}, {0x11bc0, 0x11be0, wbALetter},
+	{0x11bf0, 0x11bf9, wbNumeric}, {0x11c00, 0x11c08, wbALetter}, {0x11c0a, 0x11c2e, wbALetter}, {0x11c2f, 0x11c36, wbExtend},
+	{0x11c38, 0x11c3f, wbExtend}, {0x11c40, 0x11c40, wbALetter}, {0x11c50, 0x11c59, wbNumeric}, {0x11c72, 0x11c8f, wbALetter},
+	{0x11c92, 0x11ca7, wbExtend}, {0x11ca9, 0x11cb6, wbExtend}, {0x11d00, 0x11d06, wbALetter}, {0x11d08, 0x11d09, wbALetter},
+	{0x11d0b, 0x11d30, wbALetter}, {0x11d31, 0x11d36, wbExtend}, {0x11d3a, 0x11d3a, wbExtend}, {0x11d3c, 0x11d3d, wbExtend},
+	{0x11d3f, 0x11d45, wbExtend}, {0x11d46, 0x11d46, wbALetter}, {0x11d47, 0x11d47, wbExtend}, {0x11d50, 0x11d59, wbNumeric},
+	{0x11d60, 0x11d65, wbALetter}, {0x11d67, 0x11d68, wbALetter}, {0x11d6a, 0x11d89, wbALetter}, {0x11d8a, 0x11d8e, wbExtend},
+	{0x11d90, 0x11d91, wbExtend}, {0x11d93, 0x11d97, wbExtend}, {0x11d98, 0x11d98, wbALetter}, {0x11da0, 0x11da9, wbNumeric},
+	{0x11db0, 0x11ddb, wbALetter}, {0x11de0, 0x11de9, wbNumeric}, {0x11ee0, 0x11ef2, wbALetter}, {0x11ef3, 0x11ef6, wbExtend},
+	{0x11f00, 0x11f01, wbExtend}, {0x11f02, 0x11f02, wbALetter}, {0x11f03, 0x11f03, wbExtend}, {0x11f04, 0x11f10, wbALetter},
+	{0x11f12, 0x11f33, wbALetter}, {0x11f34, 0x11f3a, wbExtend}, {0x11f3e, 0x11f42, wbExtend}, {0x11f50, 0x11f59, wbNumeric},
+	{0x11f5a, 0x11f5a, wbExtend}, {0x11fb0, 0x11fb0, wbALetter}, {0x12000, 0x12399, wbALetter}, {0x12400, 0x1246e, wbALetter},
+	{0x12480, 0x12543, wbALetter}, {0x12f90, 0x12ff0, wbALetter}, {0x13000, 0x1342f, wbALetter}, {0x13430, 0x1343f, wbFormat},
+	{0x13440, 0x13440, wbExtend}, {0x13441, 0x13446, wbALetter}, {0x13447, 0x13455, wbExtend}, {0x13460, 0x143fa, wbALetter},
+	{0x14400, 0x14646, wbALetter}, {0x16100, 0x1611d, wbALetter}, {0x1611e, 0x1612f, wbExtend}, {0x16130, 0x16139, wbNumeric},
+	{0x16800, 0x16a38, wbALetter}, {0x16a40, 0x16a5e, wbALetter}, {0x16a60, 0x16a69, wbNumeric}, {0x16a70, 0x16abe, wbALetter},
+	{0x16ac0, 0x16ac9, wbNumeric}, {0x16ad0, 0x16aed, wbALetter}, {0x16af0, 0x16af4, wbExtend}, {0x16b00, 0x16b2f, wbALetter},
+	{0x16b30, 0x16b36, wbExtend}, {0x16b40, 0x16b43, wbALetter}, {0x16b50, 0x16b59, wbNumeric}, {0x16b63, 0x16b77, wbALetter},
+	{0x16b7d, 0x16b8f, wbALetter}, {0x16d40, 0x16d6c, wbALetter}, {0x16d70, 0x16d79, wbNumeric}, {0x16e40, 0x16e7f, wbALetter},
+	{0x16ea0, 0x16eb8, wbALetter}, {0x16ebb, 0x16ed3, wbALetter}, {0x16f00, 0x16f4a, wbALetter}, {0x16f4f, 0x16f4f, wbExtend},
+	{0x16f50, 0x16f50, wbALetter}, {0x16f51, 0x16f87, wbExtend}, {0x16f8f, 0x16f92, wbExtend}, {0x16f93, 0x16f9f, wbALetter},
+	{0x16fe0, 0x16fe1, wbALetter}, {0x16fe3, 0x16fe3, wbALetter}, {0x16fe4, 0x16fe4, wbExtend}, {0x16ff0, 0x16ff1, wbExtend},
+	{0x1aff0, 0x1aff3, wbKatakana}, {0x1aff5, 0x1affb, wbKatakana}, {0x1affd, 0x1affe, wbKatakana}, {0x1b000, 0x1b000, wbKatakana},
+	{0x1b120, 0x1b122, wbKatakana}, {0x1b155, 0x1b155, wbKatakana}, {0x1b164, 0x1b167, wbKatakana}, {0x1bc00, 0x1bc6a, wbALetter},
+	{0x1bc70, 0x1bc7c, wbALetter}, {0x1bc80, 0x1bc88, wbALetter}, {0x1bc90, 0x1bc99, wbALetter}, {0x1bc9d, 0x1bc9e, wbExtend},
+	{0x1bca0, 0x1bca3, wbFormat}, {0x1ccf0, 0x1ccf9, wbNumeric}, {0x1cf00, 0x1cf2d, wbExtend}, {0x1cf30, 0x1cf46, wbExtend},
+	{0x1d165, 0x1d169, wbExtend}, {0x1d16d, 0x1d172, wbExtend}, {0x1d173, 0x1d17a, wbFormat}, {0x1d17b, 0x1d182, wbExtend},
+	{0x1d185, 0x1d18b, wbExtend}, {0x1d1aa, 0x1d1ad, wbExtend}, {0x1d242, 0x1d244, wbExtend}, {0x1d400, 0x1d454, wbALetter},
+	{0x1d456, 0x1d49c, wbALetter}, {0x1d49e, 0x1d49f, wbALetter}, {0x1d4a2, 0x1d4a2, wbALetter}, {0x1d4a5, 0x1d4a6, wbALetter},
+	{0x1d4a9, 0x1d4ac, wbALetter}, {0x1d4ae, 0x1d4b9, wbALetter}, {0x1d4bb, 0x1d4bb, wbALetter}, {0x1d4bd, 0x1d4c3, wbALetter},
+	{0x1d4c5, 0x1d505, wbALetter}, {0x1d507, 0x1d50a, wbALetter}, {0x1d50d, 0x1d514, wbALetter}, {0x1d516, 0x1d51c, wbALetter},
+	{0x1d51e, 0x1d539, wbALetter}, {0x1d53b, 0x1d53e, wbALetter}, {0x1d540, 0x1d544, wbALetter}, {0x1d546, 0x1d546, wbALetter},
+	{0x1d54a, 0x1d550, wbALetter}, {0x1d552, 0x1d6a5, wbALetter}, {0x1d6a8, 0x1d6c0, wbALetter}, {0x1d6c2, 0x1d6da, wbALetter},
+	{0x1d6dc, 0x1d6fa, wbALetter}, {0x1d6fc, 0x1d714, wbALetter}, {0x1d716, 0x1d734, wbALetter}, {0x1d736, 0x1d74e, wbALetter},
+	{0x1d750, 0x1d76e, wbALetter}, {0x1d770, 0x1d788, wbALetter}, {0x1d78a, 0x1d7a8, wbALetter}, {0x1d7aa, 0x1d7c2, wbALetter},
+	{0x1d7c4, 0x1d7cb, wbALetter}, {0x1d7ce, 0x1d7ff, wbNumeric}, {0x1da00, 0x1da36, wbExtend}, {0x1da3b, 0x1da6c, wbExtend},
+	{0x1da75, 0x1da75, wbExtend}, {0x1da84, 0x1da84, wbExtend}, {0x1da9b, 0x1da9f, wbExtend}, {0x1daa1, 0x1daaf, wbExtend},
+	{0x1df00, 0x1df1e, wbALetter}, {0x1df25, 0x1df2a, wbALetter}, {0x1e000, 0x1e006, wbExtend}, {0x1e008, 0x1e018, wbExtend},
+	{0x1e01b, 0x1e021, wbExtend}, {0x1e023, 0x1e024, wbExtend}, {0x1e026, 0x1e02a, wbExtend}, {0x1e030, 0x1e06d, wbALetter},
+	{0x1e08f, 0x1e08f, wbExtend}, {0x1e100, 0x1e12c, wbALetter}, {0x1e130, 0x1e136, wbExtend}, {0x1e137, 0x1e13d, wbALetter},
+	{0x1e140, 0x1e149, wbNumeric}, {0x1e14e, 0x1e14e, wbALetter}, {0x1e290, 0x1e2ad, wbALetter}, {0x1e2ae, 0x1e2ae, wbExtend},
+	{0x1e2c0, 0x1e2eb, wbALetter}, {0x1e2ec, 0x1e2ef, wbExtend}, {0x1e2f0, 0x1e2f9, wbNumeric}, {0x1e4d0, 0x1e4eb, wbALetter},
+	{0x1e4ec, 0x1e4ef, wbExtend}, {0x1e4f0, 0x1e4f9, wbNumeric}, {0x1e5d0, 0x1e5ed, wbALetter}, {0x1e5ee, 0x1e5ef, wbExtend},
+	{0x1e5f0, 0x1e5f0, wbALetter}, {0x1e5f1, 0x1e5fa, wbNumeric}, {0x1e6c0, 0x1e6de, wbALetter}, {0x1e6e0, 0x1e6e2, wbALetter},
+	{0x1e6e3, 0x1e6e3, wbExtend}, {0x1e6e4, 0x1e6e5, wbALetter}, {0x1e6e6, 0x1e6e6, wbExtend}, {0x1e6e7, 0x1e6ed, wbALetter},
+	{0x1e6ee, 0x1e6ef, wbExtend}, {0x1e6f0, 0x1e6f4, wbALetter}, {0x1e6f5, 0x1e6f5, wbExtend}, {0x1e6fe, 0x1e6ff, wbALetter},
+	{0x1e7e0, 0x1e7e6, wbALetter}, {0x1e7e8, 0x1e7eb, wbALetter}, {0x1e7ed, 0x1e7ee, wbALetter}, {0x1e7f0, 0x1e7fe, wbALetter},
+	{0x1e800, 0x1e8c4, wbALetter}, {0x1e8d0, 0x1e8d6, wbExtend}, {0x1e900, 0x1e943, wbALetter}, {0x1e944, 0x1e94a, wbExtend},
+	{0x1e94b, 0x1e94b, wbALetter}, {0x1e950, 0x1e959, wbNumeric}, {0x1ee00, 0x1ee03, wbALetter}, {0x1ee05, 0x1ee1f, wbALetter},
+	{0x1ee21, 0x1ee22, wbALetter}, {0x1ee24, 0x1ee24, wbALetter}, {0x1ee27, 0x1ee27, wbALetter}, {0x1ee29, 0x1ee32, wbALetter},
+	{0x1ee34, 0x1ee37, wbALetter}, {0x1ee39, 0x1ee39, wbALetter}, {0x1ee3b, 0x1ee3b, wbALetter}, {0x1ee42, 0x1ee42, wbALetter},
+	{0x1ee47, 0x1ee47, wbALetter}, {0x1ee49, 0x1ee49, wbALetter}, {0x1ee4b, 0x1ee4b, wbALetter}, {0x1ee4d, 0x1ee4f, wbALetter},
+	{0x1ee51, 0x1ee52, wbALetter}, {0x1ee54, 0x1ee54, wbALetter}, {0x1ee57, 0x1ee57, wbALetter}, {0x1ee59, 0x1ee59, wbALetter},
+	{0x1ee5b, 0x1ee5b, wbALetter}, {0x1ee5d, 0x1ee5d, wbALetter}, {0x1ee5f, 0x1ee5f, wbALetter}, {0x1ee61, 0x1ee62, wbALetter},
+	{0x1ee64, 0x1ee64, wbALetter}, {0x1ee67, 0x1ee6a, wbALetter}, {0x1ee6c, 0x1ee72, wbALetter}, {0x1ee74, 0x1ee77, wbALetter},
+	{0x1ee79, 0x1ee7c, wbALetter}, {0x1ee7e, 0x1ee7e, wbALetter}, {0x1ee80, 0x1ee89, wbALetter}, {0x1ee8b, 0x1ee9b, wbALetter},
+	{0x1eea1, 0x1eea3, wbALetter}, {0x1eea5, 0x1eea9, wbALetter}, {0x1eeab, 0x1eebb, wbALetter}, {0x1f130, 0x1f149, wbALetter},
+	{0x1f150, 0x1f169, wbALetter}, {0x1f170, 0x1f189, wbALetter}, {0x1f1e6, 0x1f1ff, wbRI}, {0x1f3fb, 0x1f3ff, wbExtend},
+	{0x1fbf0, 0x1fbf9, wbNumeric}, {0xe0001, 0xe0001, wbFormat}, {0xe0020, 0xe007f, wbExtend}, {0xe0100, 0xe01ef, wbExtend},
+}
+
+// sentenceProps are Sentence_Break of runes other than Other
+var sentenceProps = []propRange[sbProp]{
+	{0x9, 0x9, sbSp}, {0xa, 0xa, sbLF}, {0xb, 0xc, sbSp}, {0xd, 0xd, sbCR},
+	{0x20, 0x20, sbSp}, {0x21, 0x21, sbSTerm}, {0x22, 0x22, sbClose}, {0x27, 0x29, sbClose},
+	{0x2c, 0x2d, sbSContinue}, {0x2e, 0x2e, sbATerm}, {0x30, 0x39, sbNumeric}, {0x3a, 0x3b, sbSContinue},
+	{0x3f, 0x3f, sbSTerm}, {0x41, 0x5a, sbUpper}, {0x5b, 0x5b, sbClose}, {0x5d, 0x5d, sbClose},
+	{0x61, 0x7a, sbLower}, {0x7b, 0x7b, sbClose}, {0x7d, 0x7d, sbClose}, {0x85, 0x85, sbSep},
+	{0xa0, 0xa0, sbSp}, {0xaa, 0xaa, sbLower}, {0xab, 0xab, sbClose}, {0xad, 0xad, sbFormat},
+	{0xb5, 0xb5, sbLower}, {0xba, 0xba, sbLower}, {0xbb, 0xbb, sbClose}, {0xc0, 0xd6, sbUpper},
+	{0xd8, 0xde, sbUpper}, {0xdf, 0xf6, sbLower}, {0xf8, 0xff, sbLower}, {0x100, 0x100, sbUpper},
+	{0x101, 0x101, sbLower}, {0x102, 0x102, sbUpper}, {0x103, 0x103, sbLower}, {0x104, 0x104, sbUpper},
+	{0x105, 0x105, sbLower}, {0x106, 0x106, sbUpper}, {0x107, 0x107, sbLower}, {0x108, 0x108, sbUpper},
+	{0x109, 0x109, sbLower}, {0x10a, 0x10a, sbUpper}, {0x10b, 0x10b, sbLower}, {0x10c, 0x10c, sbUpper},
+	{0x10d, 0x10d, sbLower}, {0x10e, 0x10e, sbUpper}, {0x10f, 0x10f, sbLower}, {0x110, 0x110, sbUpper},
+	{0x111, 0x111, sbLower}, {0x112, 0x112, sbUpper}, {0x113, 0x113, sbLower}, {0x114, 0x114, sbUpper},
+	{0x115, 0x115, sbLower}, {0x116, 0x116, sbUpper}, {0x117, 0x117, sbLower}, {0x118, 0x118, sbUpper},
+	{0x119, 0x119, sbLower}, {0x11a, 0x11a, sbUpper}, {0x11b, 0x11b, sbLower}, {0x11c, 0x11c, sbUpper},
+	{0x11d, 0x11d, sbLower}, {0x11e, 0x11e, sbUpper}, {0x11f, 0x11f, sbLower}, {0x120, 0x120, sbUpper},
+	{0x121, 0x121, sbLower}, {0x122, 0x122, sbUpper}, {0x123, 0x123, sbLower}, {0x124, 0x124, sbUpper},
+	{0x125, 0x125, sbLower}, {0x126, 0x126, sbUpper}, {0x127, 0x127, sbLower}, {0x128, 0x128, sbUpper},
+	{0x129, 0x129, sbLower}, {0x12a, 0x12a, sbUpper}, {0x12b, 0x12b, sbLower}, {0x12c, 0x12c, sbUpper},
+	{0x12d, 0x12d, sbLower}, {0x12e, 0x12e, sbUpper}, {0x12f, 0x12f, sbLower}, {0x130, 0x130, sbUpper},
+	{0x131, 0x131, sbLower}, {0x132, 0x132, sbUpper}, {0x133, 0x133, sbLower}, {0x134, 0x134, sbUpper},
+	{0x135, 0x135, sbLower}, {0x136, 0x136, sbUpper}, {0x137, 0x138, sbLower}, {0x139, 0x139, sbUpper},
+	{0x13a, 0x13a, sbLower}, {0x13b, 0x13b, sbUpper}, {0x13c, 0x13c, sbLower}, {0x13d, 0x13d, sbUpper},
+	{0x13e, 0x13e, sbLower}, {0x13f, 0x13f, sbUpper}, {0x140, 0x140, sbLower}, {0x141, 0x141, sbUpper},
+	{0x142, 0x142, sbLower}, {0x143, 0x143, sbUpper}, {0x144, 0x144, sbLower}, {0x145, 0x145, sbUpper},
+	{0x146, 0x146, sbLower}, {0x147, 0x147, sbUpper}, {0x148, 0x149, sbLower}, {0x14a, 0x14a, sbUpper},
+	{0x14b, 0x14b, sbLower}, {0x14c, 0x14c, sbUpper}, {0x14d, 0x14d, sbLower}, {0x14e, 0x14e, sbUpper},
+	{0x14f, 0x14f, sbLower}, {0x150, 0x150, sbUpper}, {0x151, 0x151, sbLower}, {0x152, 0x152, sbUpper},
+	{0x153, 0x153, sbLower}, {0x154, 0x154, sbUpper}, {0x155, 0x155, sbLower}, {0x156, 0x156, sbUpper},
+	{0x157, 0x157, sbLower}, {0x158, 0x158, sbUpper}, {0x159, 0x159, sbLower}, {0x15a, 0x15a, sbUpper},
+	{0x15b, 0x15b, sbLower}, {0x15c, 0x15c, sbUpper}, {0x15d, 0x15d, sbLower}, {0x15e, 0x15e, sbUpper},
+	{0x15f, 0x15f, sbLower}, {0x160, 0x160, sbUpper}, {0x161, 0x161, sbLower}, {0x162, 0x162, sbUpper},
+	{0x163, 0x163, sbLower}, {0x164, 0x164, sbUpper}, {0x165, 0x165, sbLower}, {0x166, 0x166, sbUpper},
+	{0x167, 0x167, sbLower}, {0x168, 0x168, sbUpper}, {0x169, 0x169, sbLower}, {0x16a, 0x16a, sbUpper},
+	{0x16b, 0x16b, sbLower}, {0x16c, 0x16c, sbUpper}, {0x16d, 0x16d, sbLower}, {0x16e, 0x16e, sbUpper},
+	{0x16f, 0x16f, sbLower}, {0x170, 0x170, sbUpper}, {0x171, 0x171, sbLower}, {0x172, 0x172, sbUpper},
+	{0x173, 0x173, sbLower}, {0x174, 0x174, sbUpper}, {0x175, 0x175, sbLower}, {0x176, 0x176, sbUpper},
+	{0x177, 0x177, sbLower}, {0x178, 0x179, sbUpper}, {0x17a, 0x17a, sbLower}, {0x17b, 0x17b, sbUpper},
+	{0x17c, 0x17c, sbLower}, {0x17d, 0x17d, sbUpper}, {0x17e, 0x180, sbLower}, {0x181, 0x182, sbUpper},
+	{0x183, 0x183, sbLower}, {0x184, 0x184, sbUpper}, {0x185, 0x185, sbLower}, {0x186, 0x187, sbUpper},
+	{0x188, 0x188, sbLower}, {0x189, 0x18b, sbUpper}, {0x18c, 0x18d, sbLower}, {0x18e, 0x191, sbUpper},
+	{0x192, 0x192, sbLower}, {0x193, 0x194, sbUpper}, {0x195, 0x195, sbLower}, {0x196, 0x198, sbUpper},
+	{0x199, 0x19b, sbLower}, {0x19c, 0x19d, sbUpper}, {0x19e, 0x19e, sbLower}, {0x19f, 0x1a0, sbUpper},
+	{0x1a1, 0x1a1, sbLower}, {0x1a2, 0x1a2, sbUpper}, {0x1a3, 0x1a3, sbLower}, {0x1a4, 0x1a4, sbUpper},
+	{0x1a5, 0x1a5, sbLower}, {0x1a6, 0x1a7, sbUpper}, {0x1a8, 0x1a8, sbLower}, {0x1a9, 0x1a9, sbUpper},
+	{0x1aa, 0x1ab, sbLower}, {0x1ac, 0x1ac, sbUpper}, {0x1ad, 0x1ad, sbLower}, {0x1ae, 0x1af, sbUpper},
+	{0x1b0, 0x1b0, sbLower}, {0x1b1, 0x1b3, sbUpper}, {0x1b4, 0x1b4, sbLower}, {0x1b5, 0x1b5, sbUpper},
+	{0x1b6, 0x1b6, sbLower}, {0x1b7, 0x1b8, sbUpper}, {0x1b9, 0x1ba, sbLower}, {0x1bb, 0x1bb, sbOLetter},
+	{0x1bc, 0x1bc, sbUpper}, {0x1bd, 0x1bf, sbLower}, {0x1c0, 0x1c3, sbOLetter}, {0x1c4, 0x1c5, sbUpper},
+	{0x1c6, 0x1c6, sbLower}, {0x1c7, 0x1c8, sbUpper}, {0x1c9, 0x1c9, sbLower}, {0x1ca, 0x1cb, sbUpper},
+	{0x1cc, 0x1cc, sbLower}, {0x1cd, 0x1cd, sbUpper}, {0x1ce, 0x1ce, sbLower}, {0x1cf, 0x1cf, sbUpper},
+	{0x1d0, 0x1d0, sbLower}, {0x1d1, 0x1d1, sbUpper}, {0x1d2, 0x1d2, sbLower}, {0x1d3, 0x1d3, sbUpper},
+	{0x1d4, 0x1d4, sbLower}, {0x1d5, 0x1d5, sbUpper}, {0x1d6, 0x1d6, sbLower}, {0x1d7, 0x1d7, sbUpper},
+	{0x1d8, 0x1d8, sbLower}, {0x1d9, 0x1d9, sbUpper}, {0x1da, 0x1da, sbLower}, {0x1db, 0x1db, sbUpper},
+	{0x1dc, 0x1dd, sbLower}, {0x1de, 0x1de, sbUpper}, {0x1df, 0x1df, sbLower}, {0x1e0, 0x1e0, sbUpper},
+	{0x1e1, 0x1e1, sbLower}, {0x1e2, 0x1e2, sbUpper}, {0x1e3, 0x1e3, sbLower}, {0x1e4, 0x1e4, sbUpper},
+	{0x1e5, 0x1e5, sbLower}, {0x1e6, 0x1e6, sbUpper}, {0x1e7, 0x1e7, sbLower}, {0x1e8, 0x1e8, sbUpper},
+	{0x1e9, 0x1e9, sbLower}, {0x1ea, 0x1ea, sbUpper}, {0x1eb, 0x1eb, sbLower}, {0x1ec, 0x1ec, sbUpper},
+	{0x1ed, 0x1ed, sbLower}, {0x1ee, 0x1ee, sbUpper}, {0x1ef, 0x1f0, sbLower}, {0x1f1, 0x1f2, sbUpper},
+	{0x1f3, 0x1f3, sbLower}, {0x1f4, 0x1f4, sbUpper}, {0x1f5, 0x1f5, sbLower}, {0x1f6, 0x1f8, sbUpper},
+	{0x1f9, 0x1f9, sbLower}, {0x1fa, 0x1fa, sbUpper}, {0x1fb, 0x1fb, sbLower}, {0x1fc, 0x1fc, sbUpper},
+	{0x1fd, 0x1fd, sbLower}, {0x1fe, 0x1fe, sbUpper}, {0x1ff, 0x1ff, sbLower}, {0x200, 0x200, sbUpper},
+	{0x201, 0x201, sbLower}, {0x202, 0x202, sbUpper}, {0x203, 0x203, sbLower}, {0x204, 0x204, sbUpper},
+	{0x205, 0x205, sbLower}, {0x206, 0x206, sbUpper}, {0x207, 0x207, sbLower}, {0x208, 0x208, sbUpper},
+	{0x209, 0x209, sbLower}, {0x20a, 0x20a, sbUpper}, {0x20b, 0x20b, sbLower}, {0x20c, 0x20c, sbUpper},
+	{0x20d, 0x20d, sbLower}, {0x20e, 0x20e, sbUpper}, {0x20f, 0x20f, sbLower}, {0x210, 0x210, sbUpper},
+	{0x211, 0x211, sbLower}, {0x212, 0x212, sbUpper}, {0x213, 0x213, sbLower}, {0x214, 0x214, sbUpper},
+	{0x215, 0x215, sbLower}, {0x216, 0x216, sbUpper}, {0x217, 0x217, sbLower}, {0x218, 0x218, sbUpper},
+	{0x219, 0x219, sbLower}, {0x21a, 0x21a, sbUpper}, {0x21b, 0x21b, sbLower}, {0x21c, 0x21c, sbUpper},
+	{0x21d, 0x21d, sbLower}, {0x21e, 0x21e, sbUpper}, {0x21f, 0x21f, sbLower}, {0x220, 0x220, sbUpper},
+	{0x221, 0x221, sbLower}, {0x222, 0x222, sbUpper}, {0x223, 0x223, sbLower}, {0x224, 0x224, sbUpper},
+	{0x225, 0x225, sbLower}, {0x226, 0x226, sbUpper}, {0x227, 0x227, sbLower}, {0x228, 0x228, sbUpper},
+	{0x229, 0x229, sbLower}, {0x22a, 0x22a, sbUpper}, {0x22b, 0x22b, sbLower}, {0x22c, 0x22c, sbUpper},
+	{0x22d, 0x22d, sbLower}, {0x22e, 0x22e, sbUpper}, {0x22f, 0x22f, sbLower}, {0x230, 0x230, sbUpper},
+	{0x231, 0x231, sbLower}, {0x232, 0x232, sbUpper}, {0x233, 0x239, sbLower}, {0x23a, 0x23b, sbUpper},
+	{0x23c, 0x23c, sbLower}, {0x23d, 0x23e, sbUpper}, {0x23f, 0x240, sbLower}, {0x241, 0x241, sbUpper},
+	{0x242, 0x242, sbLower}, {0x243, 0x246, sbUpper}, {0x247, 0x247, sbLower}, {0x248, 0x248, sbUpper},
+	{0x249, 0x249, sbLower}, {0x24a, 0x24a, sbUpper}, {0x24b, 0x24b, sbLower}, {0x24c, 0x24c, sbUpper},
+	{0x24d, 0x24d, sbLower}, {0x24e, 0x24e, sbUpper}, {0x24f, 0x293, sbLower}, {0x294, 0x295, sbOLetter},
+	{0x296, 0x2b8, sbLower}, {0x2b9, 0x2bf, sbOLetter}, {0x2c0, 0x2c1, sbLower}, {0x2c6, 0x2d1, sbOLetter},
+	{0x2e0, 0x2e4, sbLower}, {0x2ec, 0x2ec, sbOLetter}, {0x2ee, 0x2ee, sbOLetter}, {0x300, 0x36f, sbExtend},
+	{0x370, 0x370, sbUpper}, {0x371, 0x371, sbLower}, {0x372, 0x372, sbUpper}, {0x373, 0x373, sbLower},
+	{0x374, 0x374, sbOLetter}, {0x376, 0x376, sbUpper}, {0x377, 0x377, sbLower}, {0x37a, 0x37d, sbLower},
+	{0x37e, 0x37e, sbSContinue}, {0x37f, 0x37f, sbUpper}, {0x386, 0x386, sbUpper}, {0x388, 0x38a, sbUpper},
+	{0x38c, 0x38c, sbUpper}, {0x38e, 0x38f, sbUpper}, {0x390, 0x390, sbLower}, {0x391, 0x3a1, sbUpper},
+	{0x3a3, 0x3ab, sbUpper}, {0x3ac, 0x3ce, sbLower}, {0x3cf, 0x3cf, sbUpper}, {0x3d0, 0x3d1, sbLower},
+	{0x3d2, 0x3d4, sbUpper}, {0x3d5, 0x3d7, sbLower}, {0x3d8, 0x3d8, sbUpper}, {0x3d9, 0x3d9, sbLower},
+	{0x3da, 0x3da, sbUpper}, {0x3db, 0x3db, sbLower}, {0x3dc, 0x3dc, sbUpper}, {0x3dd, 0x3dd, sbLower},
+	{0x3de, 0x3de, sbUpper}, {0x3df, 0x3df, sbLower}, {0x3e0, 0x3e0, sbUpper}, {0x3e1, 0x3e1, sbLower},
+	{0x3e2, 0x3e2, sbUpper}, {0x3e3, 0x3e3, sbLower}, {0x3e4, 0x3e4, sbUpper}, {0x3e5, 0x3e5, sbLower},
+	{0x3e6, 0x3e6, sbUpper}, {0x3e7, 0x3e7, sbLower}, {0x3e8, 0x3e8, sbUpper}, {0x3e9, 0x3e9, sbLower},
+	{0x3ea, 0x3ea, sbUpper}, {0x3eb, 0x3eb, sbLower}, {0x3ec, 0x3ec, sbUpper}, {0x3ed, 0x3ed, sbLower},
+	{0x3ee, 0x3ee, sbUpper}, {0x3ef, 0x3f3, sbLower}, {0x3f4, 0x3f4, sbUpper}, {0x3f5, 0x3f5, sbLower},
+	{0x3f7, 0x3f7, sbUpper}, {0x3f8, 0x3f8, sbLower}, {0x3f9, 0x3fa, sbUpper}, {0x3fb, 0x3fc, sbLower},
+	{0x3fd, 0x42f, sbUpper}, {0x430, 0x45f, sbLower}, {0x460, 0x460, sbUpper}, {0x461, 0x461, sbLower},
+	{0x462, 0x462, sbUpper}, {0x463, 0x463, sbLower}, {0x464, 0x464, sbUpper}, {0x465, 0x465, sbLower},
+	{0x466, 0x466, sbUpper}, {0x467, 0x467, sbLower}, {0x468, 0x468, sbUpper}, {0x469, 0x469, sbLower},
+	{0x46a, 0x46a, sbUpper}, {0x46b, 0x46b, sbLower}, {0x46c, 0x46c, sbUpper}, {0x46d, 0x46d, sbLower},
+	{0x46e, 0x46e, sbUpper}, {0x46f, 0x46f, sbLower}, {0x470, 0x470, sbUpper}, {0x471, 0x471, sbLower},
+	{0x472, 0x472, sbUpper}, {0x473, 0x473, sbLower}, {0x474, 0x474, sbUpper}, {0x475, 0x475, sbLower},
+	{0x476, 0x476, sbUpper}, {0x477, 0x477, sbLower}, {0x478, 0x478, sbUpper}, {0x479, 0x479, sbLower},
+	{0x47a, 0x47a, sbUpper}, {0x47b, 0x47b, sbLower}, {0x47c, 0x47c, sbUpper}, {0x47d, 0x47d, sbLower},
+	{0x47e, 0x47e, sbUpper}, {0x47f, 0x47f, sbLower}, {0x480, 0x480, sbUpper}, {0x481, 0x481, sbLower},
+	{0x483, 0x489, sbExtend}, {0x48a, 0x48a, sbUpper}, {0x48b, 0x48b, sbLower}, {0x48c, 0x48c, sbUpper},
+	{0x48d, 0x48d, sbLower}, {0x48e, 0x48e, sbUpper}, {0x48f, 0x48f, sbLower}, {0x490, 0x490, sbUpper},
+	{0x491, 0x491, sbLower}, {0x492, 0x492, sbUpper}, {0x493, 0x493, sbLower}, {0x494, 0x494, sbUpper},
+	{0x495, 0x495, sbLower}, {0x496, 0x496, sbUpper}, {0x497, 0x497, sbLower}, {0x498, 0x498, sbUpper},
+	{0x499, 0x499, sbLower}, {0x49a, 0x49a, sbUpper}, {0x49b, 0x49b, sbLower}, {0x49c, 0x49c, sbUpper},
+	{0x49d, 0x49d, sbLower}, {0x49e, 0x49e, sbUpper}, {0x49f, 0x49f, sbLower}, {0x4a0, 0x4a0, sbUpper},
+	{0x4a1, 0x4a1, sbLower}, {0x4a2, 0x4a2, sbUpper}, {0x4a3, 0x4a3, sbLower}, {0x4a4, 0x4a4, sbUpper},
+	{0x4a5, 0x4a5, sbLower}, {0x4a6, 0x4a6, sbUpper}, {0x4a7, 0x4a7, sbLower}, {0x4a8, 0x4a8, sbUpper},
+	{0x4a9, 0x4a9, sbLower}, {0x4aa, 0x4aa, sbUpper}, {0x4ab, 0x4ab, sbLower}, {0x4ac, 0x4ac, sbUpper},
+	{0x4ad, 0x4ad, sbLower}, {0x4ae, 0x4ae, sbUpper}, {0x4af, 0x4af, sbLower}, {0x4b0, 0x4b0, sbUpper},
+	{0x4b1, 0x4b1, sbLower}, {0x4b2, 0x4b2, sbUpper}, {0x4b3, 0x4b3, sbLower}, {0x4b4, 0x4b4, sbUpper},
+	{0x4b5, 0x4b5, sbLower}, {0x4b6, 0x4b6, sbUpper}, {0x4b7, 0x4b7, sbLower}, {0x4b8, 0x4b8, sbUpper},
+	{0x4b9, 0x4b9, sbLower}, {0x4ba, 0x4ba, sbUpper}, {0x4bb, 0x4bb, sbLower}, {0x4bc, 0x4bc, sbUpper},
+	{0x4bd, 0x4bd, sbLower}, {0x4be, 0x4be, sbUpper}, {0x4bf, 0x4bf, sbLower}, {0x4c0, 0x4c1, sbUpper},
+	{0x4c2, 0x4c2, sbLower}, {0x4c3, 0x4c3, sbUpper}, {0x4c4, 0x4c4, sbLower}, {0x4c5, 0x4c5, sbUpper},
+	{0x4c6, 0x4c6, sbLower}, {0x4c7, 0x4c7, sbUpper}, {0x4c8, 0x4c8, sbLower}, {0x4c9, 0x4c9, sbUpper},
+	{0x4ca, 0x4ca, sbLower}, {0x4cb, 0x4cb, sbUpper}, {0x4cc, 0x4cc, sbLower}, {0x4cd, 0x4cd, sbUpper},
+	{0x4ce, 0x4cf, sbLower}, {0x4d0, 0x4d0, sbUpper}, {0x4d1, 0x4d1, sbLower}, {0x4d2, 0x4d2, sbUpper},
+	{0x4d3, 0x4d3, sbLower}, {0x4d4, 0x4d4, sbUpper}, {0x4d5, 0x4d5, sbLower}, {0x4d6, 0x4d6, sbUpper},
+	{0x4d7, 0x4d7, sbLower}, {0x4d8, 0x4d8, sbUpper}, {0x4d9, 0x4d9, sbLower}, {0x4da, 0x4da, sbUpper},
+	{0x4db, 0x4db, sbLower}, {0x4dc, 0x4dc, sbUpper}, {0x4dd, 0x4dd, sbLower}, {0x4de, 0x4de, sbUpper},
+	{0x4df, 0x4df, sbLower}, {0x4e0, 0x4e0, sbUpper}, {0x4e1, 0x4e1, sbLower}, {0x4e2, 0x4e2, sbUpper},
+	{0x4e3, 0x4e3, sbLower}, {0x4e4, 0x4e4, sbUpper}, {0x4e5, 0x4e5, sbLower}, {0x4e6, 0x4e6, sbUpper},
+	{0x4e7, 0x4e7, sbLower}, {0x4e8, 0x4e8, sbUpper}, {0x4e9, 0x4e9, sbLower}, {0x4ea, 0x4ea, sbUpper},
+	{0x4eb, 0x4eb, sbLower}, {0x4ec, 0x4ec, sbUpper}, {0x4ed, 0x4ed, sbLower}, {0x4ee, 0x4ee, sbUpper},
+	{0x4ef, 0x4ef, sbLower}, {0x4f0, 0x4f0, sbUpper}, {0x4f1, 0x4f1, sbLower}, {0x4f2, 0x4f2, sbUpper},
+	{0x4f3, 0x4f3, sbLower}, {0x4f4, 0x4f4, sbUpper}, {0x4f5, 0x4f5, sbLower}, {0x4f6, 0x4f6, sbUpper},
+	{0x4f7, 0x4f7, sbLower}, {0x4f8, 0x4f8, sbUpper}, {0x4f9, 0x4f9, sbLower}, {0x4fa, 0x4fa, sbUpper},
+	{0x4fb, 0x4fb, sbLower}, {0x4fc, 0x4fc, sbUpper}, {0x4fd, 0x4fd, sbLower}, {0x4fe, 0x4fe, sbUpper},
+	{0x4ff, 0x4ff, sbLower}, {0x500, 0x500, sbUpper}, {0x501, 0x501, sbLower}, {0x502, 0x502, sbUpper},
+	{0x503, 0x503, sbLower}, {0x504, 0x504, sbUpper}, {0x505, 0x505, sbLower}, {0x506, 0x506, sbUpper},
+	{0x507, 0x507, sbLower}, {0x508, 0x508, sbUpper}, {0x509, 0x509, sbLower}, {0x50a, 0x50a, sbUpper},
+	{0x50b, 0x50b, sbLower}, {0x50c, 0x50c, sbUpper}, {0x50d, 0x50d, sbLower}, {0x50e, 0x50e, sbUpper},
+	{0x50f, 0x50f, sbLower}, {0x510, 0x510, sbUpper}, {0x511, 0x511, sbLower}, {0x512, 0x512, sbUpper},
+	{0x513, 0x513, sbLower}, {0x514, 0x514, sbUpper}, {0x515, 0x515, sbLower}, {0x516, 0x516, sbUpper},
+	{0x517, 0x517, sbLower}, {0x518, 0x518, sbUpper}, {0x519, 0x519, sbLower}, {0x51a, 0x51a, sbUpper},
+	{0x51b, 0x51b, sbLower}, {0x51c, 0x51c, sbUpper}, {0x51d, 0x51d, sbLower}, {0x51e, 0x51e, sbUpper},
+	{0x51f, 0x51f, sbLower}, {0x520, 0x520, sbUpper}, {0x521, 0x521, sbLower}, {0x522, 0x522, sbUpper},
+	{0x523, 0x523, sbLower}, {0x524, 0x524, sbUpper}, {0x525, 0x525, sbLower}, {0x526, 0x526, sbUpper},
+	{0x527, 0x527, sbLower}, {0x528, 0x528, sbUpper}, {0x529, 0x529, sbLower}, {0x52a, 0x52a, sbUpper},
+	{0x52b, 0x52b, sbLower}, {0x52c, 0x52c, sbUpper}, {0x52d, 0x52d, sbLower}, {0x52e, 0x52e, sbUpper},
+	{0x52f, 0x52f, sbLower}, {0x531, 0x556, sbUpper}, {0x559, 0x559, sbOLetter}, {0x55d, 0x55d, sbSContinue},
+	{0x560, 0x588, sbLower}, {0x589, 0x589, sbSTerm}, {0x591, 0x5bd, sbExtend}, {0x5bf, 0x5bf, sbExtend},
+	{0x5c1, 0x5c2, sbExtend}, {0x5c4, 0x5c5, sbExtend}, {0x5c7, 0x5c7, sbExtend}, {0x5d0, 0x5ea, sbOLetter},
+	{0x5ef, 0x5f3, sbOLetter}, {0x600, 0x605, sbNumeric}, {0x60c, 0x60d, sbSContinue}, {0x610, 0x61a, sbExtend},
+	{0x61c, 0x61c, sbFormat}, {0x61d, 0x61f, sbSTerm}, {0x620, 0x64a, sbOLetter}, {0x64b, 0x65f, sbExtend},
+	{0x660, 0x669, sbNumeric}, {0x66b, 0x66c, sbNumeric}, {0x66e, 0x66f, sbOLetter}, {0x670, 0x670, sbExtend},
+	{0x671, 0x6d3, sbOLetter}, {0x6d4, 0x6d4, sbSTerm}, {0x6d5, 0x6d5, sbOLetter}, {0x6d6, 0x6dc, sbExtend},
+	{0x6dd, 0x6dd, sbNumeric}, {0x6df, 0x6e4, sbExtend}, {0x6e5, 0x6e6, sbOLetter}, {0x6e7, 0x6e8, sbExtend},
+	{0x6ea, 0x6ed, sbExtend}, {0x6ee, 0x6ef, sbOLetter}, {0x6f0, 0x6f9, sbNumeric}, {0x6fa, 0x6fc, sbOLetter},
+	{0x6ff, 0x6ff, sbOLetter}, {0x700, 0x702, sbSTerm}, {0x70f, 0x70f, sbFormat}, {0x710, 0x710, sbOLetter},
+	{0x711, 0x711, sbExtend}, {0x712, 0x72f, sbOLetter}, {0x730, 0x74a, sbExtend}, {0x74d, 0x7a5, sbOLetter},
+	{0x7a6, 0x7b0, sbExtend}, {0x7b1, 0x7b1, sbOLetter}, {0x7c0, 0x7c9, sbNumeric}, {0x7ca, 0x7ea, sbOLetter},
+	{0x7eb, 0x7f3, sbExtend}, {0x7f4, 0x7f5, sbOLetter}, {0x7f8, 0x7f8, sbSContinue}, {0x7f9, 0x7f9, sbSTerm},
+	{0x7fa, 0x7fa, sbOLetter}, {0x7fd, 0x7fd, sbExtend}, {0x800, 0x815, sbOLetter}, {0x816, 0x819, sbExtend},
+	{0x81a, 0x81a, sbOLetter}, {0x81b, 0x823, sbExtend}, {0x824, 0x824, sbOLetter}, {0x825, 0x827, sbExtend},
+	{0x828, 0x828, sbOLetter}, {0x829, 0x82d, sbExtend}, {0x837, 0x837, sbSTerm}, {0x839, 0x839, sbSTerm},
+	{0x83d, 0x83e, sbSTerm}, {0x840, 0x858, sbOLetter}, {0x859, 0x85b, sbExtend}, {0x860, 0x86a, sbOLetter},
+	{0x870, 0x887, sbOLetter}, {0x889, 0x88f, sbOLetter}, {0x890, 0x891, sbNumeric}, {0x897, 0x89f, sbExtend},
+	{0x8a0, 0x8c9, sbOLetter}, {0x8ca, 0x8e1, sbExtend}, {0x8e2, 0x8e2, sbNumeric}, {0x8e3, 0x903, sbExtend},
+	{0x904, 0x939, sbOLetter}, {0x93a, 0x93c, sbExtend}, {0x93d, 0x93d, sbOLetter}, {0x93e, 0x94f, sbExtend},
+	{0x950, 0x950, sbOLetter}, {0x951, 0x957, sbExtend}, {0x958, 0x961, sbOLetter}, {0x962, 0x963, sbExtend},
+	{0x964, 0x965, sbSTerm}, {0x966, 0x96f, sbNumeric}, {0x971, 0x980, sbOLetter}, {0x981, 0x983, sbExtend},
+	{0x985, 0x98c, sbOLetter}, {0x98f, 0x990, sbOLetter}, {0x993, 0x9a8, sbOLetter}, {0x9aa, 0x9b0, sbOLetter},
+	{0x9b2, 0x9b2, sbOLetter}, {0x9b6, 0x9b9, sbOLetter}, {0x9bc, 0x9bc, sbExtend}, {0x9bd, 0x9bd, sbOLetter},
+	{0x9be, 0x9c4, sbExtend}, {0x9c7, 0x9c8, sbExtend}, {0x9cb, 0x9cd, sbExtend}, {0x9ce, 0x9ce, sbOLetter},
+	{0x9d7, 0x9d7, sbExtend}, {0x9dc, 0x9dd, sbOLetter}, {0x9df, 0x9e1, sbOLetter}, {0x9e2, 0x9e3, sbExtend},
+	{0x9e6, 0x9ef, sbNumeric}, {0x9f0, 0x9f1, sbOLetter}, {0x9fc, 0x9fc, sbOLetter}, {0x9fe, 0x9fe, sbExtend},
+	{0xa01, 0xa03, sbExtend}, {0xa05, 0xa0a, sbOLetter}, {0xa0f, 0xa10, sbOLetter}, {0xa13, 0xa28, sbOLetter},
+	{0xa2a, 0xa30, sbOLetter}, {0xa32, 0xa33, sbOLetter}, {0xa35, 0xa36, sbOLetter}, {0xa38, 0xa39, sbOLetter},
+	{0xa3c, 0xa3c, sbExtend}, {0xa3e, 0xa42, sbExtend}, {0xa47, 0xa48, sbExtend}, {0xa4b, 0xa4d, sbExtend},
+	{0xa51, 0xa51, sbExtend}, {0xa59, 0xa5c, sbOLetter}, {0xa5e, 0xa5e, sbOLetter}, {0xa66, 0xa6f, sbNumeric},
+	{0xa70, 0xa71, sbExtend}, {0xa72, 0xa74, sbOLetter}, {0xa75, 0xa75, sbExtend}, {0xa81, 0xa83, sbExtend},
+	{0xa85, 0xa8d, sbOLetter}, {0xa8f, 0xa91, sbOLetter}, {0xa93, 0xaa8, sbOLetter}, {0xaaa, 0xab0, sbOLetter},
+	{0xab2, 0xab3, sbOLetter}, {0xab5, 0xab9, sbOLetter}, {0xabc, 0xabc, sbExtend}, {0xabd, 0xabd, sbOLetter},
+	{0xabe, 0xac5, sbExtend}, {0xac7, 0xac9, sbExtend}, {0xacb, 0xacd, sbExtend}, {0xad0, 0xad0, sbOLetter},
+	{0xae0, 0xae1, sbOLetter}, {0xae2, 0xae3, sbExtend}, {0xae6, 0xaef, sbNumeric}, {0xaf9, 0xaf9, sbOLetter},
+	{0xafa, 0xaff, sbExtend}, {0xb01, 0xb03, sbExtend}, {0xb05, 0xb0c, sbOLetter}, {0xb0f, 0xb10, sbOLetter},
+	{0xb13, 0xb28, sbOLetter}, {0xb2a, 0xb30, sbOLetter}, {0xb32, 0xb33, sbOLetter}, {0xb35, 0xb39, sbOLetter},
+	{0xb3c, 0xb3c, sbExtend}, {0xb3d, 0xb3d, sbOLetter}, {0xb3e, 0xb44, sbExtend}, {0xb47, 0xb48, sbExtend},
+	{0xb4b, 0xb4d, sbExtend}, {0xb55, 0xb57, sbExtend}, {0xb5c, 0xb5d, sbOLetter}, {0xb5f, 0xb61, sbOLetter},
+	{0xb62, 0xb63, sbExtend}, {0xb66, 0xb6f, sbNumeric}, {0xb71, 0xb71, sbOLetter}, {0xb82, 0xb82, sbExtend},
+	{0xb83, 0xb83, sbOLetter}, {0xb85, 0xb8a, sbOLetter}, {0xb8e, 0xb90, sbOLetter}, {0xb92, 0xb95, sbOLetter},
+	{0xb99, 0xb9a, sbOLetter}, {0xb9c, 0xb9c, sbOLetter}, {0xb9e, 0xb9f, sbOLetter}, {0xba3, 0xba4, sbOLetter},
+	{0xba8, 0xbaa, sbOLetter}, {0xbae, 0xbb9, sbOLetter}, {0xbbe, 0xbc2, sbExtend}, {0xbc6, 0xbc8, sbExtend},
+	{0xbca, 0xbcd, sbExtend}, {0xbd0, 0xbd0, sbOLetter}, {0xbd7, 0xbd7, sbExtend}, {0xbe6, 0xbef, sbNumeric},
+	{0xc00, 0xc04, sbExtend}, {0xc05, 0xc0c, sbOLetter}, {0xc0e, 0xc10, sbOLetter}, {0xc12, 0xc28, sbOLetter},
+	{0xc2a, 0xc39, sbOLetter}, {0xc3c, 0xc3c, sbExtend}, {0xc3d, 0xc3d, sbOLetter}, {0xc3e, 0xc44, sbExtend},
+	{0xc46, 0xc48, sbExtend}, {0xc4a, 0xc4d, sbExtend}, {0xc55, 0xc56, sbExtend}, {0xc58, 0xc5a, sbOLetter},
+	{0xc5c, 0xc5d, sbOLetter}, {0xc60, 0xc61, sbOLetter}, {0xc62, 0xc63, sbExtend}, {0xc66, 0xc6f, sbNumeric},
+	{0xc80, 0xc80, sbOLetter}, {0xc81, 0xc83, sbExtend}, {0xc85, 0xc8c, sbOLetter}, {0xc8e, 0xc90, sbOLetter},
+	{0xc92, 0xca8, sbOLetter}, {0xcaa, 0xcb3, sbOLetter}, {0xcb5, 0xcb9, sbOLetter}, {0xcbc, 0xcbc, sbExtend},
+	{0xcbd, 0xcbd, sbOLetter}, {0xcbe, 0xcc4, sbExtend}, {0xcc6, 0xcc8, sbExtend}, {0xcca, 0xccd, sbExtend},
+	{0xcd5, 0xcd6, sbExtend}, {0xcdc, 0xcde, sbOLetter}, {0xce0, 0xce1, sbOLetter}, {0xce2, 0xce3, sbExtend},
+	{0xce6, 0xcef, sbNumeric}, {0xcf1, 0xcf2, sbOLetter}, {0xcf3, 0xcf3, sbExtend}, {0xd00, 0xd03, sbExtend},
+	{0xd04, 0xd0c, sbOLetter}, {0xd0e, 0xd10, sbOLetter}, {0xd12, 0xd3a, sbOLetter}, {0xd3b, 0xd3c, sbExtend},
+	{0xd3d, 0xd3d, sbOLetter}, {0xd3e, 0xd44, sbExtend}, {0xd46, 0xd48, sbExtend}, {0xd4a, 0xd4d, sbExtend},
+	{0xd4e, 0xd4e, sbOLetter}, {0xd54, 0xd56, sbOLetter}, {0xd57, 0xd57, sbExtend}, {0xd5f, 0xd61, sbOLetter},
+	{0xd62, 0xd63, sbExtend}, {0xd66, 0xd6f, sbNumeric}, {0xd7a, 0xd7f, sbOLetter}, {0xd81, 0xd83, sbExtend},
+	{0xd85, 0xd96, sbOLetter}, {0xd9a, 0xdb1, sbOLetter}, {0xdb3, 0xdbb, sbOLetter}, {0xdbd, 0xdbd, sbOLetter},
+	{0xdc0, 0xdc6, sbOLetter}, {0xdca, 0xdca, sbExtend}, {0xdcf, 0xdd4, sbExtend}, {0xdd6, 0xdd6, sbExtend},
+	{0xdd8, 0xddf, sbExtend}, {0xde6, 0xdef, sbNumeric}, {0xdf2, 0xdf3, sbExtend}, {0xe01, 0xe30, sbOLetter},
+	{0xe31, 0xe31, sbExtend}, {0xe32, 0xe33, sbOLetter}, {0xe34, 0xe3a, sbExtend}, {0xe40, 0xe46, sbOLetter},
+	{0xe47, 0xe4e, sbExtend}, {0xe50, 0xe59, sbNumeric}, {0xe81, 0xe82, sbOLetter}, {0xe84, 0xe84, sbOLetter},
+	{0xe86, 0xe8a, sbOLetter}, {0xe8c, 0xea3, sbOLetter}, {0xea5, 0xea5, sbOLetter}, {0xea7, 0xeb0, sbOLetter},
+	{0xeb1, 0xeb1, sbExtend}, {0xeb2, 0xeb3, sbOLetter}, {0xeb4, 0xebc, sbExtend}, {0xebd, 0xebd, sbOLetter},
+	{0xec0, 0xec4, sbOLetter}, {0xec6, 0xec6, sbOLetter}, {0xec8, 0xece, sbExtend}, {0xed0, 0xed9, sbNumeric},
+	{0xedc, 0xedf, sbOLetter}, {0xf00, 0xf00, sbOLetter}, {0xf18, 0xf19, sbExtend}, {0xf20, 0xf29, sbNumeric},
+	{0xf35, 0xf35, sbExtend}, {0xf37, 0xf37, sbExtend}, {0xf39, 0xf39, sbExtend}, {0xf3a, 0xf3d, sbClose},
+	{0xf3e, 0xf3f, sbExtend}, {0xf40, 0xf47, sbOLetter}, {0xf49, 0xf6c, sbOLetter}, {0xf71, 0xf84, sbExtend},
+	{0xf86, 0xf87, sbExtend}, {0xf88, 0xf8c, sbOLetter}, {0xf8d, 0xf97, sbExtend}, {0xf99, 0xfbc, sbExtend},
+	{0xfc6, 0xfc6, sbExtend}, {0x1000, 0x102a, sbOLetter}, {0x102b, 0x103e, sbExtend}, {0x103f, 0x103f, sbOLetter},
+	{0x1040, 0x1049, sbNumeric}, {0x104a, 0x104b, sbSTerm}, {0x1050, 0x1055, sbOLetter}, {0x1056, 0x1059, sbExtend},
+	{0x105a, 0x105d, sbOLetter}, {0x105e, 0x1060, sbExtend}, {0x1061, 0x1061, sbOLetter}, {0x1062, 0x1064, sbExtend},
+	{0x1065, 0x1066, sbOLetter}, {0x1067, 0x106d, sbExtend}, {0x106e, 0x1070, sbOLetter}, {0x1071, 0x1074, sbExtend},
+	{0x1075, 0x1081, sbOLetter}, {0x1082, 0x108d, sbExtend}, {0x108e, 0x108e, sbOLetter}, {0x108f, 0x108f, sbExtend},
+	{0x1090, 0x1099, sbNumeric}, {0x109a, 0x109d, sbExtend}, {0x10a0, 0x10c5, sbUpper}, {0x10c7, 0x10c7, sbUpper},
+	{0x10cd, 0x10cd, sbUpper}, {0x10d0, 0x10fa, sbOLetter}, {0x10fc, 0x10fc, sbLower}, {0x10fd, 0x1248, sbOLetter},
+	{0x124a, 0x124d, sbOLetter}, {0x1250, 0x1256, sbOLetter}, {0x1258, 0x1258, sbOLetter}, {0x125a, 0x125d, sbOLetter},
+	{0x1260, 0x1288, sbOLetter}, {0x128a, 0x128d, sbOLetter}, {0x1290, 0x12b0, sbOLetter}, {0x12b2, 0x12b5, sbOLetter},
+	{0x12b8, 0x12be, sbOLetter}, {0x12c0, 0x12c0, sbOLetter}, {0x12c2, 0x12c5, sbOLetter}, {0x12c8, 0x12d6, sbOLetter},
+	{0x12d8, 0x1310, sbOLetter}, {0x1312, 0x1315, sbOLetter}, {0x1318, 0x135a, sbOLetter}, {0x135d, 0x135f, sbExtend},
+	{0x1362, 0x1362, sbSTerm}, {0x1367, 0x1368, sbSTerm}, {0x1380, 0x138f, sbOLetter}, {0x13a0, 0x13f5, sbUpper},
+	{0x13f8, 0x13fd, sbLower}, {0x1401, 0x166c, sbOLetter}, {0x166e, 0x166e, sbSTerm}, {0x166f, 0x167f, sbOLetter},
+	{0x1680, 0x1680, sbSp}, {0x1681, 0x169a, sbOLetter}, {0x169b, 0x169c, sbClose}, {0x16a0, 0x16ea, sbOLetter},
+	{0x16ee, 0x16f8, sbOLetter}, {0x1700, 0x1711, sbOLetter}, {0x1712, 0x1715, sbExtend}, {0x171f, 0x1731, sbOLetter},
+	{0x1732, 0x1734, sbExtend}, {0x1735, 0x1736, sbSTerm}, {0x1740, 0x1751, sbOLetter}, {0x1752, 0x1753, sbExtend},
+	{0x1760, 0x176c, sbOLetter}, {0x176e, 0x1770, sbOLetter}, {0x1772, 0x1773, sbExtend}, {0x1780, 0x17b3, sbOLetter},
+	{0x17b4, 0x17d3, sbExtend}, {0x17d4, 0x17d5, sbSTerm}, {0x17d7, 0x17d7, sbOLetter}, {0x17dc, 0x17dc, sbOLetter},
+	{0x17dd, 0x17dd, sbExtend}, {0x17e0, 0x17e9, sbNumeric}, {0x1802, 0x1802, sbSContinue}, {0x1803, 0x1803, sbSTerm},
+	{0x1808, 0x1808, sbSContinue}, {0x1809, 0x1809, sbSTerm}, {0x180b, 0x180d, sbExtend}, {0x180e, 0x180e, sbFormat},
+	{0x180f, 0x180f, sbExtend}, {0x1810, 0x1819, sbNumeric}, {0x1820, 0x1878, sbOLetter}, {0x1880, 0x1884, sbOLetter},
+	{0x1885, 0x1886, sbExtend}, {0x1887, 0x18a8, sbOLetter}, {0x18a9, 0x18a9, sbExtend}, {0x18aa, 0x18aa, sbOLetter},
+	{0x18b0, 0x18f5, sbOLetter}, {0x1900, 0x191e, sbOLetter}, {0x1920, 0x192b, sbExtend}, {0x1930, 0x193b, sbExtend},
+	{0x1944, 0x1945, sbSTerm}, {0x1946, 0x194f, sbNumeric}, {0x1950, 0x196d, sbOLetter}, {0x1970, 0x1974, sbOLetter},
+	{0x1980, 0x19ab, sbOLetter}, {0x19b0, 0x19c9, sbOLetter}, {0x19d0, 0x19da, sbNumeric}, {0x1a00, 0x1a16, sbOLetter},
+	{0x1a17, 0x1a1b, sbExtend}, {0x1a20, 0x1a54, sbOLetter}, {0x1a55, 0x1a5e, sbExtend}, {0x1a60, 0x1a7c, sbExtend},
+	{0x1a7f, 0x1a7f, sbExtend}, {0x1a80, 0x1a89, sbNumeric}, {0x1a90, 0x1a99, sbNumeric}, {0x1aa7, 0x1aa7, sbOLetter},
+	{0x1aa8, 0x1aab, sbSTerm}, {0x1ab0, 0x1add, sbExtend}, {0x1ae0, 0x1aeb, sbExtend}, {0x1b00, 0x1b04, sbExtend},
+	{0x1b05, 0x1b33, sbOLetter}, {0x1b34, 0x1b44, sbExtend}, {0x1b45, 0x1b4c, sbOLetter}, {0x1b4e, 0x1b4f, sbSTerm},
+	{0x1b50, 0x1b59, sbNumeric}, {0x1b5a, 0x1b5b, sbSTerm}, {0x1b5e, 0x1b5f, sbSTerm}, {0x1b6b, 0x1b73, sbExtend},
+	{0x1b7d, 0x1b7f, sbSTerm}, {0x1b80, 0x1b82, sbExtend}, {0x1b83, 0x1ba0, sbOLetter}, {0x1ba1, 0x1bad, sbExtend},
+	{0x1bae, 0x1baf, sbOLetter}, {0x1bb0, 0x1bb9, sbNumeric}, {0x1bba, 0x1be5, sbOLetter}, {0x1be6, 0x1bf3, sbExtend},
+	{0x1c00, 0x1c23, sbOLetter}, {0x1c24, 0x1c37, sbExtend}, {0x1c3b, 0x1c3c, sbSTerm}, {0x1c40, 0x1c49, sbNumeric},
+	{0x1c4d, 0x1c4f, sbOLetter}, {0x1c50, 0x1c59, sbNumeric}, {0x1c5a, 0x1c7d, sbOLetter}, {0x1c7e, 0x1c7f, sbSTerm},
+	{0x1c80, 0x1c88, sbLower}, {0x1c89, 0x1c89, sbUpper}, {0x1c8a, 0x1c8a, sbLower}, {0x1c90, 0x1cba, sbOLetter},
+	{0x1cbd, 0x1cbf, sbOLetter}, {0x1cd0, 0x1cd2, sbExtend}, {0x1cd4, 0x1ce8, sbExtend}, {0x1ce9, 0x1cec, sbOLetter},
+	{0x1ced, 0x1ced, sbExtend}, {0x1cee, 0x1cf3, sbOLetter}, {0x1cf4, 0x1cf4, sbExtend}, {0x1cf5, 0x1cf6, sbOLetter},
+	{0x1cf7, 0x1cf9, sbExtend}, {0x1cfa, 0x1cfa, sbOLetter}, {0x1d00, 0x1dbf, sbLower}, {0x1dc0, 0x1dff, sbExtend},
+	{0x1e00, 0x1e00, sbUpper}, {0x1e01, 0x1e01, sbLower}, {0x1e02, 0x1e02, sbUpper}, {0x1e03, 0x1e03, sbLower},
+	{0x1e04, 0x1e04, sbUpper}, {0x1e05, 0x1e05, sbLower}, {0x1e06, 0x1e06, sbUpper}, {0x1e07, 0x1e07, sbLower},
+	{0x1e08, 0x1e08, sbUpper}, {0x1e09, 0x1e09, sbLower}, {0x1e0a, 0x1e0a, sbUpper}, {0x1e0b, 0x1e0b, sbLower},
+	{0x1e0c, 0x1e0c, sbUpper}, {0x1e0d, 0x1e0d, sbLower}, {0x1e0e, 0x1e0e, sbUpper}, {0x1e0f, 0x1e0f, sbLower},
+	{0x1e10, 0x1e10, sbUpper}, {0x1e11, 0x1e11, sbLower}, {0x1e12, 0x1e12, sbUpper}, {0x1e13, 0x1e13, sbLower},
+	{0x1e14, 0x1e14, sbUpper}, {0x1e15, 0x1e15, sbLower}, {0x1e16, 0x1e16, sbUpper}, {0x1e17, 0x1e17, sbLower},
+	{0x1e18, 0x1e18, sbUpper}, {0x1e19, 0x1e19, sbLower}, {0x1e1a, 0x1e1a, sbUpper}, {0x1e1b, 0x1e1b, sbLower},
+	{0x1e1c, 0x1e1c, sbUpper}, {0x1e1d, 0x1e1d, sbLower}, {0x1e1e, 0x1e1e, sbUpper}, {0x1e1f, 0x1e1f, sbLower},
+	{0x1e20, 0x1e20, sbUpper}, {0x1e21, 0x1e21, sbLower}, {0x1e22, 0x1e22, sbUpper}, {0x1e23, 0x1e23, sbLower},
+	{0x1e24, 0x1e24, sbUpper}, {0x1e25, 0x1e25, sbLower}, {0x1e26, 0x1e26, sbUpper}, {0x1e27, 0x1e27, sbLower},
+	{0x1e28, 0x1e28, sbUpper}, {0x1e29, 0x1e29, sbLower}, {0x1e2a, 0x1e2a, sbUpper}, {0x1e2b, 0x1e2b, sbLower},
+	{0x1e2c, 0x1e2c, sbUpper}, {0x1e2d, 0x1e2d, sbLower}, {0x1e2e, 0x1e2e, sbUpper}, {0x1e2f, 0x1e2f, sbLower},
+	{0x1e30, 0x1e30, sbUpper}, {0x1e31, 0x1e31, sbLower}, {0x1e32, 0x1e32, sbUpper}, {0x1e33, 0x1e33, sbLower},
+	{0x1e34, 0x1e34, sbUpper}, {0x1e35, 0x1e35, sbLower}, {0x1e36, 0x1e36, sbUpper}, {0x1e37, 0x1e37, sbLower},
+	{0x1e38, 0x1e38, sbUpper}, {0x1e39, 0x1e39, sbLower}, {0x1e3a, 0x1e3a, sbUpper}, {0x1e3b, 0x1e3b, sbLower},
+	{0x1e3c, 0x1e3c, sbUpper}, {0x1e3d, 0x1e3d, sbLower}, {0x1e3e, 0x1e3e, sbUpper}, {0x1e3f, 0x1e3f, sbLower},
+	{0x1e40, 0x1e40, sbUpper}, {0x1e41, 0x1e41, sbLower}, {0x1e42, 0x1e42, sbUpper}, {0x1e43, 0x1e43, sbLower},
+	{0x1e44, 0x1e44, sbUpper}, {0x1e45, 0x1e45, sbLower}, {0x1e46, 0x1e46, sbUpper}, {0x1e47, 0x1e47, sbLower},
+	{0x1e48, 0x1e48, sbUpper}, {0x1e49, 0x1e49, sbLower}, {0x1e4a, 0x1e4a, sbUpper}, {0x1e4b, 0x1e4b, sbLower},
+	{0x1e4c, 0x1e4c, sbUpper}, {0x1e4d, 0x1e4d, sbLower}, {0x1e4e, 0x1e4e, sbUpper}, {0x1e4f, 0x1e4f, sbLower},
+	{0x1e50, 0x1e50, sbUpper}, {0x1e51, 0x1e51, sbLower}, {0x1e52, 0x1e52, sbUpper}, {0x1e53, 0x1e53, sbLower},
+	{0x1e54, 0x1e54, sbUpper}, {0x1e55, 0x1e55, sbLower}, {0x1e56, 0x1e56, sbUpper}, {0x1e57, 0x1e57, sbLower},
+	{0x1e58, 0x1e58, sbUpper}, {0x1e59, 0x1e59, sbLower}, {0x1e5a, 0x1e5a, sbUpper}, {0x1e5b, 0x1e5b, sbLower},
+	{0x1e5c, 0x1e5c, sbUpper}, {0x1e5d, 0x1e5d, sbLower}, {0x1e5e, 0x1e5e, sbUpper}, {0x1e5f, 0x1e5f, sbLower},
+	{0x1e60, 0x1e60, sbUpper}, {0x1e61, 0x1e61, sbLower}, {0x1e62, 0x1e62, sbUpper}, {0x1e63, 0x1e63, sbLower},
+	{0x1e64, 0x1e64, sbUpper}, {0x1e65, 0x1e65, sbLower}, {0x1e66, 0x1e66, sbUpper}, {0x1e67, 0x1e67, sbLower},
+	{0x1e68, 0x1e68, sbUpper}, {0x1e69, 0x1e69, sbLower}, {0x1e6a, 0x1e6a, sbUpper}, {0x1e6b, 0x1e6b, sbLower},
+	{0x1e6c, 0x1e6c, sbUpper}, {0x1e6d, 0x1e6d, sbLower}, {0x1e6e, 0x1e6e, sbUpper}, {0x1e6f, 0x1e6f, sbLower},
+	{0x1e70, 0x1e70, sbUpper}, {0x1e71, 0x1e71, sbLower}, {0x1e72, 0x1e72, sbUpper}, {0x1e73, 0x1e73, sbLower},
+	{0x1e74, 0x1e74, sbUpper}, {0x1e75, 0x1e75, sbLower}, {0x1e76, 0x1e76, sbUpper}, {0x1e77, 0x1e77, sbLower},
+	{0x1e78, 0x1e78, sbUpper}, {0x1e79, 0x1e79, sbLower}, {0x1e7a, 0x1e7a, sbUpper}, {0x1e7b, 0x1e7b, sbLower},
+	{0x1e7c, 0x1e7c, sbUpper}, {0x1e7d, 0x1e7d, sbLower}, {0x1e7e, 0x1e7e, sbUpper}, {0x1e7f, 0x1e7f, sbLower},
+	{0x1e80, 0x1e80, sbUpper}, {0x1e81, 0x1e81, sbLower}, {0x1e82, 0x1e82, sbUpper}, {0x1e83, 0x1e83, sbLower},
+	{0x1e84, 0x1e84, sbUpper}, {0x1e85, 0x1e85, sbLower}, {0x1e86, 0x1e86, sbUpper}, {0x1e87, 0x1e87, sbLower},
+	{0x1e88, 0x1e88, sbUpper}, {0x1e89, 0x1e89, sbLower}, {0x1e8a, 0x1e8a, sbUpper}, {0x1e8b, 0x1e8b, sbLower},
+	{0x1e8c, 0x1e8c, sbUpper}, {0x1e8d, 0x1e8d, sbLower}, {0x1e8e, 0x1e8e, sbUpper}, {0x1e8f, 0x1e8f, sbLower},
+	{0x1e90, 0x1e90, sbUpper}, {0x1e91, 0x1e91, sbLower}, {0x1e92, 0x1e92, sbUpper}, {0x1e93, 0x1e93, sbLower},
+	{0x1e94, 0x1e94, sbUpper}, {0x1e95, 0x1e9d, sbLower}, {0x1e9e, 0x1e9e, sbUpper}, {0x1e9f, 0x1e9f, sbLower},
+	{0x1ea0, 0x1ea0, sbUpper}, {0x1ea1, 0x1ea1, sbLower}, {0x1ea2, 0x1ea2, sbUpper}, {0x1ea3, 0x1ea3, sbLower},
+	{0x1ea4, 0x1ea4, sbUpper}, {0x1ea5, 0x1ea5, sbLower}, {0x1ea6, 0x1ea6, sbUpper}, {0x1ea7, 0x1ea7, sbLower},
+	{0x1ea8, 0x1ea8, sbUpper}, {0x1ea9, 0x1ea9, sbLower}, {0x1eaa, 0x1eaa, sbUpper}, {0x1eab, 0x1eab, sbLower},
+	{0x1eac, 0x1eac, sbUpper}, {0x1ead, 0x1ead, sbLower}, {0x1eae, 0x1eae, sbUpper}, {0x1eaf, 0x1eaf, sbLower},
+	{0x1eb0, 0x1eb0, sbUpper}, {0x1eb1, 0x1eb1, sbLower}, {0x1eb2, 0x1eb2, sbUpper}, {0x1eb3, 0x1eb3, sbLower},
+	{0x1eb4, 0x1eb4, sbUpper}, {0x1eb5, 0x1eb5, sbLower}, {0x1eb6, 0x1eb6, sbUpper}, {0x1eb7, 0x1eb7, sbLower},
+	{0x1eb8, 0x1eb8, sbUpper}, {0x1eb9, 0x1eb9, sbLower}, {0x1eba, 0x1eba, sbUpper}, {0x1ebb, 0x1ebb, sbLower},
+	{0x1ebc, 0x1ebc, sbUpper}, {0x1ebd, 0x1ebd, sbLower}, {0x1ebe, 0x1ebe, sbUpper}, {0x1ebf, 0x1ebf, sbLower},
+	{0x1ec0, 0x1ec0, sbUpper}, {0x1ec1, 0x1ec1, sbLower}, {0x1ec2, 0x1ec2, sbUpper}, {0x1ec3, 0x1ec3, sbLower},
+	{0x1ec4, 0x1ec4, sbUpper}, {0x1ec5, 0x1ec5, sbLower}, {0x1ec6, 0x1ec6, sbUpper}, {0x1ec7, 0x1ec7, sbLower},
+	{0x1ec8, 0x1ec8, sbUpper}, {0x1ec9, 0x1ec9, sbLower}, {0x1eca, 0x1eca, sbUpper}, {0x1ecb, 0x1ecb, sbLower},
+	{0x1ecc, 0x1ecc, sbUpper}, {0x1ecd, 0x1ecd, sbLower}, {0x1ece, 0x1ece, sbUpper}, {0x1ecf, 0x1ecf, sbLower},
+	{0x1ed0, 0x1ed0, sbUpper}, {0x1ed1, 0x1ed1, sbLower}, {0x1ed2, 0x1ed2, sbUpper}, {0x1ed3, 0x1ed3, sbLower},
+	{0x1ed4, 0x1ed4, sbUpper}, {0x1ed5, 0x1ed5, sbLower}, {0x1ed6, 0x1ed6, sbUpper}, {0x1ed7, 0x1ed7, sbLower},
+	{0x1ed8, 0x1ed8, sbUpper}, {0x1ed9, 0x1ed9, sbLower}, {0x1eda, 0x1eda, sbUpper}, {0x1edb, 0x1edb, sbLower},
+	{0x1edc, 0x1edc, sbUpper}, {0x1edd, 0x1edd, sbLower}, {0x1ede, 0x1ede, sbUpper}, {0x1edf, 0x1edf, sbLower},
+	{0x1ee0, 0x1ee0, sbUpper}, {0x1ee1, 0x1ee1, sbLower}, {0x1ee2, 0x1ee2, sbUpper}, {0x1ee3, 0x1ee3, sbLower},
+	{0x1ee4, 0x1ee4, sbUpper}, {0x1ee5, 0x1ee5, sbLower}, {0x1ee6, 0x1ee6, sbUpper}, {0x1ee7, 0x1ee7, sbLower},
+	{0x1ee8, 0x1ee8, sbUpper}, {0x1ee9, 0x1ee9, sbLower}, {0x1eea, 0x1eea, sbUpper}, {0x1eeb, 0x1eeb, sbLower},
+	{0x1eec, 0x1eec, sbUpper}, {0x1eed, 0x1eed, sbLower}, {0x1eee, 0x1eee, sbUpper}, {0x1eef, 0x1eef, sbLower},
+	{0x1ef0, 0x1ef0, sbUpper}, {0x1ef1, 0x1ef1, sbLower}, {0x1ef2, 0x1ef2, sbUpper}, {0x1ef3, 0x1ef3, sbLower},
+	{0x1ef4, 0x1ef4, sbUpper}, {0x1ef5, 0x1ef5, sbLower}, {0x1ef6, 0x1ef6, sbUpper}, {0x1ef7, 0x1ef7, sbLower},
+	{0x1ef8, 0x1ef8, sbUpper}, {0x1ef9, 0x1ef9, sbLower}, {0x1efa, 0x1efa, sbUpper}, {0x1efb, 0x1efb, sbLower},
+	{0x1efc, 0x1efc, sbUpper}, {0x1efd, 0x1efd, sbLower}, {0x1efe, 0x1efe, sbUpper}, {0x1eff, 0x1f07, sbLower},
+	{0x1f08, 0x1f0f, sbUpper}, {0x1f10, 0x1f15, sbLower}, {0x1f18, 0x1f1d, sbUpper}, {0x1f20, 0x1f27, sbLower},
+	{0x1f28, 0x1f2f, sbUpper}, {0x1f30, 0x1f37, sbLower}, {0x1f38, 0x1f3f, sbUpper}, {0x1f40, 0x1f45, sbLower},
+	{0x1f48, 0x1f4d, sbUpper}, {0x1f50, 0x1f57, sbLower}, {0x1f59, 0x1f59, sbUpper}, {0x1f5b, 0x1f5b, sbUpper},
+	{0x1f5d, 0x1f5d, sbUpper}, {0x1f5f, 0x1f5f, sbUpper}, {0x1f60, 0x1f67, sbLower}, {0x1f68, 0x1f6f, sbUpper},
+	{0x1f70, 0x1f7d, sbLower}, {0x1f80, 0x1f87, sbLower}, {0x1f88, 0x1f8f, sbUpper}, {0x1f90, 0x1f97, sbLower},
+	{0x1f98, 0x1f9f, sbUpper}, {0x1fa0, 0x1fa7, sbLower}, {0x1fa8, 0x1faf, sbUpper}, {0x1fb0, 0x1fb4, sbLower},
+	{0x1fb6, 0x1fb7, sbLower}, {0x1fb8, 0x1fbc, sbUpper}, {0x1fbe, 0x1fbe, sbLower}, {0x1fc2, 0x1fc4, sbLower},
+	{0x1fc6, 0x1fc7, sbLower}, {0x1fc8, 0x1fcc, sbUpper}, {0x1fd0, 0x1fd3, sbLower}, {0x1fd6, 0x1fd7, sbLower},
+	{0x1fd8, 0x1fdb, sbUpper}, {0x1fe0, 0x1fe7, sbLower}, {0x1fe8, 0x1fec, sbUpper}, {0x1ff2, 0x1ff4, sbLower},
+	{0x1ff6, 0x1ff7, sbLower}, {0x1ff8, 0x1ffc, sbUpper}, {0x2000, 0x200a, sbSp}, {0x200b, 0x200b, sbFormat},
+	{0x200c, 0x200d, sbExtend}, {0x200e, 0x200f, sbFormat}, {0x2013, 0x2014, sbSContinue}, {0x2018, 0x201f, sbClose},
+	{0x2024, 0x2024, sbATerm}, {0x2028, 0x2029, sbSep}, {0x202a, 0x202e, sbFormat}, {0x202f, 0x202f, sbSp},
+	{0x2039, 0x203a, sbClose}, {0x203c, 0x203d, sbSTerm}, {0x2045, 0x2046, sbClose}, {0x2047, 0x2049, sbSTerm},
+	{0x205f, 0x205f, sbSp}, {0x2060, 0x2064, sbFormat}, {0x2066, 0x206f, sbFormat}, {0x2071, 0x2071, sbLower},
+	{0x207d, 0x207e, sbClose}, {0x207f, 0x207f, sbLower}, {0x208d, 0x208e, sbClose}, {0x2090, 0x209c, sbLower},
+	{0x20d0, 0x20f0, sbExtend}, {0x2102, 0x2102, sbUpper}, {0x2107, 0x2107, sbUpper}, {0x210a, 0x210a, sbLower},
+	{0x210b, 0x210d, sbUpper}, {0x210e, 0x210f, sbLower}, {0x2110, 0x2112, sbUpper}, {0x2113, 0x2113, sbLower},
+	{0x2115, 0x2115, sbUpper}, {0x2119, 0x211d, sbUpper}, {0x2124, 0x2124, sbUpper}, {0x2126, 0x2126, sbUpper},
+	{0x2128, 0x2128, sbUpper}, {0x212a, 0x212d, sbUpper}, {0x212f, 0x212f, sbLower}, {0x2130, 0x2133, sbUpper},
+	{0x2134, 0x2134, sbLower}, {0x2135, 0x2138, sbOLetter}, {0x2139, 0x2139, sbLower}, {0x213c, 0x213d, sbLower},
+	{0x213e, 0x213f, sbUpper}, {0x2145, 0x2145, sbUpper}, {0x2146, 0x2149, sbLower}, {0x214e, 0x214e, sbLower},
+	{0x2160, 0x216f, sbUpper}, {0x2170, 0x217f, sbLower}, {0x2180, 0x2182, sbOLetter}, {0x2183, 0x2183, sbUpper},
+	{0x2184, 0x2184, sbLower}, {0x2185, 0x2188, sbOLetter}, {0x2308, 0x230b, sbClose}, {0x2329, 0x232a, sbClose},
+	{0x24b6, 0x24cf, sbUpper}, {0x24d0, 0x24e9, sbLower}, {0x275b, 0x2760, sbClose}, {0x2768, 0x2775, sbClose},
+	{0x27c5, 0x27c6, sbClose}, {0x27e6, 0x27ef, sbClose}, {0x2983, 0x2998, sbClose}, {0x29d8, 0x29db, sbClose},
+	{0x29fc, 0x29fd, sbClose}, {0x2c00, 0x2c2f, sbUpper}, {0x2c30, 0x2c5f, sbLower}, {0x2c60, 0x2c60, sbUpper},
+	{0x2c61, 0x2c61, sbLower}, {0x2c62, 0x2c64, sbUpper}, {0x2c65, 0x2c66, sbLower}, {0x2c67, 0x2c67, sbUpper},
+	{0x2c68, 0x2c68, sbLower}, {0x2c69, 0x2c69, sbUpper}, {0x2c6a, 0x2c6a, sbLower}, {0x2c6b, 0x2c6b, sbUpper},
+	{0x2c6c, 0x2c6c, sbLower}, {0x2c6d, 0x2c70, sbUpper}, {0x2c71, 0x2c71, sbLower}, {0x2c72, 0x2c72, sbUpper},
+	{0x2c73, 0x2c74, sbLower}, {0x2c75, 0x2c75, sbUpper}, {0x2c76, 0x2c7d, sbLower}, {0x2c7e, 0x2c80, sbUpper},
+	{0x2c81, 0x2c81, sbLower}, {0x2c82, 0x2c82, sbUpper}, {0x2c83, 0x2c83, sbLower}, {0x2c84, 0x2c84, sbUpper},
+	{0x2c85, 0x2c85, sbLower}, {0x2c86, 0x2c86, sbUpper}, {0x2c87, 0x2c87, sbLower}, {0x2c88, 0x2c88, sbUpper},
+	{0x2c89, 0x2c89, sbLower}, {0x2c8a, 0x2c8a, sbUpper}, {0x2c8b, 0x2c8b, sbLower}, {0x2c8c, 0x2c8c, sbUpper},
+	{0x2c8d, 0x2c8d, sbLower}, {0x2c8e, 0x2c8e, sbUpper}, {0x2c8f, 0x2c8f, sbLower}, {0x2c90, 0x2c90, sbUpper},
+	{0x2c91, 0x2c91, sbLower}, {0x2c92, 0x2c92, sbUpper}, {0x2c93, 0x2c93, sbLower}, {0x2c94, 0x2c94, sbUpper},
+	{0x2c95, 0x2c95, sbLower}, {0x2c96, 0x2c96, sbUpper}, {0x2c97, 0x2c97, sbLower}, {0x2c98, 0x2c98, sbUpper},
+	{0x2c99, 0x2c99, sbLower}, {0x2c9a, 0x2c9a, sbUpper}, {0x2c9b, 0x2c9b, sbLower}, {0x2c9c, 0x2c9c, sbUpper},
+	{0x2c9d, 0x2c9d, sbLower}, {0x2c9e, 0x2c9e, sbUpper}, {0x2c9f, 0x2c9f, sbLower}, {0x2ca0, 0x2ca0, sbUpper},
+	{0x2ca1, 0x2ca1, sbLower}, {0x2ca2, 0x2ca2, sbUpper}, {0x2ca3, 0x2ca3, sbLower}, {0x2ca4, 0x2ca4, sbUpper},
+	{0x2ca5, 0x2ca5, sbLower}, {0x2ca6, 0x2ca6, sbUpper}, {0x2ca7, 0x2ca7, sbLower}, {0x2ca8, 0x2ca8, sbUpper},
+	{0x2ca9, 0x2ca9, sbLower}, {0x2caa, 0x2caa, sbUpper}, {0x2cab, 0x2cab, sbLower}, {0x2cac, 0x2cac, sbUpper},
+	{0x2cad, 0x2cad, sbLower}, {0x2cae, 0x2cae, sbUpper}, {0x2caf, 0x2caf, sbLower}, {0x2cb0, 0x2cb0, sbUpper},
+	{0x2cb1, 0x2cb1, sbLower}, {0x2cb2, 0x2cb2, sbUpper}, {0x2cb3, 0x2cb3, sbLower}, {0x2cb4, 0x2cb4, sbUpper},
+	{0x2cb5, 0x2cb5, sbLower}, {0x2cb6, 0x2cb6, sbUpper}, {0x2cb7, 0x2cb7, sbLower}, {0x2cb8, 0x2cb8, sbUpper},
+	{0x2cb9, 0x2cb9, sbLower}, {0x2cba, 0x2cba, sbUpper}, {0x2cbb, 0x2cbb, sbLower}, {0x2cbc, 0x2cbc, sbUpper},
+	{0x2cbd, 0x2cbd, sbLower}, {0x2cbe, 0x2cbe, sbUpper}, {0x2cbf, 0x2cbf, sbLower}, {0x2cc0, 0x2cc0, sbUpper},
+	{0x2cc1, 0x2cc1, sbLower}, {0x2cc2, 0x2cc2, sbUpper}, {0x2cc3, 0x2cc3, sbLower}, {0x2cc4, 0x2cc4, sbUpper},
+	{0x2cc5, 0x2cc5, sbLower}, {0x2cc6, 0x2cc6, sbUpper}, {0x2cc7, 0x2cc7, sbLower}, {0x2cc8, 0x2cc8, sbUpper},
+	{0x2cc9, 0x2cc9, sbLower}, {0x2cca, 0x2cca, sbUpper}, {0x2ccb, 0x2ccb, sbLower}, {0x2ccc, 0x2ccc, sbUpper},
+	{0x2ccd, 0x2ccd, sbLower}, {0x2cce, 0x2cce, sbUpper}, {0x2ccf, 0x2ccf, sbLower}, {0x2cd0, 0x2cd0, sbUpper},
+	{0x2cd1, 0x2cd1, sbLower}, {0x2cd2, 0x2cd2, sbUpper}, {0x2cd3, 0x2cd3, sbLower}, {0x2cd4, 0x2cd4, sbUpper},
+	{0x2cd5, 0x2cd5, sbLower}, {0x2cd6, 0x2cd6, sbUpper}, {0x2cd7, 0x2cd7, sbLower}, {0x2cd8, 0x2cd8, sbUpper},
+	{0x2cd9, 0x2cd9, sbLower}, {0x2cda, 0x2cda, sbUpper}, {0x2cdb, 0x2cdb, sbLower}, {0x2cdc, 0x2cdc, sbUpper},
+	{0x2cdd, 0x2cdd, sbLower}, {0x2cde, 0x2cde, sbUpper}, {0x2cdf, 0x2cdf, sbLower}, {0x2ce0, 0x2ce0, sbUpper},
+	{0x2ce1, 0x2ce1, sbLower}, {0x2ce2, 0x2ce2, sbUpper}, {0x2ce3, 0x2ce4, sbLower}, {0x2ceb, 0x2ceb, sbUpper},
+	{0x2cec, 0x2cec, sbLower}, {0x2ced, 0x2ced, sbUpper}, {0x2cee, 0x2cee, sbLower}, {0x2cef, 0x2cf1, sbExtend},
+	{0x2cf2, 0x2cf2, sbUpper}, {0x2cf3, 0x2cf3, sbLower}, {0x2cf9, 0x2cfb, sbSTerm}, {0x2d00, 0x2d25, sbLower},
+	{0x2d27, 0x2d27, sbLower}, {0x2d2d, 0x2d2d, sbLower}, {0x2d30, 0x2d67, sbOLetter}, {0x2d6f, 0x2d6f, sbOLetter},
+	{0x2d7f, 0x2d7f, sbExtend}, {0x2d80, 0x2d96, sbOLetter}, {0x2da0, 0x2da6, sbOLetter}, {0x2da8, 0x2dae, sbOLetter},
+	{0x2db0, 0x2db6, sbOLetter}, {0x2db8, 0x2dbe, sbOLetter}, {0x2dc0, 0x2dc6, sbOLetter}, {0x2dc8, 0x2dce, sbOLetter},
+	{0x2dd0, 0x2dd6, sbOLetter}, {0x2dd8, 0x2dde, sbOLetter}, {0x2de0, 0x2dff, sbExtend}, {0x2e00, 0x2e0d, sbClose},
+	{0x2e1c, 0x2e1d, sbClose}, {0x2e20, 0x2e29, sbClose}, {0x2e2e, 0x2e2e, sbSTerm}, {0x2e2f, 0x2e2f, sbOLetter},
+	{0x2e3c, 0x2e3c, sbSTerm}, {0x2e42, 0x2e42, sbClose}, {0x2e53, 0x2e54, sbSTerm}, {0x2e55, 0x2e5c, sbClose},
+	{0x3000, 0x3000, sbSp}, {0x3001, 0x3001, sbSContinue}, {0x3002, 0x3002, sbSTerm}, {0x3005, 0x3007, sbOLetter},
+	{0x3008, 0x3011, sbClose}, {0x3014, 0x301b, sbClose}, {0x301d, 0x301f, sbClose}, {0x3021, 0x3029, sbOLetter},
+	{0x302a, 0x302f, sbExtend}, {0x3031, 0x3035, sbOLetter}, {0x3038, 0x303c, sbOLetter}, {0x3041, 0x3096, sbOLetter},
+	{0x3099, 0x309a, sbExtend}, {0x309d, 0x309f, sbOLetter}, {0x30a1, 0x30fa, sbOLetter}, {0x30fc, 0x30ff, sbOLetter},
+	{0x3105, 0x312f, sbOLetter}, {0x3131, 0x318e, sbOLetter}, {0x31a0, 0x31bf, sbOLetter}, {0x31f0, 0x31ff, sbOLetter},
+	{0x3400, 0x4dbf, sbOLetter}, {0x4e00, 0xa48c, sbOLetter}, {0xa4d0, 0xa4fd, sbOLetter}, {0xa4ff, 0xa4ff, sbSTerm},
+	{0xa500, 0xa60c, sbOLetter}, {0xa60e, 0xa60f, sbSTerm}, {0xa610, 0xa61f, sbOLetter}, {0xa620, 0xa629, sbNumeric},
+	{0xa62a, 0xa62b, sbOLetter}, {0xa640, 0xa640, sbUpper}, {0xa641, 0xa641, sbLower}, {0xa642, 0xa642, sbUpper},
+	{0xa643, 0xa643, sbLower}, {0xa644, 0xa644, sbUpper}, {0xa645, 0xa645, sbLower}, {0xa646, 0xa646, sbUpper},
+	{0xa647, 0xa647, sbLower}, {0xa648, 0xa648, sbUpper}, {0xa649, 0xa649, sbLower}, {0xa64a, 0xa64a, sbUpper},
+	{0xa64b, 0xa64b, sbLower}, {0xa64c, 0xa64c, sbUpper}, {0xa64d, 0xa64d, sbLower}, {0xa64e, 0xa64e, sbUpper},
+	{0xa64f, 0xa64f, sbLower}, {0xa650, 0xa650, sbUpper}, {0xa651, 0xa651, sbLower}, {0xa652, 0xa652, sbUpper},
+	{0xa653, 0xa653, sbLower}, {0xa654, 0xa654, sbUpper}, {0xa655, 0xa655, sbLower}, {0xa656, 0xa656, sbUpper},
+	{0xa657, 0xa657, sbLower}, {0xa658, 0xa658, sbUpper}, {0xa659, 0xa659, sbLower}, {0xa65a, 0xa65a, sbUpper},
+	{0xa65b, 0xa65b, sbLower}, {0xa65c, 0xa65c, sbUpper}, {0xa65d, 0xa65d, sbLower}, {0xa65e, 0xa65e, sbUpper},
+	{0xa65f, 0xa65f, sbLower}, {0xa660, 0xa660, sbUpper}, {0xa661, 0xa661, sbLower}, {0xa662, 0xa662, sbUpper},
+	{0xa663, 0xa663, sbLower}, {0xa664, 0xa664, sbUpper}, {0xa665, 0xa665, sbLower}, {0xa666, 0xa666, sbUpper},
+	{0xa667, 0xa667, sbLower}, {0xa668, 0xa668, sbUpper}, {0xa669, 0xa669, sbLower}, {0xa66a, 0xa66a, sbUpper},
+	{0xa66b, 0xa66b, sbLower}, {0xa66c, 0xa66c, sbUpper}, {0xa66d, 0xa66d, sbLower}, {0xa66e, 0xa66e, sbOLetter},
+	{0xa66f, 0xa672, sbExtend}, {0xa674, 0xa67d, sbExtend}, {0xa67f, 0xa67f, sbOLetter}, {0xa680, 0xa680, sbUpper},
+	{0xa681, 0xa681, sbLower}, {0xa682, 0xa682, sbUpper}, {0xa683, 0xa683, sbLower}, {0xa684, 0xa684, sbUpper},
+	{0xa685, 0xa685, sbLower}, {0xa686, 0xa686, sbUpper}, {0xa687, 0xa687, sbLower}, {0xa688, 0xa688, sbUpper},
+	{0xa689, 0xa689, sbLower}, {0xa68a, 0xa68a, sbUpper}, {0xa68b, 0xa68b, sbLower}, {0xa68c, 0xa68c, sbUpper},
+	{0xa68d, 0xa68d, sbLower}, {0xa68e, 0xa68e, sbUpper}, {0xa68f, 0xa68f, sbLower}, {0xa690, 0xa690, sbUpper},
+	{0xa691, 0xa691, sbLower}, {0xa692, 0xa692, sbUpper}, {0xa693, 0xa693, sbLower}, {0xa694, 0xa694, sbUpper},
+	{0xa695, 0xa695, sbLower}, {0xa696, 0xa696, sbUpper}, {0xa697, 0xa697, sbLower}, {0xa698, 0xa698, sbUpper},
+	{0xa699, 0xa699, sbLower}, {0xa69a, 0xa69a, sbUpper}, {0xa69b, 0xa69d, sbLower}, {0xa69e, 0xa69f, sbExtend},
+	{0xa6a0, 0xa6ef, sbOLetter}, {0xa6f0, 0xa6f1, sbExtend}, {0xa6f3, 0xa6f3, sbSTerm}, {0xa6f7, 0xa6f7, sbSTerm},
+	{0xa717, 0xa71f, sbOLetter}, {0xa722, 0xa722, sbUpper}, {0xa723, 0xa723, sbLower}, {0xa724, 0xa724, sbUpper},
+	{0xa725, 0xa725, sbLower}, {0xa726, 0xa726, sbUpper}, {0xa727, 0xa727, sbLower}, {0xa728, 0xa728, sbUpper},
+	{0xa729, 0xa729, sbLower}, {0xa72a, 0xa72a, sbUpper}, {0xa72b, 0xa72b, sbLower}, {0xa72c, 0xa72c, sbUpper},
+	{0xa72d, 0xa72d, sbLower}, {0xa72e, 0xa72e, sbUpper}, {0xa72f, 0xa731, sbLower}, {0xa732, 0xa732, sbUpper},
+	{0xa733, 0xa733, sbLower}, {0xa734, 0xa734, sbUpper}, {0xa735, 0xa735, sbLower}, {0xa736, 0xa736, sbUpper},
+	{0xa737, 0xa737, sbLower}, {0xa738, 0xa738, sbUpper}, {0xa739, 0xa739, sbLower}, {0xa73a, 0xa73a, sbUpper},
+	{0xa73b, 0xa73b, sbLower}, {0xa73c, 0xa73c, sbUpper}, {0xa73d, 0xa73d, sbLower}, {0xa73e, 0xa73e, sbUpper},
+	{0xa73f, 0xa73f, sbLower}, {0xa740, 0xa740, sbUpper}, {0xa741, 0xa741, sbLower}, {0xa742, 0xa742, sbUpper},
+	{0xa743, 0xa743, sbLower}, {0xa744, 0xa744, sbUpper}, {0xa745, 0xa745, sbLower}, {0xa746, 0xa746, sbUpper},
+	{0xa747, 0xa747, sbLower}, {0xa748, 0xa748, sbUpper}, {0xa749, 0xa749, sbLower}, {0xa74a, 0xa74a, sbUpper},
+	{0xa74b, 0xa74b, sbLower}, {0xa74c, 0xa74c, sbUpper}, {0xa74d, 0xa74d, sbLower}, {0xa74e, 0xa74e, sbUpper},
+	{0xa74f, 0xa74f, sbLower}, {0xa750, 0xa750, sbUpper}, {0xa751, 0xa751, sbLower}, {0xa752, 0xa752, sbUpper},
+	{0xa753, 0xa753, sbLower}, {0xa754, 0xa754, sbUpper}, {0xa755, 0xa755, sbLower}, {0xa756, 0xa756, sbUpper},
+	{0xa757, 0xa757, sbLower}, {0xa758, 0xa758, sbUpper}, {0xa759, 0xa759, sbLower}, {0xa75a, 0xa75a, sbUpper},
+	{0xa75b, 0xa75b, sbLower}, {0xa75c, 0xa75c, sbUpper}, {0xa75d, 0xa75d, sbLower}, {0xa75e, 0xa75e, sbUpper},
+	{0xa75f, 0xa75f, sbLower}, {0xa760, 0xa760, sbUpper}, {0xa761, 0xa761, sbLower}, {0xa762, 0xa762, sbUpper},
+	{0xa763, 0xa763, sbLower}, {0xa764, 0xa764, sbUpper}, {0xa765, 0xa765, sbLower}, {0xa766, 0xa766, sbUpper},
+	{0xa767, 0xa767, sbLower}, {0xa768, 0xa768, sbUpper}, {0xa769, 0xa769, sbLower}, {0xa76a, 0xa76a, sbUpper},
+	{0xa76b, 0xa76b, sbLower}, {0xa76c, 0xa76c, sbUpper}, {0xa76d, 0xa76d, sbLower}, {0xa76e, 0xa76e, sbUpper},
+	{0xa76f, 0xa778, sbLower}, {0xa779, 0xa779, sbUpper}, {0xa77a, 0xa77a, sbLower}, {0xa77b, 0xa77b, sbUpper},
+	{0xa77c, 0xa77c, sbLower}, {0xa77d, 0xa77e, sbUpper}, {0xa77f, 0xa77f, sbLower}, {0xa780, 0xa780, sbUpper},
+	{0xa781, 0xa781, sbLower}, {0xa782, 0xa782, sbUpper}, {0xa783, 0xa783, sbLower}, {0xa784, 0xa784, sbUpper},
+	{0xa785, 0xa785, sbLower}, {0xa786, 0xa786, sbUpper}, {0xa787, 0xa787, sbLower}, {0xa788, 0xa788, sbOLetter},
+	{0xa78b, 0xa78b, sbUpper}, {0xa78c, 0xa78c, sbLower}, {0xa78d, 0xa78d, sbUpper}, {0xa78e, 0xa78e, sbLower},
+	{0xa78f, 0xa78f, sbOLetter}, {0xa790, 0xa790, sbUpper}, {0xa791, 0xa791, sbLower}, {0xa792, 0xa792, sbUpper},
+	{0xa793, 0xa795, sbLower}, {0xa796, 0xa796, sbUpper}, {0xa797, 0xa797, sbLower}, {0xa798, 0xa798, sbUpper},
+	{0xa799, 0xa799, sbLower}, {0xa79a, 0xa79a, sbUpper}, {0xa79b, 0xa79b, sbLower}, {0xa79c, 0xa79c, sbUpper},
+	{0xa79d, 0xa79d, sbLower}, {0xa79e, 0xa79e, sbUpper}, {0xa79f, 0xa79f, sbLower}, {0xa7a0, 0xa7a0, sbUpper},
+	{0xa7a1, 0xa7a1, sbLower}, {0xa7a2, 0xa7a2, sbUpper}, {0xa7a3, 0xa7a3, sbLower}, {0xa7a4, 0xa7a4, sbUpper},
+	{0xa7a5, 0xa7a5, sbLower}, {0xa7a6, 0xa7a6, sbUpper}, {0xa7a7, 0xa7a7, sbLower}, {0xa7a8, 0xa7a8, sbUpper},
+	{0xa7a9, 0xa7a9, sbLower}, {0xa7aa, 0xa7ae, sbUpper}, {0xa7af, 0xa7af, sbLower}, {0xa7b0, 0xa7b4, sbUpper},
+	{0xa7b5, 0xa7b5, sbLower}, {0xa7b6, 0xa7b6, sbUpper}, {0xa7b7, 0xa7b7, sbLower}, {0xa7b8, 0xa7b8, sbUpper},
+	{0xa7b9, 0xa7b9, sbLower}, {0xa7ba, 0xa7ba, sbUpper}, {0xa7bb, 0xa7bb, sbLower}, {0xa7bc, 0xa7bc, sbUpper},
+	{0xa7bd, 0xa7bd, sbLower}, {0xa7be, 0xa7be, sbUpper}, {0xa7bf, 0xa7bf, sbLower}, {0xa7c0, 0xa7c0, sbUpper},
+	{0xa7c1, 0xa7c1, sbLower}, {0xa7c2, 0xa7c2, sbUpper}, {0xa7c3, 0xa7c3, sbLower}, {0xa7c4, 0xa7c7, sbUpper},
+	{0xa7c8, 0xa7c8, sbLower}, {0xa7c9, 0xa7c9, sbUpper}, {0xa7ca, 0xa7ca, sbLower}, {0xa7cb, 0xa7cc, sbUpper},
+	{0xa7cd, 0xa7cd, sbLower}, {0xa7ce, 0xa7ce, sbUpper}, {0xa7cf, 0xa7cf, sbLower}, {0xa7d0, 0xa7d0, sbUpper},
+	{0xa7d1, 0xa7d1, sbLower}, {0xa7d2, 0xa7d2, sbUpper}, {0xa7d3, 0xa7d3, sbLower}, {0xa7d4, 0xa7d4, sbUpper},
+	{0xa7d5, 0xa7d5, sbLower}, {0xa7d6, 0xa7d6, sbUpper}, {0xa7d7, 0xa7d7, sbLower}, {0xa7d8, 0xa7d8, sbUpper},
+	{0xa7d9, 0xa7d9, sbLower}, {0xa7da, 0xa7da, sbUpper}, {0xa7db, 0xa7db, sbLower}, {0xa7dc, 0xa7dc, sbUpper},
+	{0xa7f1, 0xa7f4, sbLower}, {0xa7f5, 0xa7f5, sbUpper}, {0xa7f6, 0xa7f6, sbLower}, {0xa7f7, 0xa7f7, sbOLetter},
+	{0xa7f8, 0xa7fa, sbLower}, {0xa7fb, 0xa801, sbOLetter}, {0xa802, 0xa802, sbExtend}, {0xa803, 0xa805, sbOLetter},
+	{0xa806, 0xa806, sbExtend}, {0xa807, 0xa80a, sbOLetter}, {0xa80b, 0xa80b, sbExtend}, {0xa80c, 0xa822, sbOLetter},
+	{0xa823, 0xa827, sbExtend}, {0xa82c, 0xa82c, sbExtend}, {0xa840, 0xa873, sbOLetter}, {0xa876, 0xa877, sbSTerm},
+	{0xa880, 0xa881, sbExtend}, {0xa882, 0xa8b3, sbOLetter}, {0xa8b4, 0xa8c5, sbExtend}, {0xa8ce, 0xa8cf, sbSTerm},
+	{0xa8d0, 0xa8d9, sbNumeric}, {0xa8e0, 0xa8f1, sbExtend}, {0xa8f2, 0xa8f7, sbOLetter}, {0xa8fb, 0xa8fb, sbOLetter},
+	{0xa8fd, 0xa8fe, sbOLetter}, {0xa8ff, 0xa8ff, sbExtend}, {0xa900, 0xa909, sbNumeric}, {0xa90a, 0xa925, sbOLetter},
+	{0xa926, 0xa92d, sbExtend}, {0xa92f, 0xa92f, sbSTerm}, {0xa930, 0xa946, sbOLetter}, {0xa947, 0xa953, sbExtend},
+	{0xa960, 0xa97c, sbOLetter}, {0xa980, 0xa983, sbExtend}, {0xa984, 0xa9b2, sbOLetter}, {0xa9b3, 0xa9c0, sbExtend},
+	{0xa9c8, 0xa9c9, sbSTerm}, {0xa9cf, 0xa9cf, sbOLetter}, {0xa9d0, 0xa9d9, sbNumeric}, {0xa9e0, 0xa9e4, sbOLetter},
+	{0xa9e5, 0xa9e5, sbExtend}, {0xa9e6, 0xa9ef, sbOLetter}, {0xa9f0, 0xa9f9, sbNumeric}, {0xa9fa, 0xa9fe, sbOLetter},
+	{0xaa00, 0xaa28, sbOLetter}, {0xaa29, 0xaa36, sbExtend}, {0xaa40, 0xaa42, sbOLetter}, {0xaa43, 0xaa43, sbExtend},
+	{0xaa44, 0xaa4b, sbOLetter}, {0xaa4c, 0xaa4d, sbExtend}, {0xaa50, 0xaa59, sbNumeric}, {0xaa5d, 0xaa5f, sbSTerm},
+	{0xaa60, 0xaa76, sbOLetter}, {0xaa7a, 0xaa7a, sbOLetter}, {0xaa7b, 0xaa7d, sbExtend}, {0xaa7e, 0xaaaf, sbOLetter},
+	{0xaab0, 0xaab0, sbExtend}, {0xaab1, 0xaab1, sbOLetter}, {0xaab2, 0xaab4, sbExtend}, {0xaab5, 0xaab6, sbOLetter},
+	{0xaab7, 0xaab8, sbExtend}, {0xaab9, 0xaabd, sbOLetter}, {0xaabe, 0xaabf, sbExtend}, {0xaac0, 0xaac0, sbOLetter},
+	{0xaac1, 0xaac1, sbExtend}, {0xaac2, 0xaac2, sbOLetter}, {0xaadb, 0xaadd, sbOLetter}, {0xaae0, 0xaaea, sbOLetter},
+	{0xaaeb, 0xaaef, sbExtend}, {0xaaf0, 0xaaf1, sbSTerm}, {0xaaf2, 0xaaf4, sbOLetter}, {0xaaf5, 0xaaf6, sbExtend},
+	{0xab01, 0xab06, sbOLetter}, {0xab09, 0xab0e, sbOLetter}, {0xab11, 0xab16, sbOLetter}, {0xab20, 0xab26, sbOLetter},
+	{0xab28, 0xab2e, sbOLetter}, {0xab30, 0xab5a, sbLower}, {0xab5c, 0xab69, sbLower}, {0xab70, 0xabbf, sbLower},
+	{0xabc0, 0xabe2, sbOLetter}, {0xabe3, 0xabea, sbExtend}, {0xabeb, 0xabeb, sbSTerm}, {0xabec, 0xabed, sbExtend},
+	{0xabf0, 0xabf9, sbNumeric}, {0xac00, 0xd7a3, sbOLetter}, {0xd7b0, 0xd7c6, sbOLetter}, {0xd7cb, 0xd7fb, sbOLetter},
+	{0xf900, 0xfa6d, sbOLetter}, {0xfa70, 0xfad9, sbOLetter}, {0xfb00, 0xfb06, sbLower}, {0xfb13, 0xfb17, sbLower},
+	{0xfb1d, 0xfb1d, sbOLetter}, {0xfb1e, 0xfb1e, sbExtend}, {0xfb1f, 0xfb28, sbOLetter}, {0xfb2a, 0xfb36, sbOLetter},
+	{0xfb38, 0xfb3c, sbOLetter}, {0xfb3e, 0xfb3e, sbOLetter}, {0xfb40, 0xfb41, sbOLetter}, {0xfb43, 0xfb44, sbOLetter},
+	{0xfb46, 0xfbb1, sbOLetter}, {0xfbd3, 0xfd3d, sbOLetter}, {0xfd3e, 0xfd3f, sbClose}, {0xfd50, 0xfd8f, sbOLetter},
+	{0xfd92, 0xfdc7, sbOLetter}, {0xfdf0, 0xfdfb, sbOLetter}, {0xfe00, 0xfe0f, sbExtend}, {0xfe10, 0xfe11, sbSContinue},
+	{0xfe12, 0xfe12, sbSTerm}, {0xfe13, 0xfe14, sbSContinue}, {0xfe15, 0xfe16, sbSTerm}, {0xfe17, 0xfe18, sbClose},
+	{0xfe20, 0xfe2f, sbExtend}, {0xfe31, 0xfe32, sbSContinue}, {0xfe35, 0xfe44, sbClose}, {0xfe47, 0xfe48, sbClose},
+	{0xfe50, 0xfe51, sbSContinue}, {0xfe52, 0xfe52, sbATerm}, {0xfe54, 0xfe55, sbSContinue}, {0xfe56, 0xfe57, sbSTerm},
+	{0xfe58, 0xfe58, sbSContinue}, {0xfe59, 0xfe5e, sbClose}, {0xfe63, 0xfe63, sbSContinue}, {0xfe70, 0xfe74, sbOLetter},
+	{0xfe76, 0xfefc, sbOLetter}, {0xfeff, 0xfeff, sbFormat}, {0xff01, 0xff01, sbSTerm}, {0xff08, 0xff09, sbClose},
+	{0xff0c, 0xff0d, sbSContinue}, {0xff0e, 0xff0e, sbATerm}, {0xff10, 0xff19, sbNumeric}, {0xff1a, 0xff1b, sbSContinue},
+	{0xff1f, 0xff1f, sbSTerm}, {0xff21, 0xff3a, sbUpper}, {0xff3b, 0xff3b, sbClose}, {0xff3d, 0xff3d, sbClose},
+	{0xff41, 0xff5a, sbLower}, {0xff5b, 0xff5b, sbClose}, {0xff5d, 0xff5d, sbClose}, {0xff5f, 0xff60, sbClose},
+	{0xff61, 0xff61, sbSTerm}, {0xff62, 0xff63, sbClose}, {0xff64, 0xff64, sbSContinue}, {0xff66, 0xff9d, sbOLetter},
+	{0xff9e, 0xff9f, sbExtend}, {0xffa0, 0xffbe, sbOLetter}, {0xffc2, 0xffc7, sbOLetter}, {0xffca, 0xffcf, sbOLetter},
+	{0xffd2, 0xffd7, sbOLetter}, {0xffda, 0xffdc, sbOLetter}, {0xfff9, 0xfffb, sbFormat}, {0x10000, 0x1000b, sbOLetter},
+	{0x1000d, 0x10026, sbOLetter}, {0x10028, 0x1003a, sbOLetter}, {0x1003c, 0x1003d, sbOLetter}, {0x1003f, 0x1004d, sbOLetter},
+	{0x10050, 0x1005d, sbOLetter}, {0x10080, 0x100fa, sbOLetter}, {0x10140, 0x10174, sbOLetter}, {0x101fd, 0x101fd, sbExtend},
+	{0x10280, 0x1029c, sbOLetter}, {0x102a0, 0x102d0, sbOLetter}, {0x102e0, 0x102e0, sbExtend}, {0x10300, 0x1031f, sbOLetter},
+	{0x1032d, 0x1034a, sbOLetter}, {0x10350, 0x10375, sbOLetter}, {0x10376, 0x1037a, sbExtend}, {0x10380, 0x1039d, sbOLetter},
+	{0x103a0, 0x103c3, sbOLetter}, {0x103c8, 0x103cf, sbOLetter}, {0x103d1, 0x103d5, sbOLetter}, {0x10400, 0x10427, sbUpper},
+	{0x10428, 0x1044f, sbLower}, {0x10450, 0x1049d, sbOLetter}, {0x104a0, 0x104a9, sbNumeric}, {0x104b0, 0x104d3, sbUpper},
+	{0x104d8, 0x104fb, sbLower}, {0x10500, 0x10527, sbOLetter}, {0x10530, 0x10563, sbOLetter}, {0x10570, 0x1057a, sbUpper},
+	{0x1057c, 0x1058a, sbUpper}, {0x1058c, 0x10592, sbUpper}, {0x10594, 0x10595, sbUpper}, {0x10597, 0x105a1, sbLower},
+	{0x105a3, 0x105b1, sbLower}, {0x105b3, 0x105b9, sbLower}, {0x105bb, 0x105bc, sbLower}, {0x105c0, 0x105f3, sbOLetter},
+	{0x10600, 0x10736, sbOLetter}, {0x10740, 0x10755, sbOLetter}, {0x10760, 0x10767, sbOLetter}, {0x10780, 0x10780, sbLower},
+	{0x10781, 0x10782, sbOLetter}, {0x10783, 0x10785, sbLower}, {0x10787, 0x107b0, sbLower}, {0x107b2, 0x107ba, sbLower},
+	{0x10800, 0x10805, sbOLetter}, {0x10808, 0x10808, sbOLetter}, {0x1080a, 0x10835, sbOLetter}, {0x10837, 0x10838, sbOLetter},
+	{0x1083c, 0x1083c, sbOLetter}, {0x1083f, 0x10855, sbOLetter}, {0x10860, 0x10876, sbOLetter}, {0x10880, 0x1089e, sbOLetter},
+	{0x108e0, 0x108f2, sbOLetter}, {0x108f4, 0x108f5, sbOLetter}, {0x10900, 0x10915, sbOLetter}, {0x10920, 0x10939, sbOLetter},
+	{0x10940, 0x10959, sbOLetter}, {0x10980, 0x109b7, sbOLetter}, {0x109be, 0x109bf, sbOLetter}, {0x10a00, 0x10a00, sbOLetter},
+	{0x10a01, 0x10a03, sbExtend}, {0x10a05, 0x10a06, sbExtend}, {0x10a0c, 0x10a0f, sbExtend}, {0x10a10, 0x10a13, sbOLetter},
+	{0x10a15, 0x10a17, sbOLetter}, {0x10a19, 0x10a35, sbOLetter}, {0x10a38, 0x10a3a, sbExtend}, {0x10a3f, 0x10a3f, sbExtend},
+	{0x10a56, 0x10a57, sbSTerm}, {0x10a60, 0x10a7c, sbOLetter}, {0x10a80, 0x10a9c, sbOLetter}, {0x10ac0, 0x10ac7, sbOLetter},
+	{0x10ac9, 0x10ae4, sbOLetter}, {0x10ae5, 0x10ae6, sbExtend}, {0x10b00, 0x10b35, sbOLetter}, {0x10b40, 0x10b55, sbOLetter},
+	{0x10b60, 0x10b72, sbOLetter}, {0x10b80, 0x10b91, sbOLetter}, {0x10c00, 0x10c48, sbOLetter}, {0x10c80, 0x10cb2, sbUpper},
+	{0x10cc0, 0x10cf2, sbLower}, {0x10d00, 0x10d23, sbOLetter}, {0x10d24, 0x10d27, sbExtend}, {0x10d30, 0x10d39, sbNumeric},
+	{0x10d40, 0x10d49, sbNumeric}, {0x10d4a, 0x10d4f, sbOLetter}, {0x10d50, 0x10d65, sbUpper}, {0x10d69, 0x10d6d, sbExtend},
+	{0x10d6f, 0x10d6f, sbOLetter}, {0x10d70, 0x10d85, sbLower}, {0x10e80, 0x10ea9, sbOLetter}, {0x10eab, 0x10eac, sbExtend},
+	{0x10eb0, 0x10eb1, sbOLetter}, {0x10ec2, 0x10ec7, sbOLetter}, {0x10efa, 0x10eff, sbExtend}, {0x10f00, 0x10f1c, sbOLetter},
+	{0x10f27, 0x10f27, sbOLetter}, {0x10f30, 0x10f45, sbOLetter}, {0x10f46, 0x10f50, sbExtend}, {0x10f55, 0x10f59, sbSTerm},
+	{0x10f70, 0x10f81, sbOLetter}, {0x10f82, 0x10f85, sbExtend}, {0x10f86, 0x10f89, sbSTerm}, {0x10fb0, 0x10fc4, sbOLetter},
+	{0x10fe0, 0x10ff6, sbOLetter}, {0x11000, 0x11002, sbExtend}, {0x11003, 0x11037, sbOLetter}, {0x11038, 0x11046, sbExtend},
+	{0x11047, 0x11048, sbSTerm}, {0x11066, 0x1106f, sbNumeric}, {0x11070, 0x11070, sbExtend}, {0x11071, 0x11072, sbOLetter},
+	{0x11073, 0x11074, sbExtend}, {0x11075, 0x11075, sbOLetter}, {0x1107f, 0x11082, sbExtend}, {0x11083, 0x110af, sbOLetter},
+	{0x110b0, 0x110ba, sbExtend}, {0x110bd, 0x110bd, sbNumeric}, {0x110be, 0x110c1, sbSTerm}, {0x110c2, 0x110c2, sbExtend},
+	{0x110cd, 0x110cd, sbNumeric}, {0x110d0, 0x110e8, sbOLetter}, {0x110f0, 0x110f9, sbNumeric}, {0x11100, 0x11102, sbExtend},
+	{0x11103, 0x11126, sbOLetter}, {0x11127, 0x11134, sbExtend}, {0x11136, 0x1113f, sbNumeric}, {0x11141, 0x11143, sbSTerm},
+	{0x11144, 0x11144, sbOLetter}, {0x11145, 0x11146, sbExtend}, {0x11147, 0x11147, sbOLetter}, {0x11150, 0x11172, sbOLetter},
+	{0x11173, 0x11173, sbExtend}, {0x11176, 0x11176, sbOLetter}, {0x11180, 0x11182, sbExtend}, {0x11183, 0x111b2, sbOLetter},
+	{0x111b3, 0x111c0, sbExtend}, {0x111c1, 0x111c4, sbOLetter}, {0x111c5, 0x111c6, sbSTerm}, {0x111c9, 0x111cc, sbExtend},
+	{0x111cd, 0x111cd, sbSTerm}, {0x111ce, 0x111cf, sbExtend}, {0x111d0, 0x111d9, sbNumeric}, {0x111da, 0x111da, sbOLetter},
+	{0x111dc, 0x111dc, sbOLetter}, {0x111de, 0x111df, sbSTerm}, {0x11200, 0x11211, sbOLetter}, {0x11213, 0x1122b, sbOLetter},
+	{0x1122c, 0x11237, sbExtend}, {0x11238, 0x11239, sbSTerm}, {0x1123b, 0x1123c, sbSTerm}, {0x1123e, 0x1123e, sbExtend},
+	{0x1123f, 0x11240, sbOLetter}, {0x11241, 0x11241, sbExtend}, {0x11280, 0x11286, sbOLetter}, {0x11288, 0x11288, sbOLetter},
+	{0x1128a, 0x1128d, sbOLetter}, {0x1128f, 0x1129d, sbOLetter}, {0x1129f, 0x112a8, sbOLetter}, {0x112a9, 0x112a9, sbSTerm},
+	{0x112b0, 0x112de, sbOLetter}, {0x112df, 0x112ea, sbExtend}, {0x112f0, 0x112f9, sbNumeric}, {0x11300, 0x11303, sbExtend},
+	{0x11305, 0x1130c, sbOLetter}, {0x1130f, 0x11310, sbOLetter}, {0x11313, 0x11328, sbOLetter}, {0x1132a, 0x11330, sbOLetter},
+	{0x11332, 0x11333, sbOLetter}, {0x11335, 0x11339, sbOLetter}, {0x1133b, 0x1133c, sbExtend}, {0x1133d, 0x1133d, sbOLetter},
+	{0x1133e, 0x11344, sbExtend}, {0x11347, 0x11348, sbExtend}, {0x1134b, 0x1134d, sbExtend}, {0x11350, 0x11350, sbOLetter},
+	{0x11357, 0x11357, sbExtend}, {0x1135d, 0x11361, sbOLetter}, {0x11362, 0x11363, sbExtend}, {0x11366, 0x1136c, sbExtend},
+	{0x11370, 0x11374, sbExtend}, {0x11380, 0x11389, sbOLetter}, {0x1138b, 0x1138b, sbOLetter}, {0x1138e, 0x1138e, sbOLetter},
+	{0x11390, 0x113b5, sbOLetter}, {0x113b7, 0x113b7, sbOLetter}, {0x113b8, 0x113c0, sbExtend}, {0x113c2, 0x113c2, sbExtend},
+	{0x113c5, 0x113c5, sbExtend}, {0x113c7, 0x113ca, sbExtend}, {0x113cc, 0x113d0, sbExtend}, {0x113d1, 0x113d1, sbOLetter},
+	{0x113d2, 0x113d2, sbExtend}, {0x113d3, 0x113d3, sbOLetter}, {0x113d4, 0x113d5, sbSTerm}, {0x113e1, 0x113e2, sbExtend},
+	{0x11400, 0x11434, sbOLetter}, {0x11435, 0x11446, sbExtend}, {0x11447, 0x1144a, sbOLetter}, {0x1144b, 0x1144c, sbSTerm},
+	{0x11450, 0x11459, sbNumeric}, {0x1145e, 0x1145e, sbExtend}, {0x1145f, 0x11461, sbOLetter}, {0x11480, 0x114af, sbOLetter},
+	{0x114b0, 0x114c3, sbExtend}, {0x114c4, 0x114c5, sbOLetter}, {0x114c7, 0x114c7, sbOLetter}, {0x114d0, 0x114d9, sbNumeric},
+	{0x11580, 0x115ae, sbOLetter}, {0x115af, 0x115b5, sbExtend}, {0x115b8, 0x115c0, sbExtend}, {0x115c2, 0x115c3, sbSTerm},
+	{0x115c9, 0x115d7, sbSTerm}, {0x115d8, 0x115db, sbOLetter}, {0x115dc, 0x115dd, sbExtend}, {0x11600, 0x1162f, sbOLetter},
+	{0x11630, 0x11640, sbExtend}, {0x11641, 0x11642, sbSTerm}, {0x11644, 0x11644, sbOLetter}, {0x11650, 0x11659, sbNumeric},
+	{0x11680, 0x116aa, sbOLetter}, {0x116ab, 0x116b7, sbExtend}, {0x116b8, 0x116b8, sbOLetter}, {0x116c0, 0x116c9, sbNumeric},
+	{0x116d0, 0x116e3, sbNumeric}, {0x11700, 0x1171a, sbOLetter}, {0x1171d, 0x1172b, sbExtend}, {0x11730, 0x11739, sbNumeric},
+	{0x1173c, 0x1173e, sbSTerm}, {0x11740, 0x11746, sbOLetter}, {0x11800, 0x1182b, sbOLetter}, {0x1182c, 0x1183a, sbExtend},
+	{0x118a0, 0x118bf, sbUpper}, {0x118c0, 0x118df, sbLower}, {0x118e0, 0x118e9, sbNumeric}, {0x118ff, 0x11906, sbOLetter},
+	{0x11909, 0x11909, sbOLetter}, {0x1190c, 0x11913, sbOLetter}, {0x11915, 0x11916, sbOLetter}, {0x11918, 0x1192f, sbOLetter},
+	{0x11930, 0x11935, sbExtend}, {0x11937, 0x11938, sbExtend}, {0x1193b, 0x1193e, sbExtend}, {0x1193f, 0x1193f, sbOLetter},
+	{0x11940, 0x11940, sbExtend}, {0x11941, 0x11941, sbOLetter}, {0x11942, 0x11943, sbExtend}, {0x11944, 0x11944, sbSTerm},
+	{0x11946, 0x11946, sbSTerm}, {0x11950, 0x11959, sbNumeric}, {0x119a0, 0x119a7, sbOLetter}, {0x119aa, 0x119d0, sbOLetter},
+	{0x119d1, 0x119d7, sbExtend}, {0x119da, 0x119e0, sbExtend}, {0x119e1, 0x119e1, sbOLetter}, {0x119e3, 0x119e3, sbOLetter},
+	{0x119e4, 0x119e4, sbExtend}, {0x11a00, 0x11a00, sbOLetter}, {0x11a01, 0x11a0a, sbExtend}, {0x11a0b, 0x11a32, sbOLetter},
+	{0x11a33, 0x11a39, sbExtend}, {0x11a3a, 0x11a3a, sbOLetter}, {0x11a3b, 0x11a3e, sbExtend}, {0x11a42, 0x11a43, sbSTerm},
+	{0x11a47, 0x11a47, sbExtend}, {0x11a50, 0x11a50, sbOLetter}, {0x11a51, 0x11a5b, sbExtend}, {0x11a5c, 0x11a89, sbOLetter},
+	{0x11a8a, 0x11a99, sbExtend}, {0x11a9b, 0x11a9c, sbSTerm}, {0x11a9d, 0x11a9d, sbOLetter}, {0x11ab0, 0x11af8, sbOLetter},
+	{0x11b60, 0x11b67, sbExtend}, {0x11bc0, 0x11be0, sbOLetter}, {0x11bf0, 0x11bf9, sbNumeric}, {0x11c00, 0x11c08, sbOLetter},
+	{0x11c0a, 0x11c2e, sbOLetter}, {0x11c2f, 0x11c36, sbExtend}, {0x11c38, 0x11c3f, sbExtend}, {0x11c40, 0x11c40, sbOLetter},
+	{0x11c41, 0x11c42, sbSTerm}, {0x11c50, 0x11c59, sbNumeric}, {0x11c72, 0x11c8f, sbOLetter}, {0x11c92, 0x11ca7, sbExtend},
+	{0x11ca9, 0x11cb6, sbExtend}, {0x11d00, 0x11d06, sbOLetter}, {0x11d08, 0x11d09, sbOLetter}, {0x11d0b, 0x11d30, sbOLetter},
+	{0x11d31, 0x11d36, sbExtend}, {0x11d3a, 0x11d3a, sbExtend}, {0x11d3c, 0x11d3d, sbExtend}, {0x11d3f, 0x11d45, sbExtend},
+	{0x11d46, 0x11d46, sbOLetter}, {0x11d47, 0x11d47, sbExtend}, {0x11d50, 0x11d59, sbNumeric}, {0x11d60, 0x11d65, sbOLetter},
+	{0x11d67, 0x11d68, sbOLetter}, {0x11d6a, 0x11d89, sbOLetter}, {0x11d8a, 0x11d8e, sbExtend}, {0x11d90, 0x11d91, sbExtend},
+	{0x11d93, 0x11d97, sbExtend}, {0x11d98, 0x11d98, sbOLetter}, {0x11da0, 0x11da9, sbNumeric}, {0x11db0, 0x11ddb, sbOLetter},
+	{0x11de0, 0x11de9, sbNumeric}, {0x11ee0, 0x11ef2, sbOLetter}, {0x11ef3, 0x11ef6, sbExtend}, {0x11ef7, 0x11ef8, sbSTerm},
+	{0x11f00, 0x11f01, sbExtend}, {0x11f02, 0x11f02, sbOLetter}, {0x11f03, 0x11f03, sbExtend}, {0x11f04, 0x11f10, sbOLetter},
+	{0x11f12, 0x11f33, sbOLetter}, {0x11f34, 0x11f3a, sbExtend}, {0x11f3e, 0x11f42, sbExtend}, {0x11f43, 0x11f44, sbSTerm},
+	{0x11f50, 0x11f59, sbNumeric}, {0x11f5a, 0x11f5a, sbExtend}, {0x11fb0, 0x11fb0, sbOLetter}, {0x12000, 0x12399, sbOLetter},
+	{0x12400, 0x1246e, sbOLetter}, {0x12480, 0x12543, sbOLetter}, {0x12f90, 0x12ff0, sbOLetter}, {0x13000, 0x1342f, sbOLetter},
+	{0x13430, 0x1343f, sbFormat}, {0x13440, 0x13440, sbExtend}, {0x13441, 0x13446, sbOLetter}, {0x13447, 0x13455, sbExtend},
+	{0x13460, 0x143fa, sbOLetter}, {0x14400, 0x14646, sbOLetter}, {0x16100, 0x1611d, sbOLetter}, {0x1611e, 0x1612f, sbExtend},
+	{0x16130, 0x16139, sbNumeric}, {0x16800, 0x16a38, sbOLetter}, {0x16a40, 0x16a5e, sbOLetter}, {0x16a60, 0x16a69, sbNumeric},
+	{0x16a6e, 0x16a6f, sbSTerm}, {0x16a70, 0x16abe, sbOLetter}, {0x16ac0, 0x16ac9, sbNumeric}, {0x16ad0, 0x16aed, sbOLetter},
+	{0x16af0, 0x16af4, sbExtend}, {0x16af5, 0x16af5, sbSTerm}, {0x16b00, 0x16b2f, sbOLetter}, {0x16b30, 0x16b36, sbExtend},
+	{0x16b37, 0x16b38, sbSTerm}, {0x16b40, 0x16b43, sbOLetter}, {0x16b44, 0x16b44, sbSTerm}, {0x16b50, 0x16b59, sbNumeric},
+	{0x16b63, 0x16b77, sbOLetter}, {0x16b7d, 0x16b8f, sbOLetter}, {0x16d40, 0x16d6c, sbOLetter}, {0x16d6e, 0x16d6f, sbSTerm},
+	{0x16d70, 0x16d79, sbNumeric}, {0x16e40, 0x16e5f, sbUpper}, {0x16e60, 0x16e7f, sbLower}, {0x16e98, 0x16e98, sbSTerm},
+	{0x16ea0, 0x16eb8, sbUpper}, {0x16ebb, 0x16ed3, sbLower}, {0x16f00, 0x16f4a, sbOLetter}, {0x16f4f, 0x16f4f, sbExtend},
+	{0x16f50, 0x16f50, sbOLetter}, {0x16f51, 0x16f87, sbExtend}, {0x16f8f, 0x16f92, sbExtend}, {0x16f93, 0x16f9f, sbOLetter},
+	{0x16fe0, 0x16fe1, sbOLetter}, {0x16fe3, 0x16fe3, sbOLetter}, {0x16fe4, 0x16fe4, sbExtend}, {0x16ff0, 0x16ff1, sbExtend},
+	{0x16ff2, 0x16ff6, sbOLetter}, {0x17000, 0x18cd5, sbOLetter}, {0x18cff, 0x18d1e, sbOLetter}, {0x18d80, 0x18df2, sbOLetter},
+	{0x1aff0, 0x1aff3, sbOLetter}, {0x1aff5, 0x1affb, sbOLetter}, {0x1affd, 0x1affe, sbOLetter}, {0x1b000, 0x1b122, sbOLetter},
+	{0x1b132, 0x1b132, sbOLetter}, {0x1b150, 0x1b152, sbOLetter}, {0x1b155, 0x1b155, sbOLetter}, {0x1b164, 0x1b167, sbOLetter},
+	{0x1b170, 0x1b2fb, sbOLetter}, {0x1bc00, 0x1bc6a, sbOLetter}, {0x1bc70, 0x1bc7c, sbOLetter}, {0x1bc80, 0x1bc88, sbOLetter},
+	{0x1bc90, 0x1bc99, sbOLetter}, {0x1bc9d, 0x1bc9e, sbExtend}, {0x1bc9f, 0x1bc9f, sbSTerm}, {0x1bca0, 0x1bca3, sbFormat},
+	{0x1ccf0, 0x1ccf9, sbNumeric}, {0x1cf00, 0x1cf2d, sbExtend}, {0x1cf30, 0x1cf46, sbExtend}, {0x1d165, 0x1d169, sbExtend},
+	{0x1d16d, 0x1d172, sbExtend}, {0x1d173, 0x1d17a, sbFormat}, {0x1d17b, 0x1d182, sbExtend}, {0x1d185, 0x1d18b, sbExtend},
+	{0x1d1aa, 0x1d1ad, sbExtend}, {0x1d242, 0x1d244, sbExtend}, {0x1d400, 0x1d419, sbUpper}, {0x1d41a, 0x1d433, sbLower},
+	{0x1d434, 0x1d44d, sbUpper}, {0x1d44e, 0x1d454, sbLower}, {0x1d456, 0x1d467, sbLower}, {0x1d468, 0x1d481, sbUpper},
+	{0x1d482, 0x1d49b, sbLower}, {0x1d49c, 0x1d49c, sbUpper}, {0x1d49e, 0x1d49f, sbUpper}, {0x1d4a2, 0x1d4a2, sbUpper},
+	{0x1d4a5, 0x1d4a6, sbUpper}, {0x1d4a9, 0x1d4ac, sbUpper}, {0x1d4ae, 0x1d4b5, sbUpper}, {0x1d4b6, 0x1d4b9, sbLower},
+	{0x1d4bb, 0x1d4bb, sbLower}, {0x1d4bd, 0x1d4c3, sbLower}, {0x1d4c5, 0x1d4cf, sbLower}, {0x1d4d0, 0x1d4e9, sbUpper},
+	{0x1d4ea, 0x1d503, sbLower}, {0x1d504, 0x1d505, sbUpper}, {0x1d507, 0x1d50a, sbUpper}, {0x1d50d, 0x1d514, sbUpper},
+	{0x1d516, 0x1d51c, sbUpper}, {0x1d51e, 0x1d537, sbLower}, {0x1d538, 0x1d539, sbUpper}, {0x1d53b, 0x1d53e, sbUpper},
+	{0x1d540, 0x1d544, sbUpper}, {0x1d546, 0x1d546, sbUpper}, {0x1d54a, 0x1d550, sbUpper}, {0x1d552, 0x1d56b, sbLower},
+	{0x1d56c, 0x1d585, sbUpper}, {0x1d586, 0x1d59f, sbLower}, {0x1d5a0, 0x1d5b9, sbUpper}, {0x1d5ba, 0x1d5d3, sbLower},
+	{0x1d5d4, 0x1d5ed, sbUpper}, {0x1d5ee, 0x1d607, sbLower}, {0x1d608, 0x1d621, sbUpper}, {0x1d622, 0x1d63b, sbLower},
+	{0x1d63c, 0x1d655, sbUpper}, {0x1d656, 0x1d66f, sbLower}, {0x1d670, 0x1d689, sbUpper}, {0x1d68a, 0x1d6a5, sbLower},
+	{0x1d6a8, 0x1d6c0, sbUpper}, {0x1d6c2, 0x1d6da, sbLower}, {0x1d6dc, 0x1d6e1, sbLower}, {0x1d6e2, 0x1d6fa, sbUpper},
+	{0x1d6fc, 0x1d714, sbLower}, {0x1d716, 0x1d71b, sbLower}, {0x1d71c, 0x1d734, sbUpper}, {0x1d736, 0x1d74e, sbLower},
+	{0x1d750, 0x1d755, sbLower}, {0x1d756, 0x1d76e, sbUpper}, {0x1d770, 0x1d788, sbLower}, {0x1d78a, 0x1d78f, sbLower},
+	{0x1d790, 0x1d7a8, sbUpper}, {0x1d7aa, 0x1d7c2, sbLower}, {0x1d7c4, 0x1d7c9, sbLower}, {0x1d7ca, 0x1d7ca, sbUpper},
+	{0x1d7cb, 0x1d7cb, sbLower}, {0x1d7ce, 0x1d7ff, sbNumeric}, {0x1da00, 0x1da36, sbExtend}, {0x1da3b, 0x1da6c, sbExtend},
+	{0x1da75, 0x1da75, sbExtend}, {0x1da84, 0x1da84, sbExtend}, {0x1da88, 0x1da88, sbSTerm}, {0x1da9b, 0x1da9f, sbExtend},
+	{0x1daa1, 0x1daaf, sbExtend}, {0x1df00, 0x1df09, sbLower}, {0x1df0a, 0x1df0a, sbOLetter}, {0x1df0b, 0x1df1e, sbLower},
+	{0x1df25, 0x1df2a, sbLower}, {0x1e000, 0x1e006, sbExtend}, {0x1e008, 0x1e018, sbExtend}, {0x1e01b, 0x1e021, sbExtend},
+	{0x1e023, 0x1e024, sbExtend}, {0x1e026, 0x1e02a, sbExtend}, {0x1e030, 0x1e06d, sbLower}, {0x1e08f, 0x1e08f, sbExtend},
+	{0x1e100, 0x1e12c, sbOLetter}, {0x1e130, 0x1e136, sbExtend}, {0x1e137, 0x1e13d, sbOLetter}, {0x1e140, 0x1e149, sbNumeric},
+	{0x1e14e, 0x1e14e, sbOLetter}, {0x1e290, 0x1e2ad, sbOLetter}, {0x1e2ae, 0x1e2ae, sbExtend}, {0x1e2c0, 0x1e2eb, sbOLetter},
+	{0x1e2ec, 0x1e2ef, sbExtend}, {0x1e2f0, 0x1e2f9, sbNumeric}, {0x1e4d0, 0x1e4eb, sbOLetter}, {0x1e4ec, 0x1e4ef, sbExtend},
+	{0x1e4f0, 0x1e4f9, sbNumeric}, {0x1e5d0, 0x1e5ed, sbOLetter}, {0x1e5ee, 0x1e5ef, sbExtend}, {0x1e5f0, 0x1e5f0, sbOLetter},
+	{0x1e5f1, 0x1e5fa, sbNumeric}, {0x1e6c0, 0x1e6de, sbOLetter}, {0x1e6e0, 0x1e6e2, sbOLetter}, {0x1e6e3, 0x1e6e3, sbExtend},
+	{0x1e6e4, 0x1e6e5, sbOLetter}, {0x1e6e6, 0x1e6e6, sbExtend}, {0x1e6e7, 0x1e6ed, sbOLetter}, {0x1e6ee, 0x1e6ef, sbExtend},
+	{0x1e6f0, 0x1e6f4, sbOLetter}, {0x1e6f5, 0x1e6f5, sbExtend}, {0x1e6fe, 0x1e6ff, sbOLetter}, {0x1e7e0, 0x1e7e6, sbOLetter},
+	{0x1e7e8, 0x1e7eb, sbOLetter}, {0x1e7ed, 0x1e7ee, sbOLetter}, {0x1e7f0, 0x1e7fe, sbOLetter}, {0x1e800, 0x1e8c4, sbOLetter},
+	{0x1e8d0, 0x1e8d6, sbExtend}, {0x1e900, 0x1e921, sbUpper}, {0x1e922, 0x1e943, sbLower}, {0x1e944, 0x1e94a, sbExtend},
+	{0x1e94b, 0x1e94b, sbOLetter}, {0x1e950, 0x1e959, sbNumeric}, {0x1ee00, 0x1ee03, sbOLetter}, {0x1ee05, 0x1ee1f, sbOLetter},
+	{0x1ee21, 0x1ee22, sbOLetter}, {0x1ee24, 0x1ee24, sbOLetter}, {0x1ee27, 0x1ee27, sbOLetter}, {0x1ee29, 0x1ee32, sbOLetter},
+	{0x1ee34, 0x1ee37, sbOLetter}, {0x1ee39, 0x1ee39, sbOLetter}, {0x1ee3b, 0x1ee3b, sbOLetter}, {0x1ee42, 0x1ee42, sbOLetter},
+	{0x1ee47, 0x1ee47, sbOLetter}, {0x1ee49, 0x1ee49, sbOLetter}, {0x1ee4b, 0x1ee4b, sbOLetter}, {0x1ee4d, 0x1ee4f, sbOLetter},
+	{0x1ee51, 0x1ee52, sbOLetter}, {0x1ee54, 0x1ee54, sbOLetter}, {0x1ee57, 0x1ee57, sbOLetter}, {0x1ee59, 0x1ee59, sbOLetter},
+	{0x1ee5b, 0x1ee5b, sbOLetter}, {0x1ee5d, 0x1ee5d, sbOLetter}, {0x1ee5f, 0x1ee5f, sbOLetter}, {0x1ee61, 0x1ee62, sbOLetter},
+	{0x1ee64, 0x1ee64, sbOLetter}, {0x1ee67, 0x1ee6a, sbOLetter}, {0x1ee6c, 0x1ee72, sbOLetter}, {0x1ee74, 0x1ee77, sbOLetter},
+	{0x1ee79, 0x1ee7c, sbOLetter}, {0x1ee7e, 0x1ee7e, sbOLetter}, {0x1ee80, 0x1ee89, sbOLetter}, {0x1ee8b, 0x1ee9b, sbOLetter},
+	{0x1eea1, 0x1eea3, sbOLetter}, {0x1eea5, 0x1eea9, sbOLetter}, {0x1eeab, 0x1eebb, sbOLetter}, {0x1f130, 0x1f149, sbUpper},
+	{0x1f150, 0x1f169, sbUpper}, {0x1f170, 0x1f189, sbUpper}, {0x1f676, 0x1f678, sbClose}, {0x1fbf0, 0x1fbf9, sbNumeric},
+	{0x20000, 0x2a6df, sbOLetter}, {0x2a700, 0x2b81d, sbOLetter}, {0x2b820, 0x2cead, sbOLetter}, {0x2ceb0, 0x2ebe0, sbOLetter},
+	{0x2ebf0, 0x2ee5d, sbOLetter}, {0x2f800, 0x2fa1d, sbOLetter}, {0x30000, 0x3134a, sbOLetter}, {0x31350, 0x33479, sbOLetter},
+	{0xe0001, 0xe0001, sbFormat}, {0xe0020, 0xe007f, sbExtend}, {0xe0100, 0xe01ef, sbExtend},
+}
@@ -0,0 +1,202 @@
+package rope
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func collectSegments(r *Rope, iter func(offset int, fn func(start, end int) bool) bool, offset int) (ret []string) {
+	iter(offset, func(start, end int) bool {
+		ret = append(ret, string(r.Sub(start, end-start)))
+		return true
+	})
+	return
+}
+
+// checkSegments checks iterating from every offset in both directions against segments
+func checkSegments(
+	t *testing.T,
+	r *Rope,
+	segments []string,
+	iter func(offset int, fn func(start, end int) bool) bool,
+	iterBackward func(offset int, fn func(start, end int) bool) bool,
+	next func(offset int) int,
+	prev func(offset int) int,
+) {
+	t.Helper()
+	if got := collectSegments(r, iter, 0); !slices.Equal(got, segments) {
+		t.Fatalf("got %q", got)
+	}
+	bounds := []int{0}
+	for _, s := range segments {
+		bounds = append(bounds, bounds[len(bounds)-1]+len(s))
+	}
+	for offset := 0; offset <= r.Len(); offset++ {
+		if offset < r.Len() && !isRuneStart(r.Index(offset)) {
+			continue
+		}
+		i, found := slices.BinarySearch(bounds, offset)
+		from := i
+		if !found {
+			from--
+		}
+		if got := collectSegments(r, iter, offset); !slices.Equal(got, segments[min(from, len(segments)):]) {
+			t.Fatalf("from %d: got %q", offset, got)
+		}
+		backward := slices.Clone(segments[:i])
+		slices.Reverse(backward)
+		if got := collectSegments(r, iterBackward, offset); !slices.Equal(got, backward) {
+			t.Fatalf("backward from %d: got %q", offset, got)
+		}
+		if n := next(offset); offset < r.Len() && n != bounds[from+1] {
+			t.Fatalf("next of %d: %d", offset, n)
+		}
+		if p := prev(offset); offset > 0 && p != bounds[i-1] {
+			t.Fatalf("prev of %d: %d", offset, p)
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	cases := []struct {
+		text   string
+		isWord func(rune) bool
+		words  []string
+	}{
+		{
+			"The quick (“brown”) fox can’t jump 32.3 feet, right?", nil,
+			[]string{"The", " ", "quick", " ", "(", "“", "brown", "”", ")", " ", "fox", " ", "can’t", " ", "jump", " ", "32.3", " ", "feet", ",", " ", "right", "?"},
+		},
+		{
+			"foo_bar.baz(x1)  {\r\n\n", nil,
+			[]string{"foo_bar.baz", "(", "x1", ")", "  ", "{", "\r\n", "\n"},
+		},
+		{
+			"foo_bar.baz(x1)  {\r\n\n", IsIdentifierChar,
+			[]string{"foo_bar", ".", "baz", "(", "x1", ")", "  ", "{", "\r\n", "\n"},
+		},
+		{
+			"$a-b $c", func(ru rune) bool {
+				return ru == '$' || ru == '-' || IsIdentifierChar(ru)
+			},
+			[]string{"$a-b", " ", "$c"},
+		},
+		{
+			"🇯🇵🇺🇸🇫 👨‍👩‍👧 naïve 日本語 カタカナ", nil,
+			[]string{"🇯🇵", "🇺🇸", "🇫", " ", "👨‍👩‍👧", " ", "naïve", " ", "日", "本", "語", " ", "カタカナ"},
+		},
+		{
+			"3,000.5 a1 1a x_ _x", nil,
+			[]string{"3,000.5", " ", "a1", " ", "1a", " ", "x_", " ", "_x"},
+		},
+		{"", nil, nil},
+	}
+	for _, c := range cases {
+		r := NewFromBytes([]byte(c.text))
+		checkSegments(t, r, c.words,
+			func(offset int, fn func(start, end int) bool) bool {
+				return r.IterWords(offset, c.isWord, fn)
+			},
+			func(offset int, fn func(start, end int) bool) bool {
+				return r.IterWordsBackward(offset, c.isWord, fn)
+			},
+			func(offset int) int {
+				return r.NextWord(offset, c.isWord)
+			},
+			func(offset int) int {
+				return r.PrevWord(offset, c.isWord)
+			},
+		)
+	}
+
+	r := NewFromBytes([]byte("hello, world"))
+	if s, e := r.WordAt(9, nil); s != 7 || e != 12 {
+		t.Fatal()
+	}
+	if s, e := r.WordAt(12, nil); s != 12 || e != 12 {
+		t.Fatal()
+	}
+}
+
+func TestSentences(t *testing.T) {
+	cases := []struct {
+		text      string
+		sentences []string
+	}{
+		{
+			"This is a test. This too! Mr. Smith went to e.g. the store.\r\nNew line",
+			[]string{"This is a test. ", "This too! ", "Mr. ", "Smith went to e.g. the store.\r\n", "New line"},
+		},
+		{
+			"He said “Stop.” Then left.\n\nIt costs 3.50 dollars?! Yes.",
+			[]string{"He said “Stop.” ", "Then left.\n", "\n", "It costs 3.50 dollars?! ", "Yes."},
+		},
+		{"", nil},
+	}
+	for _, c := range cases {
+		r := NewFromBytes([]byte(c.text))
+		checkSegments(t, r, c.sentences, r.IterSentences, r.IterSentencesBackward, r.NextSentence, r.PrevSentence)
+	}
+	r := NewFromBytes([]byte("One. Two."))
+	if s, e := r.SentenceAt(6); s != 5 || e != 9 {
+		t.Fatal()
+	}
+}
+
+func TestSegmentsAcrossLeaves(t *testing.T) {
+	text := strings.Repeat("Words, and more_words. Naïve café 3.14!\n", 50)
+	r := NewFromBytes([]byte(text))
+	var words []string
+	r.IterWords(0, nil, func(start, end int) bool {
+		words = append(words, string(r.Sub(start, end-start)))
+		return true
+	})
+	if len(words) != 50*15 || strings.Join(words, "") != text {
+		t.Fatalf("got %d", len(words))
+	}
+	var backward []string
+	r.IterWordsBackward(r.Len(), nil, func(start, end int) bool {
+		backward = append(backward, string(r.Sub(start, end-start)))
+		return true
+	})
+	slices.Reverse(backward)
+	if !slices.Equal(words, backward) {
+		t.Fatal()
+	}
+
+	var sentences []int
+	r.IterSentencesBackward(r.Len(), func(start, _ int) bool {
+		sentences = append(sentences, start)
+		return true
+	})
+	if len(sentences) != 100 {
+		t.Fatalf("got %d", len(sentences))
+	}
+}
+
+func TestWordBreakVectors(t *testing.T) {
+	for _, test := range readBreakTests(t, "testdata/WordBreakTest.txt") {
+		r := NewFromBytes([]byte(test.text))
+		t.Run(test.line, func(t *testing.T) {
+			checkSegments(t, r, test.segments(), func(offset int, fn func(start, end int) bool) bool {
+				return r.IterWords(offset, nil, fn)
+			}, func(offset int, fn func(start, end int) bool) bool {
+				return r.IterWordsBackward(offset, nil, fn)
+			}, func(offset int) int {
+				return r.NextWord(offset, nil)
+			}, func(offset int) int {
+				return r.PrevWord(offset, nil)
+			})
+		})
+	}
+}
+
+func TestSentenceBreakVectors(t *testing.T) {
+	for _, test := range readBreakTests(t, "testdata/SentenceBreakTest.txt") {
+		r := NewFromBytes([]byte(test.text))
+		t.Run(test.line, func(t *testing.T) {
+			checkSegments(t, r, test.segments(), r.IterSentences, r.IterSentencesBackward, r.NextSentence, r.PrevSentence)
+		})
+	}
+}
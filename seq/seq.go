@@ -0,0 +1,226 @@
+// Package seq is a persistent rope of arbitrary elements.
+// It shares weights, AVL balancing, join based split and concat with package rope,
+// without the byte specific parts: hash consing, merkle hashes, lazy loading and chunkers
+package seq
+
+import "github.com/reusee/rope/internal/avl"
+
+type Rope[T any] struct {
+	left    *Rope[T]
+	right   *Rope[T]
+	content []T
+	height  int
+	weight  int
+}
+
+var MaxLengthPerNode = avl.MaxLeafLength
+
+// New returns a rope of copied elems
+func New[T any](elems []T) (ret *Rope[T]) {
+	slots := make([]*Rope[T], 64)
+	for len(elems) > 0 {
+		l := len(elems)
+		if l > MaxLengthPerNode {
+			l = MaxLengthPerNode
+		}
+		content := make([]T, l)
+		copy(content, elems)
+		elems = elems[l:]
+		// slots hold perfect trees of 2^i leaves
+		rope := newLeaf(content)
+		slotIndex := 0
+		for slots[slotIndex] != nil {
+			rope = newNode(slots[slotIndex], rope)
+			slots[slotIndex] = nil
+			slotIndex++
+		}
+		slots[slotIndex] = rope
+	}
+
+	for _, c := range slots {
+		if c != nil {
+			if ret == nil {
+				ret = c
+			} else {
+				ret = c.Concat(ret)
+			}
+		}
+	}
+	return
+}
+
+func (r *Rope[T]) Index(i int) T {
+	if i >= r.weight {
+		return r.right.Index(i - r.weight)
+	}
+	if r.left != nil { // non leaf
+		return r.left.Index(i)
+	}
+	// leaf
+	return r.content[i]
+}
+
+func (r *Rope[T]) Len() int {
+	if r == nil {
+		return 0
+	}
+	return r.weight + r.right.Len()
+}
+
+func (r *Rope[T]) Slice() []T {
+	ret := make([]T, r.Len())
+	i := 0
+	r.Iter(0, func(elems []T) bool {
+		copy(ret[i:], elems)
+		i += len(elems)
+		return true
+	})
+	return ret
+}
+
+func newLeaf[T any](content []T) *Rope[T] {
+	return &Rope[T]{
+		content: content,
+		height:  1,
+		weight:  len(content),
+	}
+}
+
+// newNode returns the node of left and right, which must be non nil and balanced
+func newNode[T any](left, right *Rope[T]) *Rope[T] {
+	return &Rope[T]{
+		left:   left,
+		right:  right,
+		height: max(left.height, right.height) + 1,
+		weight: left.Len(),
+	}
+}
+
+func (r *Rope[T]) Concat(r2 *Rope[T]) *Rope[T] {
+	return avl.Concat(tree[T]{}, r, r2)
+}
+
+func (r *Rope[T]) Split(n int) (out1, out2 *Rope[T]) {
+	return avl.Split(tree[T]{}, r, n)
+}
+
+// tree balances ropes by package avl
+type tree[T any] struct{}
+
+func (tree[T]) Height(r *Rope[T]) int {
+	return r.height
+}
+
+func (tree[T]) Children(r *Rope[T]) (*Rope[T], *Rope[T]) {
+	return r.left, r.right
+}
+
+func (tree[T]) Node(left, right *Rope[T]) *Rope[T] {
+	return newNode(left, right)
+}
+
+func (tree[T]) Cut(r *Rope[T], n int) (*Rope[T], *Rope[T], bool) {
+	if n <= 0 {
+		return nil, r, true
+	}
+	if n >= r.Len() {
+		return r, nil, true
+	}
+	if len(r.content) > 0 { // leaf, halves share the content
+		return newLeaf(r.content[:n:n]), newLeaf(r.content[n:]), true
+	}
+	return nil, nil, false
+}
+
+func (tree[T]) Descend(r, _, _ *Rope[T], n int) (bool, int) {
+	if n >= r.weight { // at right subtree
+		return true, n - r.weight
+	}
+	return false, n
+}
+
+func (r *Rope[T]) Insert(n int, elems []T) *Rope[T] {
+	r1, r2 := r.Split(n)
+	return r1.Concat(New(elems)).Concat(r2)
+}
+
+func (r *Rope[T]) Delete(n, l int) *Rope[T] {
+	r1, r2 := r.Split(n)
+	_, r2 = r2.Split(l)
+	return r1.Concat(r2)
+}
+
+func (r *Rope[T]) Sub(n, l int) []T {
+	ret := make([]T, 0, l)
+	r.Iter(n, func(elems []T) bool {
+		if l >= len(elems) {
+			ret = append(ret, elems...)
+			l -= len(elems)
+			return true
+		}
+		ret = append(ret, elems[:l]...)
+		return false
+	})
+	return ret
+}
+
+func (r *Rope[T]) Iter(offset int, fn func([]T) bool) bool {
+	if r == nil {
+		return true
+	}
+	if len(r.content) > 0 { // leaf
+		if offset < len(r.content) {
+			if !fn(r.content[offset:]) {
+				return false
+			}
+		}
+	} else { // non leaf
+		if offset >= r.weight { // start at right subtree
+			if !r.right.Iter(offset-r.weight, fn) {
+				return false
+			}
+		} else { // start at left subtree
+			if !r.left.Iter(offset, fn) {
+				return false
+			}
+			if !r.right.Iter(0, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IterBackward calls fn with reversed copies of leaves before offset, from the last one
+func (r *Rope[T]) IterBackward(offset int, fn func([]T) bool) bool {
+	if r == nil {
+		return true
+	}
+	if len(r.content) > 0 { // leaf
+		content := r.content[:offset]
+		if len(content) == 0 {
+			return true
+		}
+		reversed := make([]T, len(content))
+		for i, e := range content {
+			reversed[len(content)-i-1] = e
+		}
+		if !fn(reversed) {
+			return false
+		}
+	} else { // non leaf
+		if offset >= r.weight { // start at right subtree
+			if !r.right.IterBackward(offset-r.weight, fn) {
+				return false
+			}
+			if !r.left.IterBackward(r.weight, fn) {
+				return false
+			}
+		} else { // start at left subtree
+			if !r.left.IterBackward(offset, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
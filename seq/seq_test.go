@@ -0,0 +1,255 @@
+package seq
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	MaxLengthPerNode = 8
+	os.Exit(m.Run())
+}
+
+func getRandomInts(l int) []int {
+	ret := make([]int, l)
+	for i := range ret {
+		ret[i] = rand.Int()
+	}
+	return ret
+}
+
+func TestNew(t *testing.T) {
+	if New[int](nil) != nil {
+		t.Fatal()
+	}
+
+	r := New([]string{"foo", "bar", "baz"})
+	if !reflect.DeepEqual(r, &Rope[string]{
+		content: []string{"foo", "bar", "baz"},
+		height:  1,
+		weight:  3,
+	}) {
+		t.Fatal()
+	}
+
+	elems := []int{1, 2, 3}
+	r2 := New(elems)
+	elems[0] = 42
+	if r2.Index(0) != 1 {
+		t.Fatal()
+	}
+
+	for i := 0; i < 1024; i++ {
+		elems := getRandomInts(i)
+		r := New(elems)
+		if r.Len() != i {
+			t.Fatal()
+		}
+		if !slices.Equal(r.Slice(), elems) {
+			t.Fatal()
+		}
+		for j, e := range elems {
+			if r.Index(j) != e {
+				t.Fatal()
+			}
+		}
+	}
+}
+
+func TestConcat(t *testing.T) {
+	for i := 0; i < 512; i++ {
+		elems1 := getRandomInts(i)
+		elems2 := getRandomInts(i)
+		r := New(elems1).Concat(New(elems2))
+		if !slices.Equal(r.Slice(), append(elems1, elems2...)) {
+			t.Fatal()
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	elems := getRandomInts(2048)
+	r := New(elems)
+	for i := 0; i <= len(elems); i++ {
+		r1, r2 := r.Split(i)
+		if !slices.Equal(r1.Slice(), elems[:i]) {
+			t.Fatal()
+		}
+		if !slices.Equal(r2.Slice(), elems[i:]) {
+			t.Fatal()
+		}
+	}
+	if !slices.Equal(r.Slice(), elems) {
+		t.Fatal()
+	}
+}
+
+func TestInsertDelete(t *testing.T) {
+	elems := getRandomInts(128)
+	r := New(elems)
+	for i := 0; i <= len(elems); i++ {
+		inserted := r.Insert(i, []int{-1, -2, -3})
+		if !slices.Equal(inserted.Slice(), slices.Concat(elems[:i], []int{-1, -2, -3}, elems[i:])) {
+			t.Fatal()
+		}
+		for j := 0; j <= len(elems); j++ {
+			k := min(i+j, len(elems))
+			if !slices.Equal(r.Delete(i, j).Slice(), slices.Concat(elems[:i], elems[k:])) {
+				t.Fatal()
+			}
+		}
+	}
+}
+
+func TestSub(t *testing.T) {
+	elems := getRandomInts(128)
+	r := New(elems)
+	for i := 0; i < len(elems); i++ {
+		for j := 0; j < len(elems); j++ {
+			end := min(i+j, len(elems))
+			if !slices.Equal(r.Sub(i, j), elems[i:end]) {
+				t.Fatal()
+			}
+		}
+	}
+}
+
+func TestBalance(t *testing.T) {
+	r := New[int](nil)
+	n := 4096
+	for i := 0; i < n; i++ {
+		r = r.Concat(New([]int{i}))
+	}
+	if r.Len() != n {
+		t.Fatal()
+	}
+	for i := 0; i < n; i++ {
+		if r.Index(i) != i {
+			t.Fatal()
+		}
+	}
+	maxHeight := int(math.Log2(float64(n/MaxLengthPerNode))+1) * 2
+	if r.height > maxHeight {
+		t.Fatal()
+	}
+}
+
+func TestIter(t *testing.T) {
+	elems := getRandomInts(4096)
+	r := New(elems)
+	for i := 0; i < r.Len(); i += 7 {
+		var got []int
+		r.Iter(i, func(es []int) bool {
+			got = append(got, es...)
+			return true
+		})
+		if !slices.Equal(got, elems[i:]) {
+			t.Fatal()
+		}
+	}
+	n := 0
+	r.Iter(0, func([]int) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Fatal()
+	}
+}
+
+func TestIterBackward(t *testing.T) {
+	r := New[int](nil)
+	r.IterBackward(0, func([]int) bool {
+		t.Fatal()
+		return true
+	})
+
+	elems := getRandomInts(1024)
+	r = New(elems)
+	for i := 0; i <= r.Len(); i++ {
+		var got []int
+		r.IterBackward(i, func(es []int) bool {
+			got = append(got, es...)
+			return true
+		})
+		expected := slices.Clone(elems[:i])
+		slices.Reverse(expected)
+		if !slices.Equal(got, expected) {
+			t.Fatal()
+		}
+	}
+	n := 0
+	r.IterBackward(r.Len(), func([]int) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Fatal()
+	}
+}
+
+func checkBalance[T any](r *Rope[T]) error {
+	if r == nil || len(r.content) > 0 {
+		return nil
+	}
+	if r.weight != r.left.Len() {
+		return fmt.Errorf("bad weight")
+	}
+	if r.height != max(r.left.height, r.right.height)+1 {
+		return fmt.Errorf("bad height")
+	}
+	if diff := r.left.height - r.right.height; diff > 1 || diff < -1 {
+		return fmt.Errorf("not balanced")
+	}
+	if err := checkBalance(r.left); err != nil {
+		return err
+	}
+	return checkBalance(r.right)
+}
+
+func TestBalanceInvariants(t *testing.T) {
+	elems := getRandomInts(1024)
+	r := New(elems)
+	for i := 0; i < 2000; i++ {
+		n := rand.Intn(len(elems) + 1)
+		switch rand.Intn(3) {
+		case 0:
+			r = r.Insert(n, []int{i})
+			elems = slices.Insert(elems, n, i)
+		case 1:
+			end := min(n+rand.Intn(10), len(elems))
+			r = r.Delete(n, end-n)
+			elems = slices.Delete(elems, n, end)
+		case 2:
+			r1, r2 := r.Split(n)
+			r = r2.Concat(r1)
+			elems = slices.Concat(elems[n:], elems[:n])
+		}
+		if err := checkBalance(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !slices.Equal(r.Slice(), elems) {
+		t.Fatal()
+	}
+}
+
+func TestPersistent(t *testing.T) {
+	elems := getRandomInts(1024)
+	r := New[int](nil)
+	var ropes []*Rope[int]
+	for _, e := range elems {
+		r = r.Insert(r.Len(), []int{e})
+		ropes = append(ropes, r)
+	}
+	for i, r := range ropes {
+		if !slices.Equal(r.Slice(), elems[:i+1]) {
+			t.Fatal()
+		}
+	}
+}
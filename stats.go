@@ -0,0 +1,139 @@
+package rope
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+var (
+	nodeSize     = int(unsafe.Sizeof(Rope{}))
+	lazyNodeSize = int(unsafe.Sizeof(lazyNode{}))
+	hashSize     = int(unsafe.Sizeof(Hash{}))
+)
+
+type Stats struct {
+	// Nodes is the number of nodes, including leaves
+	Nodes  int
+	Leaves int
+	Bytes  int
+	Height int
+	// AvgDepth is the average depth of leaves, the depth of the root is 1
+	AvgDepth float64
+	// LeafSizes[i] is the number of leaves of length in [2^i, 2^(i+1))
+	LeafSizes []int
+	// DistinctNodes is the number of nodes, counting nodes shared by hash consing once
+	DistinctNodes int
+	// Overhead is the approximate bytes of distinct nodes, excluding content bytes
+	Overhead int
+}
+
+// Stats walks the tree and reports its shape. Lazy nodes are loaded
+func (r *Rope) Stats() (ret Stats) {
+	seen := make(map[*Rope]bool)
+	depthSum := 0
+	var walk func(r *Rope, depth int)
+	walk = func(r *Rope, depth int) {
+		if r == nil {
+			return
+		}
+		r.ensure()
+		ret.Nodes++
+		if !seen[r] {
+			seen[r] = true
+			ret.DistinctNodes++
+			ret.Overhead += r.overhead()
+		}
+		if len(r.content) > 0 { // leaf
+			ret.Leaves++
+			ret.Bytes += len(r.content)
+			depthSum += depth
+			ret.Height = max(ret.Height, depth)
+			i := bits.Len(uint(len(r.content))) - 1
+			for len(ret.LeafSizes) <= i {
+				ret.LeafSizes = append(ret.LeafSizes, 0)
+			}
+			ret.LeafSizes[i]++
+			return
+		}
+		walk(r.left, depth+1)
+		walk(r.right, depth+1)
+	}
+	walk(r, 1)
+	if ret.Leaves > 0 {
+		ret.AvgDepth = float64(depthSum) / float64(ret.Leaves)
+	}
+	return
+}
+
+func (r *Rope) overhead() int {
+	n := nodeSize
+	if r.lazy != nil {
+		n += lazyNodeSize
+	}
+	if r.hash.Load() != nil {
+		n += hashSize
+	}
+	return n
+}
+
+type ShareStats struct {
+	SharedNodes int
+	UniqueNodes int
+	SharedBytes int
+	UniqueBytes int
+}
+
+// ShareStats reports nodes and bytes of r that are shared with other, and those only in r.
+// Bytes of a shared subtree are counted as shared
+func (r *Rope) ShareStats(other *Rope) (ret ShareStats) {
+	nodes := make(map[*Rope]bool)
+	other.iterNodes(func(node *Rope) bool {
+		if nodes[node] {
+			return false
+		}
+		nodes[node] = true
+		return true
+	})
+	r.iterNodes(func(node *Rope) bool {
+		if nodes[node] {
+			ret.SharedNodes++
+			ret.SharedBytes += len(node.content)
+			return true
+		}
+		ret.UniqueNodes++
+		ret.UniqueBytes += len(node.content)
+		return true
+	})
+	return
+}
+
+type CacheStats struct {
+	Entries int
+	// Leaves is the number of leaves keyed by content
+	Leaves int
+	// Nodes is the number of internal nodes keyed by children
+	Nodes int
+	// Hashed is the number of nodes keyed by hash, leaves in Merkle mode and nodes loaded from stores
+	Hashed int
+	// LeafBytes is the total length of leaves keyed by content
+	LeafBytes int
+}
+
+// GetCacheStats reports entries of the global hash consing cache
+func GetCacheStats() (ret CacheStats) {
+	cache.Range(func(k, _ any) bool {
+		key := k.(Key)
+		ret.Entries++
+		switch {
+		case key.left != nil || key.right != nil:
+			ret.Nodes++
+		case key.content != "":
+			ret.Leaves++
+			ret.LeafBytes += len(key.content)
+		default:
+			ret.Hashed++
+		}
+		return true
+	})
+	return
+}
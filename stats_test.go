@@ -0,0 +1,68 @@
+package rope
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	var r *Rope
+	if s := r.Stats(); s.Nodes != 0 || s.AvgDepth != 0 {
+		t.Fatal()
+	}
+
+	r = NewFromBytes(getRandomBytes(64))
+	s := r.Stats()
+	if s.Leaves != 8 || s.Nodes != 15 || s.Bytes != 64 || s.Height != 4 || s.Height != r.height {
+		t.Fatalf("%+v", s)
+	}
+	if s.AvgDepth != 4 {
+		t.Fatal()
+	}
+	if len(s.LeafSizes) != 4 || s.LeafSizes[3] != 8 {
+		t.Fatal()
+	}
+	if s.DistinctNodes != 15 || s.Overhead < 15*nodeSize {
+		t.Fatal()
+	}
+
+	r = r.Insert(3, []byte("foo"))
+	s = r.Stats()
+	if s.Bytes != 67 || s.Leaves != 9 || s.LeafSizes[1] != 0 || s.LeafSizes[2] != 2 || s.LeafSizes[3] != 7 {
+		t.Fatalf("%+v", s)
+	}
+
+	// hash consed leaves
+	r = NewFromString("aaaaaaaaaaaaaaaaaaaaaaaa")
+	s = r.Stats()
+	if s.Leaves != 3 || s.DistinctNodes != 3 {
+		t.Fatalf("%+v", s)
+	}
+}
+
+func TestShareStats(t *testing.T) {
+	r1 := NewFromBytes(getRandomBytes(1024))
+	if s := r1.ShareStats(r1); s.UniqueNodes != 0 || s.SharedBytes != 1024 {
+		t.Fatal()
+	}
+	if s := r1.ShareStats(nil); s.SharedNodes != 0 || s.UniqueBytes != 1024 {
+		t.Fatal()
+	}
+	r2 := r1.Insert(500, []byte("foo"))
+	s := r2.ShareStats(r1)
+	if s.SharedBytes+s.UniqueBytes != 1027 {
+		t.Fatal()
+	}
+	if s.UniqueBytes > 3*MaxLengthPerNode || s.UniqueNodes > 4*r2.height {
+		t.Fatalf("%+v", s)
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	before := GetCacheStats()
+	NewFromBytes(getRandomBytes(64))
+	after := GetCacheStats()
+	if after.Leaves-before.Leaves != 8 || after.LeafBytes-before.LeafBytes != 64 || after.Nodes-before.Nodes != 7 {
+		t.Fatalf("%+v %+v", before, after)
+	}
+	if after.Entries != after.Leaves+after.Nodes+after.Hashed {
+		t.Fatal()
+	}
+}
@@ -0,0 +1,297 @@
+package rope
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
+// Store is a content addressed node storage
+type Store interface {
+	Get(Hash) ([]byte, error)
+	Has(Hash) (bool, error)
+	Put(Hash, []byte) error
+	Delete(Hash) error
+	IterHashes(fn func(Hash) bool) error
+	// Ref adds delta to the reference count of a root and returns the new count
+	Ref(root Hash, delta int) (int, error)
+	// IterRoots iterates roots with positive reference count
+	IterRoots(fn func(Hash) bool) error
+}
+
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrBadNode    = errors.New("bad node")
+	ErrNotInStore = errors.New("rope not in store")
+)
+
+func (r *Rope) fill(store Store, n decodedNode) {
+	if n.leaf {
+		r.content = n.content
+	} else {
+		r.left = stub(store, n.left, n.leftMeta)
+		r.right = stub(store, n.right, n.rightMeta)
+	}
+}
+
+// stub returns a node not loaded yet, or the node of the same hash already in memory
+func stub(store Store, h Hash, meta nodeMeta) *Rope {
+	if h == (Hash{}) {
+		return nil
+	}
+	key := Key{
+		hash: h,
+	}
+	if v, ok := cache.Load(key); ok {
+		return v.(*Rope)
+	}
+	r := &Rope{
+		serial: atomic.AddInt64(&nextSerial, 1),
+		height: meta.height,
+		weight: meta.weight,
+		lazy: &lazyNode{
+			load: func(r *Rope) error {
+				data, err := store.Get(h)
+				if err != nil {
+					return fmt.Errorf("load %v: %w", h, err)
+				}
+				n, err := decodeNode(h, data)
+				if err != nil {
+					return err
+				}
+				if n.nodeMeta != meta {
+					return fmt.Errorf("%w: %v: meta %+v, expected %+v by parent", ErrBadNode, h, n.nodeMeta, meta)
+				}
+				r.fill(store, n)
+				return nil
+			},
+		},
+	}
+	r.hash.Store(&h)
+	v, _ := cache.LoadOrStore(key, r)
+	return v.(*Rope)
+}
+
+// nodeMeta is not in the hash preimage, so decodeNode checks it against the content or metas of children,
+// and metas of children against their own when loaded
+type nodeMeta struct {
+	weight int
+	height int
+	length int
+}
+
+func (r *Rope) meta() nodeMeta {
+	if r == nil {
+		return nodeMeta{}
+	}
+	return nodeMeta{
+		weight: r.weight,
+		height: r.height,
+		length: r.Len(),
+	}
+}
+
+func appendMeta(buf []byte, meta nodeMeta) []byte {
+	buf = binary.AppendUvarint(buf, uint64(meta.weight))
+	buf = binary.AppendUvarint(buf, uint64(meta.height))
+	return binary.AppendUvarint(buf, uint64(meta.length))
+}
+
+func readMeta(buf []byte) (meta nodeMeta, rest []byte, err error) {
+	var fields [3]uint64
+	for i := range fields {
+		v, n := binary.Uvarint(buf)
+		if n <= 0 || v > math.MaxInt {
+			return meta, nil, ErrBadNode
+		}
+		fields[i] = v
+		buf = buf[n:]
+	}
+	meta.weight = int(fields[0])
+	meta.height = int(fields[1])
+	meta.length = int(fields[2])
+	return meta, buf, nil
+}
+
+// encodeNode encodes a node as: meta length, metas, hash preimage.
+// Internal nodes carry metas of children, so children can be loaded lazily
+func encodeNode(r *Rope) []byte {
+	var meta []byte
+	meta = appendMeta(meta, r.meta())
+	var preimage []byte
+	if len(r.content) > 0 { // leaf
+		preimage = append([]byte{leafHashTag}, r.content...)
+	} else { // non leaf
+		meta = appendMeta(meta, r.left.meta())
+		meta = appendMeta(meta, r.right.meta())
+		left := r.left.Hash()
+		right := r.right.Hash()
+		preimage = append([]byte{nodeHashTag}, left[:]...)
+		preimage = append(preimage, right[:]...)
+	}
+	buf := binary.AppendUvarint(nil, uint64(len(meta)))
+	buf = append(buf, meta...)
+	return append(buf, preimage...)
+}
+
+type decodedNode struct {
+	nodeMeta
+	leaf      bool
+	content   []byte
+	left      Hash
+	right     Hash
+	leftMeta  nodeMeta
+	rightMeta nodeMeta
+}
+
+func decodeNode(h Hash, data []byte) (ret decodedNode, err error) {
+	l, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < l {
+		return ret, fmt.Errorf("%w: %v", ErrBadNode, h)
+	}
+	meta := data[n : n+int(l)]
+	preimage := data[n+int(l):]
+	if sha256.Sum256(preimage) != h {
+		return ret, fmt.Errorf("%w: %v", ErrHashMismatch, h)
+	}
+	if ret.nodeMeta, meta, err = readMeta(meta); err != nil {
+		return
+	}
+	switch {
+	case len(preimage) > 1 && preimage[0] == leafHashTag:
+		ret.leaf = true
+		ret.content = preimage[1:]
+	case len(preimage) == 1+2*len(h) && preimage[0] == nodeHashTag:
+		copy(ret.left[:], preimage[1:])
+		copy(ret.right[:], preimage[1+len(h):])
+		if ret.leftMeta, meta, err = readMeta(meta); err != nil {
+			return
+		}
+		if ret.rightMeta, _, err = readMeta(meta); err != nil {
+			return
+		}
+	default:
+		return ret, fmt.Errorf("%w: %v", ErrBadNode, h)
+	}
+	if !ret.consistent() {
+		return ret, fmt.Errorf("%w: %v: inconsistent meta %+v", ErrBadNode, h, ret.nodeMeta)
+	}
+	return
+}
+
+// consistent returns whether the meta agrees with the content or metas of children
+func (n decodedNode) consistent() bool {
+	if n.leaf {
+		return n.weight == len(n.content) && n.length == len(n.content) && n.height == 1
+	}
+	l, r := n.leftMeta, n.rightMeta
+	return n.weight == l.length && n.length == l.length+r.length &&
+		n.height == max(l.height, r.height)+1 && l.height-r.height <= 1 && r.height-l.height <= 1
+}
+
+// Save writes all nodes of r missing in store, children before parents, and returns the root hash
+func Save(store Store, r *Rope) (Hash, error) {
+	var nodes []*Rope
+	var err error
+	r.Missing(func(h Hash) bool {
+		if err != nil {
+			return true
+		}
+		var ok bool
+		ok, err = store.Has(h)
+		return ok
+	}, func(node *Rope) bool {
+		nodes = append(nodes, node)
+		return true
+	})
+	if err != nil {
+		return Hash{}, err
+	}
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if err := store.Put(nodes[i].Hash(), encodeNode(nodes[i])); err != nil {
+			return Hash{}, err
+		}
+	}
+	return r.Hash(), nil
+}
+
+// Load returns the rope of root hash h. Subtrees are loaded on first access.
+// Loading errors in later accesses panic, so roots should be retained by Store.Ref until no longer used
+func Load(store Store, h Hash) (*Rope, error) {
+	if h == (Hash{}) {
+		return nil, nil
+	}
+	data, err := store.Get(h)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", ErrNotInStore, h)
+	} else if err != nil {
+		return nil, err
+	}
+	n, err := decodeNode(h, data)
+	if err != nil {
+		return nil, err
+	}
+	r := stub(store, h, n.nodeMeta)
+	if r.lazy != nil {
+		r.lazy.once.Do(func() {
+			r.fill(store, n)
+		})
+	}
+	return r, nil
+}
+
+// GC deletes nodes not reachable from roots, returns number of deleted nodes
+func GC(store Store) (int, error) {
+	marked := make(map[Hash]bool)
+	var mark func(h Hash) error
+	mark = func(h Hash) error {
+		if h == (Hash{}) || marked[h] {
+			return nil
+		}
+		marked[h] = true
+		data, err := store.Get(h)
+		if err != nil {
+			return fmt.Errorf("mark %v: %w", h, err)
+		}
+		n, err := decodeNode(h, data)
+		if err != nil {
+			return err
+		}
+		if n.leaf {
+			return nil
+		}
+		if err := mark(n.left); err != nil {
+			return err
+		}
+		return mark(n.right)
+	}
+	var err error
+	if e := store.IterRoots(func(h Hash) bool {
+		err = mark(h)
+		return err == nil
+	}); e != nil {
+		return 0, e
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var garbage []Hash
+	if err := store.IterHashes(func(h Hash) bool {
+		if !marked[h] {
+			garbage = append(garbage, h)
+		}
+		return true
+	}); err != nil {
+		return 0, err
+	}
+	for _, h := range garbage {
+		if err := store.Delete(h); err != nil {
+			return 0, err
+		}
+	}
+	return len(garbage), nil
+}
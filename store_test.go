@@ -0,0 +1,282 @@
+package rope
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type countingStore struct {
+	Store
+	gets int
+}
+
+func (s *countingStore) Get(h Hash) ([]byte, error) {
+	s.gets++
+	return s.Store.Get(h)
+}
+
+func TestEncodeNode(t *testing.T) {
+	r := NewFromBytes([]byte("foobarbaz"))
+	data := encodeNode(r)
+	n, err := decodeNode(r.Hash(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.leaf || n.weight != r.weight || n.height != r.height {
+		t.Fatal()
+	}
+	if n.left != r.left.Hash() || n.right != r.right.Hash() {
+		t.Fatal()
+	}
+	if n.leftMeta != r.left.meta() || n.rightMeta != r.right.meta() {
+		t.Fatal()
+	}
+
+	data = encodeNode(r.right)
+	n, err = decodeNode(r.right.Hash(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !n.leaf || string(n.content) != "z" {
+		t.Fatal()
+	}
+
+	// corrupted
+	data[len(data)-1] = 'x'
+	if _, err := decodeNode(r.right.Hash(), data); !errors.Is(err, ErrHashMismatch) {
+		t.Fatal()
+	}
+	if _, err := decodeNode(Hash{}, nil); !errors.Is(err, ErrBadNode) {
+		t.Fatal()
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	fileStore, err := OpenFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &countingStore{
+		Store: fileStore,
+	}
+
+	bs := getRandomBytes(4096)
+	r := NewFromBytes(bs)
+	h, err := Save(store, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h != r.Hash() {
+		t.Fatal()
+	}
+	total := 0
+	if err := store.IterHashes(func(Hash) bool {
+		total++
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(store, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded == r {
+		t.Fatal()
+	}
+	// lazy
+	if loaded.Index(100) != bs[100] {
+		t.Fatal()
+	}
+	if store.gets > r.height+1 {
+		t.Fatalf("%d", store.gets)
+	}
+	if !bytes.Equal(loaded.Bytes(), bs) {
+		t.Fatal()
+	}
+	if store.gets != total {
+		t.Fatalf("%d %d", store.gets, total)
+	}
+	if err := loaded.Verify(h); err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.StructEqual(r) {
+		t.Fatal()
+	}
+
+	// incremental
+	r2 := loaded.Insert(2048, []byte("foobar"))
+	if _, err := Save(store, r2); err != nil {
+		t.Fatal(err)
+	}
+	total2 := 0
+	if err := store.IterHashes(func(Hash) bool {
+		total2++
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if total2-total > total/2 {
+		t.Fatalf("%d %d", total, total2)
+	}
+	loaded2, err := Load(store, r2.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded2.Equal(r2) {
+		t.Fatal()
+	}
+
+	if r, err := Load(store, Hash{}); r != nil || err != nil {
+		t.Fatal()
+	}
+	if _, err := Load(store, NewFromBytes(getRandomBytes(16)).Hash()); !errors.Is(err, ErrNotInStore) {
+		t.Fatal()
+	}
+}
+
+func TestLazyLoadError(t *testing.T) {
+	store, err := OpenFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewFromBytes(getRandomBytes(1024))
+	h, err := Save(store, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(store, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete(r.right.Hash()); err != nil {
+		t.Fatal(err)
+	}
+	func() {
+		defer func() {
+			p := recover()
+			if err, ok := p.(error); !ok || !errors.Is(err, ErrNotFound) {
+				t.Fatal()
+			}
+		}()
+		loaded.Bytes()
+	}()
+}
+
+func TestLoadBadMeta(t *testing.T) {
+	store, err := OpenFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewFromBytes(getRandomBytes(16))
+	h, err := Save(store, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// weight is the first meta byte
+	tamper := func(h Hash, weight byte) {
+		data, err := store.Get(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data[1] = weight
+		if err := store.Put(h, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tamper(h, 3)
+	if _, err := Load(store, h); !errors.Is(err, ErrBadNode) {
+		t.Fatalf("%v", err)
+	}
+	tamper(h, byte(r.weight))
+	tamper(r.left.Hash(), 3)
+	loaded, err := Load(store, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Verify(h); !errors.Is(err, ErrBadNode) {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestGC(t *testing.T) {
+	store, err := OpenFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := func() (n int) {
+		if err := store.IterHashes(func(Hash) bool {
+			n++
+			return true
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	bs := getRandomBytes(4096)
+	r1 := NewFromBytes(bs)
+	r2 := r1.Delete(1000, 10)
+	h1, err := Save(store, r1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n1 := count()
+	h2, err := Save(store, r2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Ref(h1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Ref(h2, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// unreferenced
+	if _, err := Save(store, NewFromBytes(getRandomBytes(1024))); err != nil {
+		t.Fatal(err)
+	}
+	n := count()
+	deleted, err := GC(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted == 0 || count() != n-deleted {
+		t.Fatal()
+	}
+
+	// release r2
+	if n, err := store.Ref(h2, -1); err != nil || n != 0 {
+		t.Fatal()
+	}
+	if _, err := GC(store); err != nil {
+		t.Fatal(err)
+	}
+	if count() != n1 {
+		t.Fatalf("%d %d", count(), n1)
+	}
+	if _, err := Load(store, h2); !errors.Is(err, ErrNotInStore) {
+		t.Fatal()
+	}
+	loaded, err := Load(store, h1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(loaded.Bytes(), bs) {
+		t.Fatal()
+	}
+
+	// release all
+	if _, err := store.Ref(h1, -1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GC(store); err != nil {
+		t.Fatal(err)
+	}
+	if count() != 0 {
+		t.Fatal()
+	}
+}
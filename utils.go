@@ -2,12 +2,7 @@ package rope
 
 import (
 	"bytes"
-	"fmt"
-	"strings"
-)
-
-var (
-	p = fmt.Printf
+	"os"
 )
 
 func (r *Rope) StructEqual(r2 *Rope) bool {
@@ -17,6 +12,8 @@ func (r *Rope) StructEqual(r2 *Rope) bool {
 	if r == nil && r2 != nil || r != nil && r2 == nil {
 		return false
 	}
+	r.ensure()
+	r2.ensure()
 	if r.weight != r2.weight {
 		return false
 	}
@@ -32,18 +29,9 @@ func (r *Rope) StructEqual(r2 *Rope) bool {
 	return true
 }
 
+// Dump prints the tree to stdout
 func (r *Rope) Dump() {
-	r.dump(0, "")
-}
-
-func (r *Rope) dump(level int, prefix string) {
-	p("%s%s%d |%s|\n", strings.Repeat("  ", level), prefix, r.weight, r.content)
-	if r.left != nil {
-		r.left.dump(level+1, "<")
-	}
-	if r.right != nil {
-		r.right.dump(level+1, ">")
-	}
+	r.DumpTo(os.Stdout, DumpText, nil)
 }
 
 func reversedBytes(bs []byte) []byte {
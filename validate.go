@@ -0,0 +1,143 @@
+package rope
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalid = errors.New("invalid rope")
+
+// Validate checks structural invariants of the tree: leaf sizes, weights, heights, AVL balance,
+// and memoized hashes. Lazy nodes are loaded
+func (r *Rope) Validate() error {
+	_, err := r.validate(0)
+	return err
+}
+
+// validate checks the subtree at offset, returning its length
+func (r *Rope) validate(offset int) (int, error) {
+	if r == nil {
+		return 0, nil
+	}
+	if err := r.validateNode(offset); err != nil {
+		return 0, err
+	}
+	if len(r.content) > 0 { // leaf
+		return len(r.content), nil
+	}
+	l, err := r.left.validate(offset)
+	if err != nil {
+		return 0, err
+	}
+	if l != r.weight {
+		return 0, fmt.Errorf("%w: node at %d: weight %d, left length %d", ErrInvalid, offset, r.weight, l)
+	}
+	l2, err := r.right.validate(offset + l)
+	if err != nil {
+		return 0, err
+	}
+	return l + l2, nil
+}
+
+// validateNode checks invariants of r that do not need to descend, assuming children are valid
+func (r *Rope) validateNode(offset int) error {
+	r.ensure()
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s at %d: %s", ErrInvalid, r.kind(), offset, fmt.Sprintf(format, args...))
+	}
+
+	if len(r.content) > 0 { // leaf
+		if r.left != nil || r.right != nil {
+			return invalid("leaf with children")
+		}
+		if len(r.content) > MaxLengthPerNode {
+			return invalid("length %d exceeds MaxLengthPerNode %d", len(r.content), MaxLengthPerNode)
+		}
+		if r.weight != len(r.content) {
+			return invalid("weight %d, length %d", r.weight, len(r.content))
+		}
+		if r.height != 1 {
+			return invalid("height %d", r.height)
+		}
+		if memo := r.hash.Load(); memo != nil && *memo != leafHash(r.content) {
+			return invalid("stale memoized hash")
+		}
+		return nil
+	}
+
+	// non leaf
+	if r.left == nil || r.right == nil {
+		return invalid("empty leaf or missing child")
+	}
+	r.left.ensure()
+	r.right.ensure()
+	if h := max(r.left.height, r.right.height) + 1; r.height != h {
+		return invalid("height %d, expected %d", r.height, h)
+	}
+	if diff := r.left.height - r.right.height; diff > 1 || diff < -1 {
+		return invalid("not balanced, left height %d, right height %d", r.left.height, r.right.height)
+	}
+	if memo := r.hash.Load(); memo != nil {
+		left, right := r.left.hash.Load(), r.right.hash.Load()
+		if left != nil && right != nil && *memo != nodeHash(*left, *right) {
+			return invalid("stale memoized hash")
+		}
+	}
+	return nil
+}
+
+// ValidateCache checks that every hash consed node matches its cache key.
+// Mismatches are caused by modifying bytes after passing them to the rope
+func ValidateCache() (err error) {
+	cache.Range(func(k, v any) bool {
+		key, r := k.(Key), v.(*Rope)
+		switch {
+		case key.left != nil || key.right != nil:
+			if r.left != key.left || r.right != key.right {
+				err = fmt.Errorf("%w: cached node of different children", ErrInvalid)
+			}
+		case key.content != "":
+			if string(r.content) != key.content {
+				err = fmt.Errorf("%w: cached leaf of different content, %q, key %q", ErrInvalid, r.content, key.content)
+			} else if found, ok := cache.Load(key); !ok || found != r {
+				// the key aliases the content, which is modified after caching
+				err = fmt.Errorf("%w: cached leaf not found by content %q", ErrInvalid, key.content)
+			}
+		default:
+			if memo := r.hash.Load(); memo != nil && *memo != key.hash {
+				err = fmt.Errorf("%w: cached node of different hash, %v, key %v", ErrInvalid, *memo, key.hash)
+			} else if memo == nil && r.lazy == nil && len(r.content) > 0 && leafHash(r.content) != key.hash {
+				err = fmt.Errorf("%w: cached leaf of different hash, key %v", ErrInvalid, key.hash)
+			}
+		}
+		return err == nil
+	})
+	return
+}
+
+func (r *Rope) kind() string {
+	if len(r.content) > 0 {
+		return "leaf"
+	}
+	return "node"
+}
+
+// check panics if r is invalid, in debug builds
+func (r *Rope) check() *Rope {
+	if debug {
+		if err := r.Validate(); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// checkNode panics if r itself is invalid, in debug builds
+func (r *Rope) checkNode() *Rope {
+	if debug && r != nil {
+		if err := r.validateNode(0); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
@@ -0,0 +1,99 @@
+package rope
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	var r *Rope
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	r = NewFromBytes(getRandomBytes(1024))
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	r.Hash()
+	if err := r.Insert(500, []byte("foo")).Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	leaf := func(s string) *Rope {
+		return &Rope{
+			content: []byte(s),
+			height:  1,
+			weight:  len(s),
+		}
+	}
+	node := func(left, right *Rope) *Rope {
+		return &Rope{
+			left:   left,
+			right:  right,
+			height: max(left.height, right.height) + 1,
+			weight: left.Len(),
+		}
+	}
+	for _, bad := range []*Rope{
+		// weight
+		{content: []byte("foo"), height: 1, weight: 2},
+		func() *Rope {
+			r := node(leaf("foo"), leaf("bar"))
+			r.weight = 4
+			return r
+		}(),
+		// height
+		{content: []byte("foo"), height: 2, weight: 3},
+		func() *Rope {
+			r := node(leaf("foo"), leaf("bar"))
+			r.height = 3
+			return r
+		}(),
+		// leaf size
+		leaf("foobarbaz"),
+		// empty leaf
+		{height: 1},
+		// balance
+		node(leaf("foo"), node(leaf("bar"), node(leaf("baz"), leaf("qux")))),
+		// stale hash
+		func() *Rope {
+			r := leaf("foo")
+			r.Hash()
+			r.content[0] = 'x'
+			return r
+		}(),
+		func() *Rope {
+			r := node(leaf("foo"), leaf("bar"))
+			r.Hash()
+			r.left.hash.Store(new(Hash))
+			return r
+		}(),
+		// nested
+		node(node(leaf("foo"), leaf("bar")), node(leaf("baz"), &Rope{content: []byte("qux"), height: 1})),
+	} {
+		if err := bad.Validate(); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("got %v", err)
+		}
+	}
+}
+
+func TestValidateCache(t *testing.T) {
+	if err := ValidateCache(); err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("validate")
+	r := newLeaf(content)
+	defer cache.Delete(leafKey("validate"))
+	content[0] = 'x' // copied
+	if err := ValidateCache(); err != nil {
+		t.Fatal(err)
+	}
+	r.content[0] = 'x'
+	if err := ValidateCache(); !errors.Is(err, ErrInvalid) {
+		t.Fatal()
+	}
+	r.content[0] = 'v'
+	if r.Validate() != nil { // the tree itself is fine
+		t.Fatal()
+	}
+}
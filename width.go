@@ -0,0 +1,351 @@
+package rope
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CacheWidths makes nodes memoize their width metrics, so VisualColumn and OffsetAtVisualColumn take O(log n).
+// Metrics are memoized for the last tab width used
+var CacheWidths = false
+
+// RuneWidth returns the number of terminal columns of ru other than tab and line feed.
+// Runes of East_Asian_Width Wide and Fullwidth in width_tables.go, generated by gen_ucd.go, take 2 columns,
+// combining marks, formats, controls and Hangul medial vowels and final consonants take 0
+func RuneWidth(ru rune) int {
+	switch {
+	case ru < 0x20 || 0x7f <= ru && ru < 0xa0:
+		return 0
+	case ru < 0x300: // fast path
+		return 1
+	case ru == 0x200b,
+		unicode.In(ru, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	}
+	switch graphemeProp(ru) {
+	case gbV, gbT:
+		return 0
+	}
+	if lookupProp(wideRunes, ru) {
+		return 2
+	}
+	return 1
+}
+
+// colFn maps the column before some runes of a line to the column after them.
+// With tabs, the column is aligned at the first tab, so it is pre, aligned, then post
+type colFn struct {
+	pre  int
+	tab  bool
+	post int
+}
+
+func alignTab(c, tabWidth int) int {
+	return (c/tabWidth + 1) * tabWidth
+}
+
+func (f colFn) apply(c, tabWidth int) int {
+	if f.tab {
+		return alignTab(c+f.pre, tabWidth) + f.post
+	}
+	return c + f.pre
+}
+
+func (f colFn) then(g colFn, tabWidth int) colFn {
+	switch {
+	case !g.tab && !f.tab:
+		f.pre += g.pre
+	case !g.tab:
+		f.post += g.pre
+	case !f.tab:
+		f = colFn{pre: f.pre + g.pre, tab: true, post: g.post}
+	default:
+		f.post = alignTab(f.post+g.pre, tabWidth) + g.post
+	}
+	return f
+}
+
+// widthMetrics summarizes columns and lines of a byte span, so that spans can be combined without rescanning.
+// Runes split between spans are decoded when spans are combined
+type widthMetrics struct {
+	tabWidth int
+	// leading continuation bytes of a rune started before the span
+	head []byte
+	// whether any rune is started after head
+	started bool
+	// whether any rune is decoded, the last one is CR, and the first one is LF not counted in lines.
+	// A first LF ends a line unless the span follows a CR, which is known when spans are combined
+	decoded bool
+	lastCR  bool
+	firstLF bool
+	lines   int
+	// columns before the first line terminator, and after the last
+	first colFn
+	last  colFn
+	// trailing bytes of an incomplete rune
+	tail []byte
+}
+
+func newWidthMetrics(bs []byte, tabWidth int) *widthMetrics {
+	m := &widthMetrics{tabWidth: tabWidth}
+	m.write(bs)
+	return m
+}
+
+func (m *widthMetrics) clone() *widthMetrics {
+	c := *m
+	c.head = bytes.Clone(m.head)
+	c.tail = bytes.Clone(m.tail)
+	return &c
+}
+
+func (m *widthMetrics) rune(ru rune) {
+	afterCR, first := m.lastCR, !m.decoded && len(m.head) == 0
+	m.decoded, m.lastCR = true, ru == '\r'
+	switch {
+	case ru == '\n' && first:
+		m.firstLF = true
+		return
+	case ru == '\n' && afterCR: // CRLF
+		return
+	case isLineTerminator(ru):
+		m.lines++
+		m.last = colFn{}
+		return
+	}
+	f := colFn{pre: RuneWidth(ru)}
+	if ru == '\t' {
+		f = colFn{tab: true}
+	}
+	if m.lines == 0 {
+		m.first = m.first.then(f, m.tabWidth)
+	} else {
+		m.last = m.last.then(f, m.tabWidth)
+	}
+}
+
+// decode adds runes in bs, keeping an incomplete rune at the end as tail unless final
+func (m *widthMetrics) decode(bs []byte, final bool) {
+	for len(bs) > 0 {
+		if !final && !utf8.FullRune(bs) {
+			m.tail = append(m.tail[:0:0], bs...)
+			return
+		}
+		ru, l := utf8.DecodeRune(bs)
+		m.rune(ru)
+		bs = bs[l:]
+	}
+}
+
+// write appends bytes following the span
+func (m *widthMetrics) write(bs []byte) {
+	if !m.started {
+		n := 0
+		for n < len(bs) && len(m.head)+n < utf8.UTFMax-1 && !isRuneStart(bs[n]) {
+			n++
+		}
+		m.head = append(m.head, bs[:n]...)
+		bs = bs[n:]
+		if len(bs) == 0 {
+			return
+		}
+		m.started = true
+	}
+	if len(m.tail) > 0 {
+		bs = append(m.tail, bs...)
+		m.tail = nil
+	}
+	m.decode(bs, false)
+}
+
+// append appends the span of m2
+func (m *widthMetrics) append(m2 *widthMetrics) {
+	m.write(m2.head)
+	if !m2.started {
+		return
+	}
+	tail := m.tail
+	m.tail = nil
+	m.decode(tail, true) // invalid, followed by a rune start
+	m.started = true
+	if m2.firstLF {
+		m.rune('\n')
+	}
+	if m.lines == 0 {
+		m.first = m.first.then(m2.first, m.tabWidth)
+		if m2.lines > 0 {
+			m.last = m2.last
+		}
+	} else if m2.lines == 0 {
+		m.last = m.last.then(m2.first, m.tabWidth)
+	} else {
+		m.last = m2.last
+	}
+	m.lines += m2.lines
+	if m2.decoded {
+		m.decoded, m.lastCR = true, m2.lastCR
+	}
+	m.tail = bytes.Clone(m2.tail)
+}
+
+// lineCount returns the number of line terminators, if the span starts at the start
+func (m *widthMetrics) lineCount() int {
+	if m.firstLF {
+		return m.lines + 1
+	}
+	return m.lines
+}
+
+// column returns the column at the end of the span, if it starts at a line start
+func (m *widthMetrics) column() int {
+	if m.lines > 0 {
+		return m.last.apply(0, m.tabWidth)
+	}
+	return m.first.apply(len(m.head), m.tabWidth) // invalid bytes
+}
+
+// widthMetrics returns metrics of the subtree, memoized if CacheWidths
+func (r *Rope) widthMetrics(tabWidth int) *widthMetrics {
+	if r == nil {
+		return &widthMetrics{tabWidth: tabWidth}
+	}
+	if m := r.widths.Load(); m != nil && m.tabWidth == tabWidth {
+		return m
+	}
+	r.ensure()
+	var m *widthMetrics
+	if len(r.content) > 0 { // leaf
+		m = newWidthMetrics(r.content, tabWidth)
+	} else {
+		m = r.left.widthMetrics(tabWidth).clone()
+		m.append(r.right.widthMetrics(tabWidth))
+	}
+	if CacheWidths {
+		r.widths.Store(m)
+	}
+	return m
+}
+
+// prefixWidthMetrics returns metrics of the first n bytes
+func (r *Rope) prefixWidthMetrics(n int, tabWidth int) *widthMetrics {
+	if r == nil || n <= 0 {
+		return &widthMetrics{tabWidth: tabWidth}
+	}
+	r.ensure()
+	if len(r.content) > 0 { // leaf
+		return newWidthMetrics(r.content[:min(n, len(r.content))], tabWidth)
+	}
+	if n <= r.weight {
+		return r.left.prefixWidthMetrics(n, tabWidth)
+	}
+	m := r.left.widthMetrics(tabWidth).clone()
+	m.append(r.right.prefixWidthMetrics(n-r.weight, tabWidth))
+	return m
+}
+
+// lineStart returns the offset after the last line terminator before offset, or 0
+func (r *Rope) lineStart(offset int) (ret int) {
+	r.iterRunesBackward(offset, func(ru rune, pos int, size int) bool {
+		if isLineTerminator(ru) {
+			ret = pos + size
+			return false
+		}
+		return true
+	})
+	return
+}
+
+// VisualColumn returns the column of the rune containing offset in its line.
+// Tabs advance to the next multiple of tabWidth, other runes advance by RuneWidth.
+// Without CacheWidths, the line is scanned
+func (r *Rope) VisualColumn(offset int, tabWidth int) int {
+	tabWidth = max(tabWidth, 1)
+	offset = max(min(offset, r.Len()), 0)
+	if offset > 0 && offset < r.Len() && r.terminatorBefore(offset+1) == 2 { // LF of CRLF
+		offset--
+	}
+	if CacheWidths {
+		return r.prefixWidthMetrics(offset, tabWidth).column()
+	}
+	start := r.lineStart(offset)
+	m := &widthMetrics{tabWidth: tabWidth}
+	r.Iter(start, func(bs []byte) bool {
+		bs = bs[:min(len(bs), offset-start)]
+		m.write(bs)
+		start += len(bs)
+		return start < offset
+	})
+	return m.column()
+}
+
+// OffsetAtVisualColumn returns the offset of the rune at column col of the line, counting from 0.
+// If the line is shorter, the offset of its line ending or the end is returned.
+// Without CacheWidths, the line is scanned from its start
+func (r *Rope) OffsetAtVisualColumn(line int, col int, tabWidth int) int {
+	tabWidth = max(tabWidth, 1)
+	if line < 0 {
+		return 0
+	}
+	if !CacheWidths {
+		start, ok := r.seekLine(line)
+		if !ok {
+			return start
+		}
+		ret := r.Len()
+		c := 0
+		r.iterRunes(start, func(ru rune, pos int, _ int) bool {
+			switch {
+			case isLineTerminator(ru):
+				ret = pos
+				return false
+			case ru == '\t':
+				c = alignTab(c, tabWidth)
+			default:
+				c += RuneWidth(ru)
+			}
+			if c > col {
+				ret = pos
+				return false
+			}
+			return true
+		})
+		return ret
+	}
+	// the first offset where reached is true is the end of the rune
+	reached := func(m *widthMetrics) bool {
+		return m.lineCount() > line || m.lineCount() == line && m.column() > col
+	}
+	state := &widthMetrics{tabWidth: tabWidth}
+	pos := 0
+	var seek func(*Rope) bool
+	seek = func(n *Rope) bool {
+		if n == nil {
+			return false
+		}
+		n.ensure()
+		if len(n.content) > 0 { // leaf
+			for i := range n.content {
+				state.write(n.content[i : i+1])
+				pos++
+				if reached(state) {
+					return true
+				}
+			}
+			return false
+		}
+		after := state.clone()
+		after.append(n.widthMetrics(tabWidth))
+		if !reached(after) {
+			state = after
+			pos += n.Len()
+			return false
+		}
+		return seek(n.left) || seek(n.right)
+	}
+	if !seek(r) {
+		return pos
+	}
+	_, l := r.runeBefore(pos)
+	return pos - l
+}
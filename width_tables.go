@@ -0,0 +1,38 @@
+// Code generated by gen_ucd.go from Unicode 17.0.0. DO NOT EDIT.
+
+package rope
+
+// wideRunes are runes of East_Asian_Width Wide and Fullwidth
+var wideRunes = []propRange[bool]{
+	{0x1100, 0x115f, true}, {0x231a, 0x231b, true}, {0x2329, 0x232a, true}, {0x23e9, 0x23ec, true},
+	{0x23f0, 0x23f0, true}, {0x23f3, 0x23f3, true}, {0x25fd, 0x25fe, true}, {0x2614, 0x2615, true},
+	{0x2630, 0x2637, true}, {0x2648, 0x2653, true}, {0x267f, 0x267f, true}, {0x268a, 0x268f, true},
+	{0x2693, 0x2693, true}, {0x26a1, 0x26a1, true}, {0x26aa, 0x26ab, true}, {0x26bd, 0x26be, true},
+	{0x26c4, 0x26c5, true}, {0x26ce, 0x26ce, true}, {0x26d4, 0x26d4, true}, {0x26ea, 0x26ea, true},
+	{0x26f2, 0x26f3, true}, {0x26f5, 0x26f5, true}, {0x26fa, 0x26fa, true}, {0x26fd, 0x26fd, true},
+	{0x2705, 0x2705, true}, {0x270a, 0x270b, true}, {0x2728, 0x2728, true}, {0x274c, 0x274c, true},
+	{0x274e, 0x274e, true}, {0x2753, 0x2755, true}, {0x2757, 0x2757, true}, {0x2795, 0x2797, true},
+	{0x27b0, 0x27b0, true}, {0x27bf, 0x27bf, true}, {0x2b1b, 0x2b1c, true}, {0x2b50, 0x2b50, true},
+	{0x2b55, 0x2b55, true}, {0x2e80, 0x2e99, true}, {0x2e9b, 0x2ef3, true}, {0x2f00, 0x2fd5, true},
+	{0x2ff0, 0x303e, true}, {0x3041, 0x3096, true}, {0x3099, 0x30ff, true}, {0x3105, 0x312f, true},
+	{0x3131, 0x318e, true}, {0x3190, 0x31e5, true}, {0x31ef, 0x321e, true}, {0x3220, 0x3247, true},
+	{0x3250, 0xa48c, true}, {0xa490, 0xa4c6, true}, {0xa960, 0xa97c, true}, {0xac00, 0xd7a3, true},
+	{0xf900, 0xfaff, true}, {0xfe10, 0xfe19, true}, {0xfe30, 0xfe52, true}, {0xfe54, 0xfe66, true},
+	{0xfe68, 0xfe6b, true}, {0xff01, 0xff60, true}, {0xffe0, 0xffe6, true}, {0x16fe0, 0x16fe4, true},
+	{0x16ff0, 0x16ff6, true}, {0x17000, 0x18cd5, true}, {0x18cff, 0x18d1e, true}, {0x18d80, 0x18df2, true},
+	{0x1aff0, 0x1aff3, true}, {0x1aff5, 0x1affb, true}, {0x1affd, 0x1affe, true}, {0x1b000, 0x1b122, true},
+	{0x1b132, 0x1b132, true}, {0x1b150, 0x1b152, true}, {0x1b155, 0x1b155, true}, {0x1b164, 0x1b167, true},
+	{0x1b170, 0x1b2fb, true}, {0x1d300, 0x1d356, true}, {0x1d360, 0x1d376, true}, {0x1f004, 0x1f004, true},
+	{0x1f0cf, 0x1f0cf, true}, {0x1f18e, 0x1f18e, true}, {0x1f191, 0x1f19a, true}, {0x1f200, 0x1f202, true},
+	{0x1f210, 0x1f23b, true}, {0x1f240, 0x1f248, true}, {0x1f250, 0x1f251, true}, {0x1f260, 0x1f265, true},
+	{0x1f300, 0x1f320, true}, {0x1f32d, 0x1f335, true}, {0x1f337, 0x1f37c, true}, {0x1f37e, 0x1f393, true},
+	{0x1f3a0, 0x1f3ca, true}, {0x1f3cf, 0x1f3d3, true}, {0x1f3e0, 0x1f3f0, true}, {0x1f3f4, 0x1f3f4, true},
+	{0x1f3f8, 0x1f43e, true}, {0x1f440, 0x1f440, true}, {0x1f442, 0x1f4fc, true}, {0x1f4ff, 0x1f53d, true},
+	{0x1f54b, 0x1f54e, true}, {0x1f550, 0x1f567, true}, {0x1f57a, 0x1f57a, true}, {0x1f595, 0x1f596, true},
+	{0x1f5a4, 0x1f5a4, true}, {0x1f5fb, 0x1f64f, true}, {0x1f680, 0x1f6c5, true}, {0x1f6cc, 0x1f6cc, true},
+	{0x1f6d0, 0x1f6d2, true}, {0x1f6d5, 0x1f6d8, true}, {0x1f6dc, 0x1f6df, true}, {0x1f6eb, 0x1f6ec, true},
+	{0x1f6f4, 0x1f6fc, true}, {0x1f7e0, 0x1f7eb, true}, {0x1f7f0, 0x1f7f0, true}, {0x1f90c, 0x1f93a, true},
+	{0x1f93c, 0x1f945, true}, {0x1f947, 0x1f9ff, true}, {0x1fa70, 0x1fa7c, true}, {0x1fa80, 0x1fa8a, true},
+	{0x1fa8e, 0x1fac6, true}, {0x1fac8, 0x1fac8, true}, {0x1facd, 0x1fadc, true}, {0x1fadf, 0x1faea, true},
+	{0x1faef, 0x1faf8, true}, {0x20000, 0x2fffd, true}, {0x30000, 0x3fffd, true},
+}
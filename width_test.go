@@ -0,0 +1,127 @@
+package rope
+
+import (
+	mrand "math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// columns returns the column of each offset by scanning runes, -1 inside runes
+func columns(text string, tabWidth int) []int {
+	ret := make([]int, len(text)+1)
+	col := 0
+	prevCR := false
+	for i, ru := range text {
+		ret[i] = col
+		for j := 1; j < utf8.RuneLen(ru); j++ {
+			ret[i+j] = -1
+		}
+		switch {
+		case ru == '\n' && prevCR: // same as CR
+			ret[i] = ret[i-1]
+		case ru == '\n', ru == '\r':
+			col = 0
+		case ru == '\t':
+			col = (col/tabWidth + 1) * tabWidth
+		default:
+			col += RuneWidth(ru)
+		}
+		prevCR = ru == '\r'
+	}
+	ret[len(text)] = col
+	return ret
+}
+
+func TestRuneWidth(t *testing.T) {
+	for ru, w := range map[rune]int{
+		'a':          1,
+		'é':          1,
+		'\u0301':     0,
+		'\u200b':     0,
+		'中':          2,
+		'한':          2,
+		'\u1161':     0,
+		'Ａ':          2,
+		'😀':          2,
+		'⏩':          2,
+		'☔':          2,
+		'🀄':          2,
+		'〿':          1,
+		'\ud7b0':     0,
+		'\U00030000': 2,
+		'\x01':       0,
+	} {
+		if RuneWidth(ru) != w {
+			t.Fatalf("%q", ru)
+		}
+	}
+}
+
+func TestVisualColumn(t *testing.T) {
+	defer func() {
+		CacheWidths = false
+	}()
+	pieces := []string{"a", "bc", "中", "\t", "\n", "\r\n", "\r", "é", "😀", "한글", "  "}
+	for _, cache := range []bool{false, true} {
+		CacheWidths = cache
+		for i := 0; i < 50; i++ {
+			// random edits for various shapes, and runes split between leaves
+			var text string
+			var r *Rope
+			for j, n := 0, mrand.Intn(20); j < n; j++ {
+				piece := strings.Repeat(pieces[mrand.Intn(len(pieces))], 1+mrand.Intn(5))
+				pos := 0
+				if len(text) > 0 {
+					pos = mrand.Intn(len(text) + 1)
+					for pos < len(text) && !utf8.RuneStart(text[pos]) {
+						pos++
+					}
+				}
+				text = text[:pos] + piece + text[pos:]
+				r = r.Insert(pos, []byte(piece))
+			}
+			if string(r.Bytes()) != text {
+				t.Fatal()
+			}
+
+			for _, tabWidth := range []int{4, 8} {
+				cols := columns(text, tabWidth)
+				for offset, col := range cols {
+					if col < 0 {
+						continue
+					}
+					if got := r.VisualColumn(offset, tabWidth); got != col {
+						t.Fatalf("cache %v, %q at %d: got %d, expected %d", cache, text, offset, got, col)
+					}
+				}
+
+				// offsets of columns
+				lines := lineRanges(text)
+				for line, l := range lines {
+					lineStart, lineEnd := l[0], l[1]
+					for col := 0; col < cols[lineEnd]+3; col++ {
+						expected := lineEnd
+						for offset := lineStart; offset < lineEnd; offset++ {
+							if cols[offset] < 0 {
+								continue
+							}
+							ru, _ := utf8.DecodeRuneInString(text[offset:])
+							end := columns(text[lineStart:offset+utf8.RuneLen(ru)], tabWidth)[offset+utf8.RuneLen(ru)-lineStart]
+							if end > col {
+								expected = offset
+								break
+							}
+						}
+						if got := r.OffsetAtVisualColumn(line, col, tabWidth); got != expected {
+							t.Fatalf("cache %v, %q at line %d col %d: got %d, expected %d", cache, text, line, col, got, expected)
+						}
+					}
+				}
+				if got := r.OffsetAtVisualColumn(len(lines), 0, tabWidth); got != len(text) {
+					t.Fatal()
+				}
+			}
+		}
+	}
+}
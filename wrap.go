@@ -0,0 +1,308 @@
+package rope
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/reusee/rope/internal/avl"
+)
+
+type WrapOptions struct {
+	// Width is the number of columns of rows. Lines are not wrapped if not positive
+	Width int
+	// TabWidth defaults to 8
+	TabWidth int
+	// WordWrap breaks rows after spaces or around wide runes, and hangs spaces past the width.
+	// Words longer than the width are broken at runes
+	WordWrap bool
+}
+
+// Wrapped is a rope with an index of visual rows of soft wrapped lines.
+// Lines are leaves of a companion AVL tree weighted by bytes and rows, holding offsets of row breaks,
+// so mapping between offsets and rows takes O(log n), and edits rewrap only the edited lines
+type Wrapped struct {
+	Text    *Rope
+	Options WrapOptions
+	lines   *wrapNode
+}
+
+// NewWrapped wraps all lines of text
+func NewWrapped(text *Rope, opts WrapOptions) Wrapped {
+	if opts.TabWidth <= 0 {
+		opts.TabWidth = 8
+	}
+	w := Wrapped{
+		Text:    text,
+		Options: opts,
+	}
+	w.lines = buildWrapLines(w.wrapLines(0, text.Len(), true))
+	return w
+}
+
+func (w Wrapped) Insert(n int, bs []byte) Wrapped {
+	return w.Update(w.Text.Insert(n, bs), n, 0, len(bs))
+}
+
+func (w Wrapped) Delete(n, l int) Wrapped {
+	l = max(min(l, w.Text.Len()-n), 0)
+	return w.Update(w.Text.Delete(n, l), n, l, 0)
+}
+
+func (w Wrapped) Replace(n, l int, bs []byte) Wrapped {
+	return w.Delete(n, l).Insert(n, bs)
+}
+
+// Update returns the index of text, which is the current text with removed bytes at n replaced by inserted bytes.
+// Only lines touching the edit are rewrapped
+func (w Wrapped) Update(text *Rope, n, removed, inserted int) Wrapped {
+	start, _, _ := w.lines.lineAt(n)
+	if start > 0 && w.Text.Index(start-1) == '\r' { // may become CRLF
+		start, _, _ = w.lines.lineAt(start - 1)
+	}
+	endStart, _, endLine := w.lines.lineAt(n + removed)
+	end := endStart + endLine.bytes
+	before, rest := w.lines.split(start)
+	var after *wrapNode
+	if endLine.bytes > 0 { // not the empty last line
+		_, after = rest.split(end - start)
+	}
+	w.Text = text
+	mid := buildWrapLines(w.wrapLines(start, end-removed+inserted, after == nil))
+	w.lines = before.concat(mid).concat(after)
+	return w
+}
+
+// wrapLines returns leaves of lines in [from, to), which are whole lines, and the last line if last
+func (w Wrapped) wrapLines(from, to int, last bool) (ret []*wrapNode) {
+	start := from
+	w.Text.iterTerminators(from, func(pos, l int) bool {
+		if pos+l > to {
+			return false
+		}
+		ret = append(ret, newWrapLeaf(pos+l-start, w.wrapLine(w.Text.Sub(start, pos-start))))
+		start = pos + l
+		return true
+	})
+	if last { // without terminator
+		ret = append(ret, newWrapLeaf(to-start, w.wrapLine(w.Text.Sub(start, to-start))))
+	}
+	return
+}
+
+// textWidth returns columns of bs from a row start
+func (w Wrapped) textWidth(bs []byte) int {
+	return newWidthMetrics(bs, w.Options.TabWidth).column()
+}
+
+// wrapLine returns offsets of row breaks in line
+func (w Wrapped) wrapLine(line []byte) (breaks []int) {
+	width := w.Options.Width
+	if width <= 0 {
+		return nil
+	}
+	col := 0
+	rowStart := 0
+	opportunity := -1 // last break opportunity in the row
+	prevSpace, prevWide := false, false
+	for i := 0; i < len(line); {
+		ru, l := utf8.DecodeRune(line[i:])
+		space := ru == ' ' || ru == '\t'
+		runeWidth := func() int {
+			if ru == '\t' {
+				return alignTab(col, w.Options.TabWidth) - col
+			}
+			return RuneWidth(ru)
+		}
+		rw := runeWidth()
+		if w.Options.WordWrap && i > rowStart && rw > 0 && (prevSpace && !space || prevWide || rw == 2) {
+			opportunity = i
+		}
+		for col+rw > width && i > rowStart && !(w.Options.WordWrap && space) {
+			brk := i
+			if opportunity > rowStart {
+				brk = opportunity
+			}
+			breaks = append(breaks, brk)
+			rowStart = brk
+			opportunity = -1
+			col = w.textWidth(line[brk:i])
+			rw = runeWidth()
+		}
+		col += rw
+		prevSpace, prevWide = space, rw == 2
+		i += l
+	}
+	return
+}
+
+// Rows returns the number of visual rows
+func (w Wrapped) Rows() int {
+	return w.lines.rows
+}
+
+// Position returns the visual row and column of offset
+func (w Wrapped) Position(offset int) (row, col int) {
+	offset = max(min(offset, w.Text.Len()), 0)
+	if offset > 0 && offset < w.Text.Len() && w.Text.terminatorBefore(offset+1) == 2 { // LF of CRLF
+		offset--
+	}
+	start, rowsBefore, line := w.lines.lineAt(offset)
+	i := sort.SearchInts(line.breaks, offset-start+1) // breaks at or before offset
+	rowStart := start
+	if i > 0 {
+		rowStart += line.breaks[i-1]
+	}
+	return rowsBefore + i, w.textWidth(w.Text.Sub(rowStart, offset-rowStart))
+}
+
+// Row returns the range of the visual row, excluding line terminators
+func (w Wrapped) Row(row int) (start, end int) {
+	row = max(min(row, w.Rows()-1), 0)
+	lineStart, rowsBefore, line := w.lines.rowAt(row)
+	i := row - rowsBefore
+	start, end = lineStart, lineStart+line.bytes
+	if i > 0 {
+		start += line.breaks[i-1]
+	}
+	if i < len(line.breaks) {
+		return start, lineStart + line.breaks[i]
+	}
+	return start, end - min(w.Text.terminatorBefore(end), end-start)
+}
+
+// Offset returns the offset of the rune at the visual row and column, or the end of the row if it is shorter
+func (w Wrapped) Offset(row, col int) int {
+	if row >= w.Rows() {
+		return w.Text.Len()
+	}
+	start, end := w.Row(row)
+	ret := end
+	c := 0
+	w.Text.iterRunes(start, func(ru rune, pos int, size int) bool {
+		if pos >= end {
+			return false
+		}
+		if ru == '\t' {
+			c = alignTab(c, w.Options.TabWidth)
+		} else {
+			c += RuneWidth(ru)
+		}
+		if c > col {
+			ret = pos
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// wrapNode is a node of the AVL tree of lines, balanced by package avl like Rope
+type wrapNode struct {
+	left   *wrapNode
+	right  *wrapNode
+	breaks []int // of leaf, offsets of row starts other than the first
+	height int
+	bytes  int
+	rows   int
+}
+
+func newWrapLeaf(length int, breaks []int) *wrapNode {
+	return &wrapNode{
+		breaks: breaks,
+		height: 1,
+		bytes:  length,
+		rows:   len(breaks) + 1,
+	}
+}
+
+func newWrapNode(left, right *wrapNode) *wrapNode {
+	return &wrapNode{
+		left:   left,
+		right:  right,
+		height: max(left.height, right.height) + 1,
+		bytes:  left.bytes + right.bytes,
+		rows:   left.rows + right.rows,
+	}
+}
+
+func buildWrapLines(lines []*wrapNode) *wrapNode {
+	switch len(lines) {
+	case 0:
+		return nil
+	case 1:
+		return lines[0]
+	}
+	half := len(lines) / 2
+	return newWrapNode(buildWrapLines(lines[:half]), buildWrapLines(lines[half:]))
+}
+
+func (n *wrapNode) concat(n2 *wrapNode) *wrapNode {
+	return avl.Concat(wrapTree{}, n, n2)
+}
+
+// split returns lines starting before offset n, and the others
+func (n *wrapNode) split(offset int) (out1, out2 *wrapNode) {
+	return avl.Split(wrapTree{}, n, offset)
+}
+
+type wrapTree struct{}
+
+func (wrapTree) Height(n *wrapNode) int {
+	return n.height
+}
+
+func (wrapTree) Children(n *wrapNode) (*wrapNode, *wrapNode) {
+	return n.left, n.right
+}
+
+func (wrapTree) Node(left, right *wrapNode) *wrapNode {
+	return newWrapNode(left, right)
+}
+
+func (wrapTree) Cut(n *wrapNode, offset int) (*wrapNode, *wrapNode, bool) {
+	if n.left != nil {
+		return nil, nil, false
+	}
+	// leaf
+	if offset > 0 {
+		return n, nil, true
+	}
+	return nil, n, true
+}
+
+func (wrapTree) Descend(_, left, _ *wrapNode, offset int) (bool, int) {
+	if offset <= left.bytes {
+		return false, offset
+	}
+	return true, offset - left.bytes
+}
+
+// lineAt returns the line containing offset, its start, and rows before it. The end is in the last line
+func (n *wrapNode) lineAt(offset int) (start, rows int, line *wrapNode) {
+	for n.left != nil {
+		if offset < n.left.bytes {
+			n = n.left
+		} else {
+			offset -= n.left.bytes
+			start += n.left.bytes
+			rows += n.left.rows
+			n = n.right
+		}
+	}
+	return start, rows, n
+}
+
+// rowAt returns the line containing the row, its start, and rows before it
+func (n *wrapNode) rowAt(row int) (start, rows int, line *wrapNode) {
+	for n.left != nil {
+		if row < n.left.rows {
+			n = n.left
+		} else {
+			row -= n.left.rows
+			start += n.left.bytes
+			rows += n.left.rows
+			n = n.right
+		}
+	}
+	return start, rows, n
+}
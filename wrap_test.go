@@ -0,0 +1,134 @@
+package rope
+
+import (
+	mrand "math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func checkWrapLines(n *wrapNode) bool {
+	if n.left == nil {
+		return n.right == nil && n.height == 1 && n.rows == len(n.breaks)+1
+	}
+	if diff := n.left.height - n.right.height; diff > 1 || diff < -1 {
+		return false
+	}
+	return n.height == max(n.left.height, n.right.height)+1 &&
+		n.bytes == n.left.bytes+n.right.bytes &&
+		n.rows == n.left.rows+n.right.rows &&
+		checkWrapLines(n.left) && checkWrapLines(n.right)
+}
+
+func wrappedRows(w Wrapped) (ret []string) {
+	for row := 0; row < w.Rows(); row++ {
+		start, end := w.Row(row)
+		ret = append(ret, string(w.Text.Sub(start, end-start)))
+	}
+	return
+}
+
+func TestWrapped(t *testing.T) {
+	cases := []struct {
+		text string
+		opts WrapOptions
+		rows []string
+	}{
+		{"hello world foo bar", WrapOptions{Width: 10, WordWrap: true}, []string{"hello ", "world foo ", "bar"}},
+		{"hello world foo bar", WrapOptions{Width: 10}, []string{"hello worl", "d foo bar"}},
+		{"hello     world", WrapOptions{Width: 6, WordWrap: true}, []string{"hello     ", "world"}},
+		{"abcdefghijklmnop", WrapOptions{Width: 5, WordWrap: true}, []string{"abcde", "fghij", "klmno", "p"}},
+		{"中文中文中文", WrapOptions{Width: 5}, []string{"中文", "中文", "中文"}},
+		{"ab中文", WrapOptions{Width: 5, WordWrap: true}, []string{"ab中", "文"}},
+		{"\tab\tc", WrapOptions{Width: 8, TabWidth: 4}, []string{"\tab\t", "c"}},
+		{"foo\r\nbar baz\n", WrapOptions{Width: 4, WordWrap: true}, []string{"foo", "bar ", "baz", ""}},
+		{"ab\rcd\r", WrapOptions{Width: 4}, []string{"ab", "cd", ""}},
+		{"", WrapOptions{Width: 4}, []string{""}},
+		{"no wrap\n", WrapOptions{}, []string{"no wrap", ""}},
+	}
+	for _, c := range cases {
+		w := NewWrapped(NewFromBytes([]byte(c.text)), c.opts)
+		if got := wrappedRows(w); !slices.Equal(got, c.rows) {
+			t.Fatalf("%q: got %q", c.text, got)
+		}
+	}
+
+	w := NewWrapped(NewFromBytes([]byte("hello world\nfoo")), WrapOptions{Width: 8, WordWrap: true})
+	for _, c := range []struct {
+		offset, row, col int
+	}{
+		{0, 0, 0},
+		{5, 0, 5},
+		{6, 1, 0},
+		{11, 1, 5},
+		{12, 2, 0},
+		{15, 2, 3},
+	} {
+		if row, col := w.Position(c.offset); row != c.row || col != c.col {
+			t.Fatalf("%d: got %d %d", c.offset, row, col)
+		}
+	}
+	if w.Offset(1, 2) != 8 || w.Offset(1, 100) != 11 || w.Offset(100, 0) != 15 {
+		t.Fatal()
+	}
+}
+
+func TestWrappedEdits(t *testing.T) {
+	pieces := []string{"a", "word ", "中文", "\t", "\n", "\r\n", "\r", "  ", "longerword", "😀"}
+	for _, opts := range []WrapOptions{
+		{Width: 6},
+		{Width: 7, WordWrap: true},
+		{Width: 1, WordWrap: true},
+		{Width: 12, TabWidth: 4, WordWrap: true},
+	} {
+		w := NewWrapped(nil, opts)
+		text := ""
+		for i := 0; i < 200; i++ {
+			pos := mrand.Intn(len(text) + 1)
+			for pos < len(text) && !utf8.RuneStart(text[pos]) {
+				pos++
+			}
+			if mrand.Intn(3) > 0 || len(text) == 0 {
+				piece := strings.Repeat(pieces[mrand.Intn(len(pieces))], 1+mrand.Intn(3))
+				w = w.Insert(pos, []byte(piece))
+				text = text[:pos] + piece + text[pos:]
+			} else {
+				end := min(pos+mrand.Intn(10), len(text))
+				for end < len(text) && !utf8.RuneStart(text[end]) {
+					end++
+				}
+				w = w.Delete(pos, end-pos)
+				text = text[:pos] + text[end:]
+			}
+
+			if !checkWrapLines(w.lines) {
+				t.Fatal("bad tree")
+			}
+			// same as wrapping from scratch
+			fresh := NewWrapped(NewFromBytes([]byte(text)), opts)
+			if got, expected := wrappedRows(w), wrappedRows(fresh); !slices.Equal(got, expected) {
+				t.Fatalf("got %q, expected %q", got, expected)
+			}
+		}
+
+		// round trip
+		lastRow, lastCol := 0, -1
+		for offset := 0; offset <= len(text); offset++ {
+			if offset < len(text) && !utf8.RuneStart(text[offset]) {
+				continue
+			}
+			row, col := w.Position(offset)
+			if row < lastRow || row == lastRow && col < lastCol {
+				t.Fatalf("not monotonic at %d", offset)
+			}
+			lastRow, lastCol = row, col
+			if offset > 0 && text[offset-1:min(offset+1, len(text))] == "\r\n" {
+				continue // same as CR
+			}
+			if got := w.Offset(row, col); got != offset {
+				t.Fatalf("%q: offset %d at %d %d, got %d", text, offset, row, col, got)
+			}
+		}
+	}
+}